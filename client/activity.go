@@ -0,0 +1,214 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ActivityStatus is the status of a Landscape activity.
+type ActivityStatus string
+
+// Activity statuses reported by Landscape.
+const (
+	ActivityStatusUnapproved  ActivityStatus = "unapproved"
+	ActivityStatusUndelivered ActivityStatus = "undelivered"
+	ActivityStatusDelivered   ActivityStatus = "delivered"
+	ActivityStatusSucceeded   ActivityStatus = "succeeded"
+	ActivityStatusFailed      ActivityStatus = "failed"
+	ActivityStatusCanceled    ActivityStatus = "canceled"
+)
+
+// Done reports whether s is a final status.
+func (s ActivityStatus) Done() bool {
+	return s == ActivityStatusSucceeded || s == ActivityStatusFailed || s == ActivityStatusCanceled
+}
+
+// WaitOptions configures WaitForActivity.
+type WaitOptions struct {
+	// PollInterval is the delay before the first poll. It doubles after each
+	// poll, up to MaxPollInterval. Defaults to 2 seconds.
+	PollInterval time.Duration
+
+	// MaxPollInterval caps the delay between polls. Defaults to 30 seconds.
+	MaxPollInterval time.Duration
+
+	// OnPoll, if set, is called with the state of the activity after each
+	// poll, ex. to report progress.
+	OnPoll func(*ActivityResult)
+}
+
+// ActivityResult is the state of an activity and its children.
+type ActivityResult struct {
+	// Activity The activity as last returned by the server.
+	Activity Activity `json:"activity"`
+
+	// Status The overall status. Once every computer is done, this is
+	// failed if any computer failed, canceled if any was canceled, and
+	// succeeded otherwise.
+	Status ActivityStatus `json:"status"`
+
+	// Computers The result on each computer the activity targets.
+	Computers []ComputerActivityResult `json:"computers"`
+}
+
+// ComputerActivityResult is the result of an activity on one computer.
+type ComputerActivityResult struct {
+	// ComputerId The ID of the computer.
+	ComputerId int `json:"computer_id"`
+
+	// ActivityId The ID of the activity for this computer.
+	ActivityId int `json:"activity_id"`
+
+	// Status The status of the activity on this computer.
+	Status ActivityStatus `json:"status"`
+
+	// ResultCode The exit code reported by the computer, ex. of a script.
+	ResultCode *int `json:"result_code,omitempty"`
+
+	// Output The output reported by the computer, ex. of a script.
+	Output string `json:"output,omitempty"`
+}
+
+// Done reports whether the activity has finished on every computer.
+func (r *ActivityResult) Done() bool {
+	return r.Status.Done()
+}
+
+// Counts returns how many computers are in each status.
+func (r *ActivityResult) Counts() map[ActivityStatus]int {
+	counts := map[ActivityStatus]int{}
+	for _, c := range r.Computers {
+		counts[c.Status]++
+	}
+	return counts
+}
+
+// Err returns an error describing the activity if it failed or was canceled,
+// and nil otherwise.
+func (r *ActivityResult) Err() error {
+	switch r.Status {
+	case ActivityStatusFailed, ActivityStatusCanceled:
+		counts := r.Counts()
+		return fmt.Errorf("activity %d %s (%d succeeded, %d failed, %d canceled)", r.Activity.Id, r.Status,
+			counts[ActivityStatusSucceeded], counts[ActivityStatusFailed], counts[ActivityStatusCanceled])
+	}
+	return nil
+}
+
+// WaitForActivity polls the activity with the given ID until it is done on
+// every computer it targets, following its child activities, and returns the
+// final result. A failed or canceled activity is not an error; check the
+// result's Status or call its Err method.
+//
+// If ctx is done first, the last result seen is returned with the context's
+// error. opts may be nil.
+func WaitForActivity(ctx context.Context, api *ClientWithResponses, activityID int, opts *WaitOptions) (*ActivityResult, error) {
+	interval, maxInterval := 2*time.Second, 30*time.Second
+	var onPoll func(*ActivityResult)
+	if opts != nil {
+		if opts.PollInterval > 0 {
+			interval = opts.PollInterval
+		}
+		if opts.MaxPollInterval > 0 {
+			maxInterval = opts.MaxPollInterval
+		}
+		onPoll = opts.OnPoll
+	}
+
+	var last *ActivityResult
+	for {
+		result, err := GetActivityResult(ctx, api, activityID)
+		if err != nil {
+			return last, err
+		}
+		last = result
+
+		if onPoll != nil {
+			onPoll(result)
+		}
+		if result.Done() {
+			return result, nil
+		}
+
+		if err := sleepContext(ctx, interval); err != nil {
+			return last, err
+		}
+		interval = min(interval*2, maxInterval)
+	}
+}
+
+// GetActivityResult fetches the current state of the activity with the given
+// ID and its children. It returns an error matching ErrNotFound if there is no
+// such activity.
+func GetActivityResult(ctx context.Context, api *ClientWithResponses, activityID int) (*ActivityResult, error) {
+	query := fmt.Sprintf("id:%d", activityID)
+	activities, err := api.GetActivitiesTyped(ctx, &LegacyGetActivitiesParams{Query: &query})
+	if err != nil {
+		return nil, err
+	}
+	if len(activities) == 0 {
+		return nil, fmt.Errorf("activity %d: %w", activityID, ErrNotFound)
+	}
+	activity := activities[0]
+
+	children := activity.Children
+	if len(children) == 0 && activity.ComputerId == nil {
+		query := fmt.Sprintf("parent-id:%d", activityID)
+		children, err = CollectAll(Paginate(ctx, 0, GetActivitiesPages(api, &LegacyGetActivitiesParams{Query: &query})))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return newActivityResult(activity, children), nil
+}
+
+func newActivityResult(activity Activity, children []Activity) *ActivityResult {
+	result := &ActivityResult{Activity: activity}
+
+	leaves := children
+	if len(leaves) == 0 {
+		leaves = []Activity{activity}
+	}
+
+	done := true
+	var failed, canceled bool
+	for _, a := range leaves {
+		status := ActivityStatus(a.ActivityStatus)
+
+		c := ComputerActivityResult{ActivityId: a.Id, Status: status, ResultCode: a.ResultCode}
+		if a.ComputerId != nil {
+			c.ComputerId = *a.ComputerId
+		}
+		if a.ResultText != nil {
+			c.Output = *a.ResultText
+		}
+		if a.ComputerId != nil || len(children) > 0 {
+			result.Computers = append(result.Computers, c)
+		}
+
+		done = done && status.Done()
+		failed = failed || status == ActivityStatusFailed
+		canceled = canceled || status == ActivityStatusCanceled
+	}
+
+	switch {
+	case !done:
+		result.Status = ActivityStatus(activity.ActivityStatus)
+		if result.Status.Done() {
+			// The group can report a final status before its children do.
+			result.Status = ActivityStatusDelivered
+		}
+	case failed:
+		result.Status = ActivityStatusFailed
+	case canceled:
+		result.Status = ActivityStatusCanceled
+	default:
+		result.Status = ActivityStatusSucceeded
+	}
+
+	return result
+}
@@ -0,0 +1,135 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWaitForActivity(t *testing.T) {
+	var polls atomic.Int32
+
+	computer := func(id int) *int { return &id }
+	text := func(s string) *string { return &s }
+	code := func(c int) *int { return &c }
+
+	handler := http.NewServeMux()
+	handler.HandleFunc("/api/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("action") != "GetActivities" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		var activities []Activity
+		switch r.URL.Query().Get("query") {
+		case "id:601":
+			n := polls.Add(1)
+			group := Activity{Id: 601, Type: "ActivityGroup", ActivityStatus: "delivered"}
+			second := Activity{Id: 603, ComputerId: computer(13), ParentId: code(601), ActivityStatus: "delivered"}
+			if n >= 3 {
+				group.ActivityStatus = "failed"
+				second.ActivityStatus = "failed"
+				second.ResultCode = code(1)
+				second.ResultText = text("no space left on device\n")
+			}
+			group.Children = []Activity{
+				{Id: 602, ComputerId: computer(12), ParentId: code(601), ActivityStatus: "succeeded", ResultCode: code(0), ResultText: text("deployed\n")},
+				second,
+			}
+			activities = []Activity{group}
+		case "id:701":
+			activities = []Activity{{Id: 701, Type: "ActivityGroup", ActivityStatus: "succeeded"}}
+		case "parent-id:701":
+			if r.URL.Query().Get("offset") == "0" {
+				activities = []Activity{{Id: 702, ComputerId: computer(12), ParentId: code(701), ActivityStatus: "succeeded", ResultText: text("ok\n")}}
+			}
+		case "id:801":
+			activities = []Activity{{Id: 801, Type: "ActivityGroup", ActivityStatus: "canceled"}}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(activities)
+	})
+
+	server := httptest.NewTLSServer(handler)
+	defer server.Close()
+
+	api, err := NewClientWithResponses(server.URL, WithHTTPClient(server.Client()), WithLegacyParamEncoding())
+	if err != nil {
+		t.Fatalf("failed to init client with responses: %v", err)
+	}
+
+	ctx := context.Background()
+	opts := &WaitOptions{PollInterval: time.Millisecond, MaxPollInterval: 2 * time.Millisecond}
+
+	t.Run("polls until every child is done", func(t *testing.T) {
+		var seen []ActivityStatus
+		opts := *opts
+		opts.OnPoll = func(r *ActivityResult) { seen = append(seen, r.Status) }
+
+		result, err := WaitForActivity(ctx, api, 601, &opts)
+		if err != nil {
+			t.Fatalf("WaitForActivity failed: %v", err)
+		}
+
+		if polls.Load() != 3 || len(seen) != 3 || seen[0] != ActivityStatusDelivered {
+			t.Fatalf("expected 3 polls, got %d: %v", polls.Load(), seen)
+		}
+		if result.Status != ActivityStatusFailed || result.Err() == nil {
+			t.Fatalf("expected failed activity, got %s", result.Status)
+		}
+
+		counts := result.Counts()
+		if counts[ActivityStatusSucceeded] != 1 || counts[ActivityStatusFailed] != 1 {
+			t.Fatalf("unexpected per-computer breakdown: %v", counts)
+		}
+		if c := result.Computers[1]; c.ComputerId != 13 || *c.ResultCode != 1 || c.Output != "no space left on device\n" {
+			t.Fatalf("unexpected computer result: %+v", c)
+		}
+	})
+
+	t.Run("fetches children that aren't embedded", func(t *testing.T) {
+		result, err := WaitForActivity(ctx, api, 701, opts)
+		if err != nil {
+			t.Fatalf("WaitForActivity failed: %v", err)
+		}
+		if result.Status != ActivityStatusSucceeded || result.Err() != nil || len(result.Computers) != 1 || result.Computers[0].Output != "ok\n" {
+			t.Fatalf("unexpected result: %+v", result)
+		}
+	})
+
+	t.Run("activity without children", func(t *testing.T) {
+		result, err := WaitForActivity(ctx, api, 801, opts)
+		if err != nil {
+			t.Fatalf("WaitForActivity failed: %v", err)
+		}
+		if result.Status != ActivityStatusCanceled || len(result.Computers) != 0 {
+			t.Fatalf("unexpected result: %+v", result)
+		}
+	})
+
+	t.Run("unknown activity", func(t *testing.T) {
+		if _, err := WaitForActivity(ctx, api, 999, opts); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("context cancellation", func(t *testing.T) {
+		polls.Store(0)
+		ctx, cancel := context.WithCancel(ctx)
+		opts := &WaitOptions{PollInterval: time.Hour, OnPoll: func(*ActivityResult) { cancel() }}
+
+		result, err := WaitForActivity(ctx, api, 601, opts)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+		if result == nil || result.Done() {
+			t.Fatalf("expected the last pending result, got %+v", result)
+		}
+	})
+}
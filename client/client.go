@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
@@ -49,21 +50,45 @@ func NewEmailPasswordProvider(email, password string, account *string) *EmailPas
 
 // Login implements LoginProvider for EmailPasswordProvider.
 func (p *EmailPasswordProvider) Login(ctx context.Context, c *ClientWithResponses) (string, error) {
+	session, err := p.LoginSession(ctx, c)
+	if err != nil {
+		return "", err
+	}
+	return session.Token, nil
+}
+
+// LoginSession implements SessionLoginProvider for EmailPasswordProvider. If
+// Account is set, it logs into that account, and fails if the server put the
+// session in a different one.
+func (p *EmailPasswordProvider) LoginSession(ctx context.Context, c *ClientWithResponses) (*Session, error) {
 	resp, err := c.LoginWithPasswordWithResponse(ctx, LoginWithPasswordJSONRequestBody{
+		Account:  p.Account,
 		Email:    openapi_types.Email(p.Email),
 		Password: p.Password,
 	})
 	if err != nil {
-		return "", fmt.Errorf("login with password request failed: %w", err)
+		return nil, fmt.Errorf("login with password request failed: %w", err)
 	}
 	if resp == nil {
-		return "", fmt.Errorf("nil response from login")
+		return nil, fmt.Errorf("nil response from login")
+	}
+	if err := CheckResponse(resp); err != nil {
+		return nil, err
+	}
+	if resp.JSON200 == nil {
+		return nil, fmt.Errorf("login failed with status: %d", resp.StatusCode())
 	}
-	if resp.StatusCode() != http.StatusOK {
-		return "", fmt.Errorf("login failed with status: %d", resp.StatusCode())
+
+	session := NewSession(resp.JSON200)
+	if p.Account != nil && *p.Account != "" && session.CurrentAccount != *p.Account {
+		return nil, fmt.Errorf("logged into account %q instead of %q; available accounts: %s", session.CurrentAccount, *p.Account, strings.Join(session.AccountNames(), ", "))
 	}
 
-	return resp.JSON200.Token, nil
+	return session, nil
+}
+
+func (p *EmailPasswordProvider) loginSecret() string {
+	return p.Password
 }
 
 // AccessKeyProvider logs in with an access key/secret key pair.
@@ -79,49 +104,70 @@ func NewAccessKeyProvider(accessKey, secretKey string) *AccessKeyProvider {
 	}
 }
 
+func (p *AccessKeyProvider) loginSecret() string {
+	return p.SecretKey
+}
+
 // Login implements LoginProvider for AccessKeyProvider.
 func (p *AccessKeyProvider) Login(ctx context.Context, c *ClientWithResponses) (string, error) {
+	session, err := p.LoginSession(ctx, c)
+	if err != nil {
+		return "", err
+	}
+	return session.Token, nil
+}
+
+// LoginSession implements SessionLoginProvider for AccessKeyProvider.
+func (p *AccessKeyProvider) LoginSession(ctx context.Context, c *ClientWithResponses) (*Session, error) {
 	resp, err := c.LoginWithAccessKeyWithResponse(ctx, LoginWithAccessKeyJSONRequestBody{
 		AccessKey: p.AccessKey,
 		SecretKey: p.SecretKey,
 	})
 	if err != nil {
-		return "", fmt.Errorf("login with access key request failed: %w", err)
+		return nil, fmt.Errorf("login with access key request failed: %w", err)
 	}
 	if resp == nil {
-		return "", fmt.Errorf("nil response from login")
+		return nil, fmt.Errorf("nil response from login")
 	}
-	if resp.StatusCode() != http.StatusOK {
-		return "", fmt.Errorf("login failed with status: %d", resp.StatusCode())
+	if err := CheckResponse(resp); err != nil {
+		return nil, err
+	}
+	if resp.JSON200 == nil {
+		return nil, fmt.Errorf("login failed with status: %d", resp.StatusCode())
 	}
 
-	return resp.JSON200.Token, nil
+	return NewSession(resp.JSON200), nil
 }
 
 // NewLandscapeAPIClient creates a new Landscape API client configured with authentication
 // provided by the given LoginProvider. The provider is used to obtain a JWT token which
 // is then applied to subsequent requests as a Bearer token.
 //
+// The token is refreshed shortly before it expires, and if any request is
+// rejected with 401 the client logs in again and retries it once, so the
+// returned client can be used by long-running processes and shared between
+// goroutines.
+//
+// List and map parameters of legacy actions are encoded in the dotted form
+// Landscape expects; see WithLegacyParamEncoding.
+//
 // opts are passed directly to the underlying generated client. Use
 // WithHTTPClient to supply a custom *http.Client, ex. one configured with
 // a custom TLS cert pool for self-signed server certificates.
 func NewLandscapeAPIClient(baseURL string, loginProvider LoginProvider, opts ...ClientOption) (*ClientWithResponses, error) {
+	opts = append([]ClientOption{WithLegacyParamEncoding()}, opts...)
+
 	tempClient, err := NewClientWithResponses(baseURL, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create temp client: %w", err)
 	}
 
-	token, err := loginProvider.Login(context.Background(), tempClient)
-	if err != nil {
+	tokens := NewTokenSource(loginProvider, tempClient)
+	if _, err := tokens.Token(context.Background()); err != nil {
 		return nil, fmt.Errorf("login failed: %w", err)
 	}
 
-	authEditor := func(ctx context.Context, req *http.Request) error {
-		req.Header.Set("Authorization", "Bearer "+token)
-		return nil
-	}
-
-	opts = append(opts, WithRequestEditorFn(authEditor))
+	opts = append(opts, WithTokenSource(tokens))
 	return NewClientWithResponses(baseURL, opts...)
 }
 
@@ -0,0 +1,638 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package clienttest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jansdhillon/landscape-go-api-client/client"
+)
+
+// Script is a script stored by the server.
+type Script struct {
+	ID    int
+	Title string
+
+	// Code is the decoded code of the script.
+	Code string
+
+	Username    string
+	TimeLimit   int
+	AccessGroup string
+
+	// V2 is whether the script is a v2 script. Only v2 scripts are versioned
+	// and can be archived or redacted.
+	V2 bool
+
+	// Status is the status of a v2 script. It defaults to ACTIVE.
+	Status client.V2ScriptStatus
+
+	// VersionNumber is the version of a v2 script. Editing the script bumps
+	// it.
+	VersionNumber int
+
+	Attachments []Attachment
+
+	CreatedAt    time.Time
+	LastEditedAt time.Time
+}
+
+// Attachment is a file attached to a Script.
+type Attachment struct {
+	ID       int
+	Filename string
+	Content  []byte
+}
+
+// AddComputer stores a computer that scripts can be executed on and script
+// profiles can target. It's given an ID if it has none.
+func (s *Server) AddComputer(computer client.Computer) client.Computer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if computer.Id == 0 {
+		computer.Id = s.newIDLocked()
+	}
+	s.computers = append(s.computers, computer)
+	return computer
+}
+
+// AddScript stores a script, as if it had been created through the API. It's
+// given an ID if it has none.
+func (s *Server) AddScript(script Script) Script {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if script.ID == 0 {
+		script.ID = s.newIDLocked()
+	}
+	if script.V2 {
+		if script.Status == "" {
+			script.Status = client.ACTIVE
+		}
+		if script.VersionNumber == 0 {
+			script.VersionNumber = 1
+		}
+	}
+	if script.CreatedAt.IsZero() {
+		script.CreatedAt = time.Now().UTC()
+	}
+	if script.LastEditedAt.IsZero() {
+		script.LastEditedAt = script.CreatedAt
+	}
+	for i := range script.Attachments {
+		if script.Attachments[i].ID == 0 {
+			script.Attachments[i].ID = s.newIDLocked()
+		}
+	}
+
+	stored := script
+	stored.Attachments = slices.Clone(script.Attachments)
+	s.scripts[script.ID] = &stored
+	return script
+}
+
+// Script returns the script with the given ID.
+func (s *Server) Script(id int) (Script, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	script, ok := s.scripts[id]
+	if !ok {
+		return Script{}, false
+	}
+	out := *script
+	out.Attachments = slices.Clone(script.Attachments)
+	return out, true
+}
+
+// Scripts returns every stored script, ordered by ID.
+func (s *Server) Scripts() []Script {
+	s.mu.Lock()
+	ids := make([]int, 0, len(s.scripts))
+	for id := range s.scripts {
+		ids = append(ids, id)
+	}
+	s.mu.Unlock()
+
+	slices.Sort(ids)
+	scripts := make([]Script, 0, len(ids))
+	for _, id := range ids {
+		if script, ok := s.Script(id); ok {
+			scripts = append(scripts, script)
+		}
+	}
+	return scripts
+}
+
+// Activities returns every activity created so far, including the child
+// activity created for each computer a script was executed on.
+func (s *Server) Activities() []client.Activity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.activity)
+}
+
+// UpdateActivity calls fn with the activity with the given ID, ex. to change
+// its status, and reports whether it exists. Executed scripts succeed right
+// away unless their activities are updated.
+func (s *Server) UpdateActivity(id int, fn func(*client.Activity)) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.activity {
+		if s.activity[i].Id == id {
+			fn(&s.activity[i])
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) getComputers(params url.Values) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var computers []client.Computer
+	for _, computer := range s.computers {
+		if matchComputer(params.Get("query"), computer) {
+			computers = append(computers, computer)
+		}
+	}
+	return page(params, computers)
+}
+
+func (s *Server) getActivities(params url.Values) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := params.Get("query")
+	var activities []client.Activity
+	for _, activity := range s.activity {
+		if matchActivity(query, activity) {
+			activities = append(activities, activity)
+		}
+	}
+	return page(params, activities)
+}
+
+func (s *Server) getScripts(params url.Values) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	scriptType := strings.ToLower(params.Get("script_type"))
+	switch scriptType {
+	case "", "all", "v1", "v2", "active", "archived", "redacted":
+	default:
+		return nil, errorf(http.StatusBadRequest, "InvalidParameter", "unknown script type %q", params.Get("script_type"))
+	}
+
+	ids := make([]int, 0, len(s.scripts))
+	for id := range s.scripts {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	var scripts []any
+	for _, id := range ids {
+		script := s.scripts[id]
+		var match bool
+		switch scriptType {
+		case "", "all":
+			match = true
+		case "v1":
+			match = !script.V2
+		case "v2":
+			match = script.V2
+		case "active":
+			match = !script.V2 || script.Status == client.ACTIVE
+		case "archived":
+			match = script.V2 && script.Status == client.ARCHIVED
+		case "redacted":
+			match = script.V2 && script.Status == client.REDACTED
+		}
+		if match {
+			scripts = append(scripts, s.legacyScriptLocked(script))
+		}
+	}
+	return page(params, scripts)
+}
+
+func (s *Server) getScriptCode(params url.Values) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	script, err := s.scriptParamLocked(params)
+	if err != nil {
+		return nil, err
+	}
+	if script.V2 && script.Status == client.REDACTED {
+		return nil, errorf(http.StatusBadRequest, "ScriptRedacted", "script %d has been redacted", script.ID)
+	}
+	return script.Code, nil
+}
+
+func (s *Server) createScript(params url.Values) (any, error) {
+	title := params.Get("title")
+	if title == "" {
+		return nil, errorf(http.StatusBadRequest, "InvalidParameter", "title is required")
+	}
+	code, err := decodeCode(params.Get("code"))
+	if err != nil {
+		return nil, err
+	}
+	timeLimit, err := optionalInt(params, "time_limit")
+	if err != nil {
+		return nil, err
+	}
+
+	var v2 bool
+	switch params.Get("script_type") {
+	case "", "V1", "v1":
+	case "V2", "v2":
+		v2 = true
+	default:
+		return nil, errorf(http.StatusBadRequest, "InvalidParameter", "unknown script type %q", params.Get("script_type"))
+	}
+
+	accessGroup := params.Get("access_group")
+	if accessGroup == "" {
+		accessGroup = "global"
+	}
+
+	script := s.AddScript(Script{
+		Title:       title,
+		Code:        code,
+		Username:    params.Get("username"),
+		TimeLimit:   timeLimit,
+		AccessGroup: accessGroup,
+		V2:          v2,
+	})
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.legacyScriptLocked(s.scripts[script.ID]), nil
+}
+
+func (s *Server) editScript(params url.Values) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	script, err := s.scriptParamLocked(params)
+	if err != nil {
+		return nil, err
+	}
+	if err := editable(script); err != nil {
+		return nil, err
+	}
+
+	edited := *script
+	if params.Has("title") {
+		if edited.Title = params.Get("title"); edited.Title == "" {
+			return nil, errorf(http.StatusBadRequest, "InvalidParameter", "title must not be empty")
+		}
+	}
+	if params.Has("code") {
+		if edited.Code, err = decodeCode(params.Get("code")); err != nil {
+			return nil, err
+		}
+	}
+	if params.Has("time_limit") {
+		if edited.TimeLimit, err = optionalInt(params, "time_limit"); err != nil {
+			return nil, err
+		}
+	}
+	if params.Has("username") {
+		edited.Username = params.Get("username")
+	}
+
+	if edited.V2 {
+		edited.VersionNumber++
+	}
+	edited.LastEditedAt = time.Now().UTC()
+	*script = edited
+
+	return s.legacyScriptLocked(script), nil
+}
+
+func (s *Server) copyScript(params url.Values) (any, error) {
+	s.mu.Lock()
+	source, err := s.scriptParamLocked(params)
+	var script Script
+	if err == nil {
+		script = *source
+	}
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	title := params.Get("destination_title")
+	if title == "" {
+		return nil, errorf(http.StatusBadRequest, "InvalidParameter", "destination_title is required")
+	}
+	if script.V2 && script.Status == client.REDACTED {
+		return nil, errorf(http.StatusBadRequest, "ScriptRedacted", "script %d has been redacted", script.ID)
+	}
+
+	script.ID = 0
+	script.Title = title
+	script.Status = ""
+	script.VersionNumber = 0
+	script.CreatedAt, script.LastEditedAt = time.Time{}, time.Time{}
+	if params.Has("access_group") {
+		script.AccessGroup = params.Get("access_group")
+	}
+	script.Attachments = slices.Clone(script.Attachments)
+	for i := range script.Attachments {
+		script.Attachments[i].ID = 0
+	}
+
+	script = s.AddScript(script)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.legacyScriptLocked(s.scripts[script.ID]), nil
+}
+
+func (s *Server) removeScript(params url.Values) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	script, err := s.scriptParamLocked(params)
+	if err != nil {
+		return nil, err
+	}
+	delete(s.scripts, script.ID)
+	return nil, nil
+}
+
+func (s *Server) createScriptAttachment(params url.Values) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	script, err := s.scriptParamLocked(params)
+	if err != nil {
+		return nil, err
+	}
+	if err := editable(script); err != nil {
+		return nil, err
+	}
+
+	filename, encoded, ok := strings.Cut(params.Get("file"), "$$")
+	if !ok || filename == "" {
+		return nil, errorf(http.StatusBadRequest, "InvalidParameter", "file must be given as <filename>$$<base64 encoded contents>")
+	}
+	content, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errorf(http.StatusBadRequest, "InvalidParameter", "file contents must be base64 encoded: %v", err)
+	}
+	total := len(content)
+	for _, attachment := range script.Attachments {
+		if attachment.Filename == filename {
+			return nil, errorf(http.StatusBadRequest, "DuplicateAttachment", "script %d already has an attachment named %q", script.ID, filename)
+		}
+		total += len(attachment.Content)
+	}
+	if len(script.Attachments) >= client.MaxScriptAttachments {
+		return nil, errorf(http.StatusBadRequest, "TooManyAttachments", "script %d already has %d attachments", script.ID, len(script.Attachments))
+	}
+	if total > client.MaxScriptAttachmentsSize {
+		return nil, errorf(http.StatusBadRequest, "AttachmentsTooLarge", "script %d attachments would be %d bytes in total", script.ID, total)
+	}
+
+	script.Attachments = append(script.Attachments, Attachment{
+		ID:       s.newIDLocked(),
+		Filename: filename,
+		Content:  bytes.Clone(content),
+	})
+	return filename, nil
+}
+
+func (s *Server) removeScriptAttachment(params url.Values) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	script, err := s.scriptParamLocked(params)
+	if err != nil {
+		return nil, err
+	}
+
+	filename := params.Get("filename")
+	i := slices.IndexFunc(script.Attachments, func(a Attachment) bool { return a.Filename == filename })
+	if i < 0 {
+		return nil, errorf(http.StatusNotFound, "UnknownScriptAttachment", "script %d has no attachment named %q", script.ID, filename)
+	}
+	script.Attachments = slices.Delete(script.Attachments, i, i+1)
+	return nil, nil
+}
+
+func (s *Server) executeScript(params url.Values) (any, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	script, err := s.scriptParamLocked(params)
+	if err != nil {
+		return nil, err
+	}
+	if script.V2 && script.Status != client.ACTIVE {
+		return nil, errorf(http.StatusBadRequest, "ScriptNotExecutable", "script %d is %s", script.ID, strings.ToLower(string(script.Status)))
+	}
+
+	username := params.Get("username")
+	if username == "" {
+		username = script.Username
+	}
+	if username == "" {
+		return nil, errorf(http.StatusBadRequest, "InvalidParameter", "username is required because script %d has no default user", script.ID)
+	}
+
+	accessGroup := params.Get("in_access_group")
+	var targets []client.Computer
+	for _, computer := range s.computers {
+		if matchComputer(params.Get("query"), computer) && (accessGroup == "" || computer.AccessGroup == accessGroup) {
+			targets = append(targets, computer)
+		}
+	}
+	if len(targets) == 0 {
+		return nil, errorf(http.StatusBadRequest, "NoComputersFound", "no computers match %q", params.Get("query"))
+	}
+
+	return s.runLocked(fmt.Sprintf("Run script: %s", script.Title), targets), nil
+}
+
+// runLocked records an activity running on the given computers, with a
+// child activity for each, and returns the parent. The activities succeed
+// right away.
+func (s *Server) runLocked(summary string, computers []client.Computer) client.Activity {
+	now := time.Now().UTC().Format(time.RFC3339)
+	succeeded := string(client.ActivityStatusSucceeded)
+
+	parent := client.Activity{
+		Id:             s.newIDLocked(),
+		Type:           "ActivityGroup",
+		Summary:        summary,
+		ActivityStatus: succeeded,
+		CreationTime:   &now,
+		CompletionTime: &now,
+	}
+	s.activity = append(s.activity, parent)
+
+	for _, computer := range computers {
+		computerID, parentID, code, output := computer.Id, parent.Id, 0, ""
+		s.activity = append(s.activity, client.Activity{
+			Id:             s.newIDLocked(),
+			Type:           "ExecuteScriptRequest",
+			Summary:        summary,
+			ActivityStatus: succeeded,
+			ComputerId:     &computerID,
+			ParentId:       &parentID,
+			CreationTime:   &now,
+			CompletionTime: &now,
+			ResultCode:     &code,
+			ResultText:     &output,
+		})
+	}
+
+	return parent
+}
+
+// legacyScriptLocked returns the legacy representation of a script.
+func (s *Server) legacyScriptLocked(script *Script) any {
+	if script.V2 {
+		return s.v2ScriptLocked(script)
+	}
+
+	attachments := make([]client.LegacyScriptAttachment, len(script.Attachments))
+	for i, attachment := range script.Attachments {
+		attachments[i] = attachment.Filename
+	}
+
+	return client.V1Script{
+		Id:          script.ID,
+		Title:       script.Title,
+		Status:      client.V1,
+		AccessGroup: &script.AccessGroup,
+		Attachments: &attachments,
+		TimeLimit:   &script.TimeLimit,
+		Username:    &script.Username,
+	}
+}
+
+// scriptParamLocked returns the script named by the script_id parameter.
+func (s *Server) scriptParamLocked(params url.Values) (*Script, error) {
+	id, err := strconv.Atoi(params.Get("script_id"))
+	if err != nil {
+		return nil, errorf(http.StatusBadRequest, "InvalidParameter", "script_id must be an integer")
+	}
+	script, ok := s.scripts[id]
+	if !ok {
+		return nil, errorf(http.StatusNotFound, "UnknownScript", "no script with ID %d", id)
+	}
+	return script, nil
+}
+
+// editable returns an error if script can no longer be changed.
+func editable(script *Script) error {
+	if script.V2 && script.Status != client.ACTIVE {
+		return errorf(http.StatusBadRequest, "ScriptNotEditable", "script %d is %s", script.ID, strings.ToLower(string(script.Status)))
+	}
+	return nil
+}
+
+func decodeCode(encoded string) (string, error) {
+	if encoded == "" {
+		return "", errorf(http.StatusBadRequest, "InvalidParameter", "code is required")
+	}
+	code, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", errorf(http.StatusBadRequest, "InvalidParameter", "code must be base64 encoded: %v", err)
+	}
+	return string(code), nil
+}
+
+func optionalInt(params url.Values, name string) (int, error) {
+	value := params.Get(name)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, errorf(http.StatusBadRequest, "InvalidParameter", "%s must be an integer", name)
+	}
+	return n, nil
+}
+
+// page applies the offset and limit parameters to items. The limit defaults
+// to 1000, like Landscape's.
+func page[T any](params url.Values, items []T) (any, error) {
+	offset, err := optionalInt(params, "offset")
+	if err != nil {
+		return nil, err
+	}
+	limit, err := optionalInt(params, "limit")
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = 1000
+	}
+
+	offset = min(max(offset, 0), len(items))
+	end := min(offset+limit, len(items))
+	return append([]T{}, items[offset:end]...), nil
+}
+
+// matchComputer reports whether computer matches a query. Queries are
+// space-separated terms that must all match: tag:<tag>, id:<id>,
+// access-group:<name>, all, or text found in the title or hostname.
+func matchComputer(query string, computer client.Computer) bool {
+	for _, term := range strings.Fields(query) {
+		key, value, hasKey := strings.Cut(term, ":")
+		var match bool
+		switch {
+		case term == "all":
+			match = true
+		case hasKey && key == "tag":
+			match = slices.Contains(computer.Tags, value)
+		case hasKey && key == "id":
+			match = value == strconv.Itoa(computer.Id)
+		case hasKey && key == "access-group":
+			match = computer.AccessGroup == value
+		default:
+			term = strings.ToLower(term)
+			match = strings.Contains(strings.ToLower(computer.Title), term) || strings.Contains(strings.ToLower(computer.Hostname), term)
+		}
+		if !match {
+			return false
+		}
+	}
+	return true
+}
+
+// matchActivity reports whether activity matches a query made of id:<id>
+// and parent-id:<id> terms.
+func matchActivity(query string, activity client.Activity) bool {
+	for _, term := range strings.Fields(query) {
+		key, value, _ := strings.Cut(term, ":")
+		switch key {
+		case "id":
+			if value != strconv.Itoa(activity.Id) {
+				return false
+			}
+		case "parent-id":
+			if activity.ParentId == nil || value != strconv.Itoa(*activity.ParentId) {
+				return false
+			}
+		}
+	}
+	return true
+}
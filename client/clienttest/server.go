@@ -0,0 +1,537 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package clienttest provides an in-memory fake Landscape server for testing
+// code that uses the client package.
+//
+// The server implements login, the legacy ?action= API for scripts,
+// computers and activities, and the v2 /api/scripts and /api/script-profiles
+// routes. It keeps what it's sent in memory, so a script created through the
+// API can be fetched, edited, executed and archived later in the same test.
+// Requests must carry a token issued by one of the login endpoints.
+//
+//	server := clienttest.NewServer()
+//	defer server.Close()
+//
+//	api, err := server.NewClient()
+//	...
+//	server.Fail("LegacyGetComputers", clienttest.Fault{Status: http.StatusServiceUnavailable, Times: 2})
+package clienttest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jansdhillon/landscape-go-api-client/client"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// The credentials the server accepts unless overridden with WithAccessKey or
+// WithPassword.
+const (
+	DefaultAccessKey = "ACCESS-KEY"
+	DefaultSecretKey = "SECRET-KEY"
+	DefaultEmail     = "admin@example.com"
+	DefaultPassword  = "password"
+	DefaultAccount   = "standalone"
+)
+
+// AllOperations can be passed to Server.Fail to inject a fault into every
+// request.
+const AllOperations = "*"
+
+// Fault describes how the server misbehaves for requests to an operation.
+type Fault struct {
+	// Status is the HTTP status to respond with. If it's 0, the request is
+	// handled normally after Delay.
+	Status int
+
+	// Body is encoded as the JSON response body. It defaults to an error
+	// body naming the status.
+	Body any
+
+	// Header is added to the response, ex. Retry-After.
+	Header http.Header
+
+	// Delay is how long to wait before responding. Waiting stops early if
+	// the request is canceled.
+	Delay time.Duration
+
+	// Times is how many requests the fault applies to. 0 means every
+	// request until the fault is cleared.
+	Times int
+}
+
+// Request is a request received by the server.
+type Request struct {
+	// Operation is the operation ID of the request, ex. LegacyGetComputers
+	// or GetScriptProfile, or "" if it didn't match any.
+	Operation string
+
+	Method string
+	URL    *url.URL
+	Header http.Header
+	Body   []byte
+}
+
+// ActionHandler handles a legacy action. The returned value is encoded as
+// the JSON response body. Return an *Error to respond with an error status.
+type ActionHandler func(params url.Values) (any, error)
+
+// Error is an error response from the server. Legacy actions encode it as
+// {"error": Code, "message": Message}, v2 routes as the v2 Error model.
+type Error struct {
+	Status  int
+	Code    string
+	Message string
+}
+
+// Error implements error for Error.
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.Status, e.Code, e.Message)
+}
+
+func errorf(status int, code, format string, args ...any) *Error {
+	return &Error{Status: status, Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithAccessKey sets the access key and secret key the server accepts.
+func WithAccessKey(accessKey, secretKey string) Option {
+	return func(s *Server) {
+		s.accessKey, s.secretKey = accessKey, secretKey
+	}
+}
+
+// WithPassword sets the email and password the server accepts.
+func WithPassword(email, password string) Option {
+	return func(s *Server) {
+		s.email, s.password = email, password
+	}
+}
+
+// WithAccounts sets the accounts the user can log into. The first is the
+// default. It defaults to a single account named DefaultAccount.
+func WithAccounts(names ...string) Option {
+	return func(s *Server) {
+		if len(names) > 0 {
+			s.accounts = names
+		}
+	}
+}
+
+// WithTokenLifetime sets how long issued tokens are valid for. It defaults
+// to an hour.
+func WithTokenLifetime(d time.Duration) Option {
+	return func(s *Server) {
+		s.tokenLifetime = d
+	}
+}
+
+// WithScriptProfileLimits sets the limits enforced on script profiles.
+func WithScriptProfileLimits(limits client.ScriptProfileLimits) Option {
+	return func(s *Server) {
+		s.limits = limits
+	}
+}
+
+// Server is a fake Landscape server. Its methods can be called from the test
+// while requests are being served.
+type Server struct {
+	*httptest.Server
+
+	accessKey, secretKey string
+	email, password      string
+	accounts             []string
+	tokenLifetime        time.Duration
+	limits               client.ScriptProfileLimits
+
+	mu        sync.Mutex
+	nextID    int
+	tokens    map[string]time.Time
+	faults    map[string]*Fault
+	requests  []Request
+	actions   map[string]ActionHandler
+	computers []client.Computer
+	scripts   map[int]*Script
+	profiles  map[int]*scriptProfile
+	activity  []client.Activity
+}
+
+// NewServer starts a fake Landscape server over TLS. Call Close when done.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		accessKey:     DefaultAccessKey,
+		secretKey:     DefaultSecretKey,
+		email:         DefaultEmail,
+		password:      DefaultPassword,
+		accounts:      []string{DefaultAccount},
+		tokenLifetime: time.Hour,
+		limits: client.ScriptProfileLimits{
+			MaxNumComputers: 5000,
+			MaxNumProfiles:  100,
+			MinInterval:     60,
+		},
+		nextID:   1,
+		tokens:   map[string]time.Time{},
+		faults:   map[string]*Fault{},
+		scripts:  map[int]*Script{},
+		profiles: map[int]*scriptProfile{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.actions = map[string]ActionHandler{
+		"GetComputers":           s.getComputers,
+		"GetActivities":          s.getActivities,
+		"GetScripts":             s.getScripts,
+		"GetScriptCode":          s.getScriptCode,
+		"CreateScript":           s.createScript,
+		"EditScript":             s.editScript,
+		"CopyScript":             s.copyScript,
+		"RemoveScript":           s.removeScript,
+		"CreateScriptAttachment": s.createScriptAttachment,
+		"RemoveScriptAttachment": s.removeScriptAttachment,
+		"ExecuteScript":          s.executeScript,
+	}
+
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// NewClient returns a client for the server that logs in with the access
+// key it accepts. opts are passed to client.NewLandscapeAPIClient after an
+// option that makes it trust the server's certificate.
+func (s *Server) NewClient(opts ...client.ClientOption) (*client.ClientWithResponses, error) {
+	s.mu.Lock()
+	provider := client.NewAccessKeyProvider(s.accessKey, s.secretKey)
+	s.mu.Unlock()
+
+	opts = append([]client.ClientOption{client.WithHTTPClient(s.Client())}, opts...)
+	return client.NewLandscapeAPIClient(s.URL, provider, opts...)
+}
+
+// HandleAction sets the handler of a legacy action, replacing the built-in
+// one if there is one.
+func (s *Server) HandleAction(action string, handler ActionHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.actions[action] = handler
+}
+
+// Fail makes requests to the operation with the given ID, ex.
+// LegacyExecuteScript or CreateScriptProfile, fail as described by fault.
+// Pass AllOperations to affect every request. It replaces any fault already
+// set for the operation.
+func (s *Server) Fail(operation string, fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults[operation] = &fault
+}
+
+// ClearFaults removes every fault set with Fail.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.faults)
+}
+
+// RevokeTokens invalidates every token issued so far, as if they had
+// expired, so clients must log in again.
+func (s *Server) RevokeTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.tokens)
+}
+
+// Requests returns the requests received so far, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// ResetRequests forgets the requests received so far.
+func (s *Server) ResetRequests() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = nil
+}
+
+// route is a handler for an operation. pathParams are the path parameters of
+// v2 routes, in order.
+type route struct {
+	operation  string
+	handle     func(r *http.Request, body []byte, pathParams []int) (int, any)
+	pathParams []int
+	legacy     bool
+	public     bool
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := readBody(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rt := s.route(r)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Operation: rt.operation,
+		Method:    r.Method,
+		URL:       r.URL,
+		Header:    r.Header.Clone(),
+		Body:      body,
+	})
+	fault := s.takeFaultLocked(rt.operation)
+	s.mu.Unlock()
+
+	if fault != nil {
+		if fault.Delay > 0 {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(fault.Delay):
+			}
+		}
+		if fault.Status != 0 {
+			for name, values := range fault.Header {
+				w.Header()[name] = values
+			}
+			faultBody := fault.Body
+			if faultBody == nil {
+				faultBody = errorBody(rt.legacy, errorf(fault.Status, "InjectedFault", "%s", http.StatusText(fault.Status)))
+			}
+			writeJSON(w, fault.Status, faultBody)
+			return
+		}
+	}
+
+	if rt.handle == nil {
+		writeJSON(w, http.StatusNotFound, errorBody(rt.legacy, errorf(http.StatusNotFound, "NotFound", "no route for %s %s", r.Method, r.URL.Path)))
+		return
+	}
+
+	if !rt.public && !s.authorized(r) {
+		writeJSON(w, http.StatusUnauthorized, errorBody(rt.legacy, errorf(http.StatusUnauthorized, "Unauthorized", "missing or invalid token")))
+		return
+	}
+
+	status, out := rt.handle(r, body, rt.pathParams)
+	var apiErr *Error
+	if err, ok := out.(error); ok {
+		if !errors.As(err, &apiErr) {
+			apiErr = errorf(http.StatusInternalServerError, "InternalError", "%v", err)
+		}
+		writeJSON(w, apiErr.Status, errorBody(rt.legacy, apiErr))
+		return
+	}
+	if status == http.StatusNoContent {
+		w.WriteHeader(status)
+		return
+	}
+	writeJSON(w, status, out)
+}
+
+// route finds the handler for r.
+func (s *Server) route(r *http.Request) route {
+	path := r.URL.Path
+
+	if path == "/api/" {
+		action := r.URL.Query().Get("action")
+		rt := route{operation: "Legacy" + action, legacy: true}
+		if action == "" {
+			rt.operation = ""
+		}
+		rt.handle = s.legacyAction
+		return rt
+	}
+
+	if r.Method == http.MethodPost {
+		switch path {
+		case "/api/login":
+			return route{operation: "LoginWithPassword", handle: s.loginWithPassword, public: true}
+		case "/api/login/access-key":
+			return route{operation: "LoginWithAccessKey", handle: s.loginWithAccessKey, public: true}
+		}
+	}
+
+	for _, v2 := range s.v2Routes() {
+		if v2.method != r.Method {
+			continue
+		}
+		if params, ok := matchRoute(v2.path, path); ok {
+			return route{operation: v2.operation, handle: v2.handle, pathParams: params}
+		}
+	}
+
+	return route{}
+}
+
+// takeFaultLocked returns the fault to apply to a request to operation, if
+// any, counting it against the fault's Times.
+func (s *Server) takeFaultLocked(operation string) *Fault {
+	for _, key := range []string{operation, AllOperations} {
+		fault, ok := s.faults[key]
+		if !ok {
+			continue
+		}
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				delete(s.faults, key)
+			}
+		}
+		f := *fault
+		return &f
+	}
+	return nil
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	expires, ok := s.tokens[token]
+	return ok && time.Now().Before(expires)
+}
+
+func (s *Server) loginWithAccessKey(r *http.Request, body []byte, _ []int) (int, any) {
+	var req client.AccessKeyLoginRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return 0, errorf(http.StatusBadRequest, "BadRequest", "invalid login request: %v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if req.AccessKey != s.accessKey || req.SecretKey != s.secretKey {
+		return 0, errorf(http.StatusUnauthorized, "Unauthorized", "invalid access key or secret key")
+	}
+	return http.StatusOK, s.loginResponseLocked(s.accounts[0])
+}
+
+func (s *Server) loginWithPassword(r *http.Request, body []byte, _ []int) (int, any) {
+	var req client.LoginRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return 0, errorf(http.StatusBadRequest, "BadRequest", "invalid login request: %v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if string(req.Email) != s.email || req.Password != s.password {
+		return 0, errorf(http.StatusUnauthorized, "Unauthorized", "invalid email or password")
+	}
+
+	account := s.accounts[0]
+	if req.Account != nil && *req.Account != "" {
+		account = *req.Account
+		found := false
+		for _, name := range s.accounts {
+			found = found || name == account
+		}
+		if !found {
+			return 0, errorf(http.StatusUnauthorized, "Unauthorized", "no access to account %q", account)
+		}
+	}
+	return http.StatusOK, s.loginResponseLocked(account)
+}
+
+func (s *Server) loginResponseLocked(account string) client.LoginResponse {
+	expires := time.Now().Add(s.tokenLifetime)
+	token := s.newTokenLocked(expires)
+	s.tokens[token] = expires
+
+	accounts := make([]client.LoginAccount, len(s.accounts))
+	for i, name := range s.accounts {
+		accounts[i] = client.LoginAccount{Name: name, Title: name, Default: i == 0}
+	}
+
+	return client.LoginResponse{
+		Accounts:       accounts,
+		CurrentAccount: account,
+		Email:          openapi_types.Email(s.email),
+		Token:          token,
+	}
+}
+
+// newTokenLocked returns a unique, unsigned JWT that expires at expires, so
+// clients can tell when to log in again.
+func (s *Server) newTokenLocked(expires time.Time) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`))
+	claims, _ := json.Marshal(map[string]any{
+		"exp": expires.Unix(),
+		"jti": s.newIDLocked(),
+	})
+	return header + "." + base64.RawURLEncoding.EncodeToString(claims) + ".fake"
+}
+
+// newIDLocked returns an ID that's unique across every kind of object.
+func (s *Server) newIDLocked() int {
+	id := s.nextID
+	s.nextID++
+	return id
+}
+
+func (s *Server) legacyAction(r *http.Request, _ []byte, _ []int) (int, any) {
+	params := r.URL.Query()
+	if params.Get("version") == "" {
+		return 0, errorf(http.StatusBadRequest, "InvalidParameter", "the version parameter is required")
+	}
+
+	action := params.Get("action")
+	s.mu.Lock()
+	handler, ok := s.actions[action]
+	s.mu.Unlock()
+	if !ok {
+		return 0, errorf(http.StatusBadRequest, "UnknownAction", "unknown action %q", action)
+	}
+
+	out, err := handler(params)
+	if err != nil {
+		return 0, err
+	}
+	if out == nil {
+		return http.StatusNoContent, nil
+	}
+	return http.StatusOK, out
+}
+
+// errorBody is the response body of an error from a legacy action or a v2
+// route.
+func errorBody(legacy bool, err *Error) any {
+	if legacy {
+		return map[string]string{"error": err.Code, "message": err.Message}
+	}
+	return client.Error{Code: &err.Status, Message: &err.Message}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func readBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	defer r.Body.Close()
+	return io.ReadAll(r.Body)
+}
@@ -0,0 +1,337 @@
+package clienttest
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/jansdhillon/landscape-go-api-client/client"
+)
+
+func newClient(t *testing.T, server *Server, opts ...client.ClientOption) *client.ClientWithResponses {
+	t.Helper()
+
+	api, err := server.NewClient(opts...)
+	if err != nil {
+		t.Fatalf("failed to init client: %v", err)
+	}
+	return api
+}
+
+func TestServerAuth(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	api := newClient(t, server)
+	ctx := context.Background()
+
+	if _, err := api.GetComputersTyped(ctx, &client.LegacyGetComputersParams{}); err != nil {
+		t.Fatalf("GetComputersTyped failed: %v", err)
+	}
+
+	server.RevokeTokens()
+	server.ResetRequests()
+	if _, err := api.GetComputersTyped(ctx, &client.LegacyGetComputersParams{}); err != nil {
+		t.Fatalf("expected the client to log in again, got %v", err)
+	}
+
+	var operations []string
+	for _, req := range server.Requests() {
+		operations = append(operations, req.Operation)
+	}
+	want := []string{"LegacyGetComputers", "LoginWithAccessKey", "LegacyGetComputers"}
+	if len(operations) != len(want) {
+		t.Fatalf("expected requests %v, got %v", want, operations)
+	}
+	for i := range want {
+		if operations[i] != want[i] {
+			t.Fatalf("expected requests %v, got %v", want, operations)
+		}
+	}
+
+	unauthenticated, err := client.NewClientWithResponses(server.URL, client.WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("failed to init client: %v", err)
+	}
+	res, err := unauthenticated.GetScriptProfileLimitsWithResponse(ctx)
+	if err != nil {
+		t.Fatalf("GetScriptProfileLimitsWithResponse failed: %v", err)
+	}
+	if res.StatusCode() != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without a token, got %d", res.StatusCode())
+	}
+
+	if _, err := client.NewLandscapeAPIClient(server.URL, client.NewAccessKeyProvider("wrong", "wrong"), client.WithHTTPClient(server.Client())); err == nil {
+		t.Fatal("expected login with the wrong access key to fail")
+	}
+}
+
+func TestServerScripts(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	api := newClient(t, server)
+	ctx := context.Background()
+
+	code := base64.StdEncoding.EncodeToString([]byte("#!/bin/sh\necho hi\n"))
+	scriptType := "V2"
+	created, err := api.LegacyCreateScriptWithResponse(ctx, &client.LegacyCreateScriptParams{
+		Title:      "hello",
+		Code:       code,
+		ScriptType: &scriptType,
+	})
+	if err != nil {
+		t.Fatalf("LegacyCreateScriptWithResponse failed: %v", err)
+	}
+	if err := client.CheckResponse(created); err != nil {
+		t.Fatalf("failed to create script: %v", err)
+	}
+
+	var script client.V2Script
+	if err := json.Unmarshal(created.Body, &script); err != nil {
+		t.Fatalf("failed to decode script: %v", err)
+	}
+	if script.Title != "hello" || script.Status != client.ACTIVE || *script.VersionNumber != 1 {
+		t.Fatalf("unexpected script %+v", script)
+	}
+
+	newCode := base64.StdEncoding.EncodeToString([]byte("#!/bin/sh\necho bye\n"))
+	edited, err := api.LegacyEditScriptWithResponse(ctx, &client.LegacyEditScriptParams{ScriptId: script.Id, Code: &newCode})
+	if err != nil {
+		t.Fatalf("LegacyEditScriptWithResponse failed: %v", err)
+	}
+	if err := client.CheckResponse(edited); err != nil {
+		t.Fatalf("failed to edit script: %v", err)
+	}
+
+	res, err := api.GetScriptWithResponse(ctx, script.Id)
+	if err != nil {
+		t.Fatalf("GetScriptWithResponse failed: %v", err)
+	}
+	v2, err := res.JSON200.AsV2Script()
+	if err != nil {
+		t.Fatalf("failed to decode script: %v", err)
+	}
+	if *v2.VersionNumber != 2 || v2.Code == nil || *v2.Code != "#!/bin/sh\necho bye\n" {
+		t.Fatalf("expected the edit to bump the version and change the code, got %+v", v2)
+	}
+
+	archived, err := api.ArchiveScriptWithResponse(ctx, script.Id)
+	if err != nil {
+		t.Fatalf("ArchiveScriptWithResponse failed: %v", err)
+	}
+	if archived.StatusCode() != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", archived.StatusCode())
+	}
+	if stored, _ := server.Script(script.Id); stored.Status != client.ARCHIVED {
+		t.Fatalf("expected the script to be archived, got %s", stored.Status)
+	}
+
+	edited, err = api.LegacyEditScriptWithResponse(ctx, &client.LegacyEditScriptParams{ScriptId: script.Id, Code: &code})
+	if err != nil {
+		t.Fatalf("LegacyEditScriptWithResponse failed: %v", err)
+	}
+	var apiErr *client.APIError
+	if err := client.CheckResponse(edited); !errors.As(err, &apiErr) || apiErr.Code != "ScriptNotEditable" {
+		t.Fatalf("expected archived scripts to be read-only, got %v", err)
+	}
+
+	missing, err := api.GetScriptWithResponse(ctx, 999)
+	if err != nil {
+		t.Fatalf("GetScriptWithResponse failed: %v", err)
+	}
+	if err := client.CheckResponse(missing); !errors.Is(err, client.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestServerExecuteScript(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	web := server.AddComputer(client.Computer{Title: "web-1", Tags: []string{"web"}})
+	server.AddComputer(client.Computer{Title: "db-1", Tags: []string{"db"}})
+	script := server.AddScript(Script{Title: "uptime", Code: "#!/bin/sh\nuptime\n", Username: "root"})
+
+	api := newClient(t, server)
+	ctx := context.Background()
+
+	activity, err := api.ExecuteScriptTyped(ctx, &client.LegacyExecuteScriptParams{Query: "tag:web", ScriptId: script.ID})
+	if err != nil {
+		t.Fatalf("ExecuteScriptTyped failed: %v", err)
+	}
+
+	result, err := client.WaitForActivity(ctx, api, activity.Id, &client.WaitOptions{PollInterval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("WaitForActivity failed: %v", err)
+	}
+	if result.Status != client.ActivityStatusSucceeded || len(result.Computers) != 1 || result.Computers[0].ComputerId != web.Id {
+		t.Fatalf("unexpected result %+v", result)
+	}
+
+	children := 0
+	for _, a := range server.Activities() {
+		if a.ParentId != nil && *a.ParentId == activity.Id {
+			children++
+			server.UpdateActivity(a.Id, func(a *client.Activity) { a.ActivityStatus = string(client.ActivityStatusFailed) })
+		}
+	}
+	if children != 1 {
+		t.Fatalf("expected 1 child activity, got %d", children)
+	}
+
+	result, err = client.GetActivityResult(ctx, api, activity.Id)
+	if err != nil {
+		t.Fatalf("GetActivityResult failed: %v", err)
+	}
+	if result.Status != client.ActivityStatusFailed {
+		t.Fatalf("expected the updated activity to fail, got %s", result.Status)
+	}
+}
+
+func TestServerScriptProfiles(t *testing.T) {
+	server := NewServer(WithScriptProfileLimits(client.ScriptProfileLimits{MaxNumComputers: 1, MaxNumProfiles: 2, MinInterval: 60}))
+	defer server.Close()
+
+	server.AddComputer(client.Computer{Title: "web-1", Tags: []string{"web"}})
+	server.AddComputer(client.Computer{Title: "web-2", Tags: []string{"web", "canary"}})
+	script := server.AddScript(Script{Title: "backup", Code: "#!/bin/sh\n", V2: true})
+
+	api := newClient(t, server)
+	ctx := context.Background()
+
+	var trigger client.ScriptProfileTriggerCreateRequest
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := trigger.FromScriptProfileScheduleDraftTrigger(client.ScriptProfileScheduleDraftTrigger{Interval: "0 3 * * *", StartAfter: start}); err != nil {
+		t.Fatalf("failed to build trigger: %v", err)
+	}
+	body := client.ScriptProfileCreateBody{
+		Title:     "nightly",
+		ScriptId:  script.ID,
+		Tags:      &[]string{"canary"},
+		TimeLimit: 300,
+		Username:  "root",
+		Trigger:   trigger,
+	}
+
+	created, err := api.CreateScriptProfileWithResponse(ctx, body)
+	if err != nil {
+		t.Fatalf("CreateScriptProfileWithResponse failed: %v", err)
+	}
+	if created.JSON201 == nil {
+		t.Fatalf("expected 201, got %d: %s", created.StatusCode(), created.Body)
+	}
+	profile := *created.JSON201
+	schedule, err := profile.Trigger.AsScriptProfileScheduleTrigger()
+	if err != nil || schedule.Interval != "0 3 * * *" || !schedule.StartAfter.Equal(start) {
+		t.Fatalf("unexpected trigger %+v: %v", schedule, err)
+	}
+	if profile.Computers.NumAssociatedComputers != 1 {
+		t.Fatalf("expected 1 computer, got %d", profile.Computers.NumAssociatedComputers)
+	}
+
+	duplicate, err := api.CreateScriptProfileWithResponse(ctx, body)
+	if err != nil {
+		t.Fatalf("CreateScriptProfileWithResponse failed: %v", err)
+	}
+	if duplicate.JSON409 == nil {
+		t.Fatalf("expected 409 for a duplicate title, got %d", duplicate.StatusCode())
+	}
+
+	body.Title, body.Tags = "too many", &[]string{"web"}
+	tooMany, err := api.CreateScriptProfileWithResponse(ctx, body)
+	if err != nil {
+		t.Fatalf("CreateScriptProfileWithResponse failed: %v", err)
+	}
+	if tooMany.JSON400 == nil {
+		t.Fatalf("expected 400 for too many computers, got %d", tooMany.StatusCode())
+	}
+
+	if _, err := server.RunScriptProfile(profile.Id); err != nil {
+		t.Fatalf("RunScriptProfile failed: %v", err)
+	}
+	activities, err := api.ListScriptProfileActivitiesWithResponse(ctx, profile.Id)
+	if err != nil {
+		t.Fatalf("ListScriptProfileActivitiesWithResponse failed: %v", err)
+	}
+	if activities.JSON200 == nil || activities.JSON200.Count != 1 {
+		t.Fatalf("expected 1 activity, got %s", activities.Body)
+	}
+
+	if _, err := api.ArchiveScriptProfileWithResponse(ctx, profile.Id); err != nil {
+		t.Fatalf("ArchiveScriptProfileWithResponse failed: %v", err)
+	}
+	title := "renamed"
+	updated, err := api.UpdateScriptProfileWithResponse(ctx, profile.Id, client.ScriptProfilePatchBody{Title: &title})
+	if err != nil {
+		t.Fatalf("UpdateScriptProfileWithResponse failed: %v", err)
+	}
+	if updated.JSON400 == nil {
+		t.Fatalf("expected 400 for an archived profile, got %d", updated.StatusCode())
+	}
+
+	all := client.ListScriptProfilesParamsArchivedAll
+	for archived, want := range map[*client.ListScriptProfilesParamsArchived]int{nil: 0, &all: 1} {
+		list, err := api.ListScriptProfilesWithResponse(ctx, &client.ListScriptProfilesParams{Archived: archived})
+		if err != nil {
+			t.Fatalf("ListScriptProfilesWithResponse failed: %v", err)
+		}
+		if list.JSON200 == nil || list.JSON200.Count != want {
+			t.Fatalf("expected %d profiles, got %s", want, list.Body)
+		}
+	}
+}
+
+func TestServerFaults(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	policy := client.DefaultRetryPolicy()
+	policy.MinBackoff, policy.MaxBackoff = time.Millisecond, time.Millisecond
+	api := newClient(t, server, client.WithRetry(policy))
+	ctx := context.Background()
+
+	server.Fail("LegacyGetComputers", Fault{Status: http.StatusServiceUnavailable, Times: 2})
+	server.ResetRequests()
+	if _, err := api.GetComputersTyped(ctx, &client.LegacyGetComputersParams{}); err != nil {
+		t.Fatalf("expected the request to be retried, got %v", err)
+	}
+	if n := len(server.Requests()); n != 3 {
+		t.Fatalf("expected 3 attempts, got %d", n)
+	}
+
+	server.Fail(AllOperations, Fault{Status: http.StatusForbidden})
+	res, err := api.GetScriptProfileLimitsWithResponse(ctx)
+	if err != nil {
+		t.Fatalf("GetScriptProfileLimitsWithResponse failed: %v", err)
+	}
+	if err := client.CheckResponse(res); !errors.Is(err, client.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden, got %v", err)
+	}
+
+	server.ClearFaults()
+	server.Fail("GetScriptProfileLimits", Fault{Delay: time.Second})
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, err := api.GetScriptProfileLimitsWithResponse(timeout); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the delayed request to time out, got %v", err)
+	}
+
+	server.HandleAction("GetComputers", func(params url.Values) (any, error) {
+		return nil, &Error{Status: http.StatusBadRequest, Code: "Custom", Message: params.Get("query")}
+	})
+	_, err = api.GetComputersTyped(ctx, &client.LegacyGetComputersParams{Query: ptr("tag:web")})
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != "Custom" || apiErr.Message != "tag:web" {
+		t.Fatalf("expected the custom handler's error, got %v", err)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,626 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package clienttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jansdhillon/landscape-go-api-client/client"
+)
+
+// scriptProfile is a stored script profile and the IDs of the activities it
+// has run, oldest first.
+type scriptProfile struct {
+	detail     client.ScriptProfileDetail
+	activities []int
+}
+
+// v2Route is a v2 route. Each {} in path stands for an integer path
+// parameter, which may be followed by a suffix, ex. {}:archive.
+type v2Route struct {
+	method    string
+	path      string
+	operation string
+	handle    func(r *http.Request, body []byte, pathParams []int) (int, any)
+}
+
+func (s *Server) v2Routes() []v2Route {
+	return []v2Route{
+		{http.MethodGet, "/api/scripts/{}", "GetScript", s.getScript},
+		{http.MethodGet, "/api/scripts/{}/attachments/{}", "GetScriptAttachment", s.getScriptAttachment},
+		{http.MethodGet, "/api/scripts/{}/script-profiles", "ListScriptProfilesByScript", s.listScriptProfilesByScript},
+		{http.MethodPost, "/api/scripts/{}:archive", "ArchiveScript", s.setScriptStatus(client.ARCHIVED)},
+		{http.MethodPost, "/api/scripts/{}:redact", "RedactScript", s.setScriptStatus(client.REDACTED)},
+		{http.MethodGet, "/api/script-profile-limits", "GetScriptProfileLimits", s.getScriptProfileLimits},
+		{http.MethodGet, "/api/script-profiles", "ListScriptProfiles", s.listScriptProfiles},
+		{http.MethodPost, "/api/script-profiles", "CreateScriptProfile", s.createScriptProfile},
+		{http.MethodGet, "/api/script-profiles/{}", "GetScriptProfile", s.getScriptProfile},
+		{http.MethodPatch, "/api/script-profiles/{}", "UpdateScriptProfile", s.updateScriptProfile},
+		{http.MethodPost, "/api/script-profiles/{}:archive", "ArchiveScriptProfile", s.archiveScriptProfile},
+		{http.MethodGet, "/api/script-profiles/{}/activities", "ListScriptProfileActivities", s.listScriptProfileActivities},
+		{http.MethodGet, "/api/script-profiles/{}/computers", "ListScriptProfileComputers", s.listScriptProfileComputers},
+	}
+}
+
+// ScriptProfile returns the script profile with the given ID.
+func (s *Server) ScriptProfile(id int) (client.ScriptProfileDetail, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profile, ok := s.profiles[id]
+	if !ok {
+		return client.ScriptProfileDetail{}, false
+	}
+	return profile.detail, true
+}
+
+// RunScriptProfile runs a script profile as if its trigger had fired, on the
+// computers it targets, and returns the parent activity.
+func (s *Server) RunScriptProfile(id int) (client.Activity, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profile, ok := s.profiles[id]
+	if !ok {
+		return client.Activity{}, fmt.Errorf("no script profile with ID %d", id)
+	}
+	if profile.detail.Archived {
+		return client.Activity{}, fmt.Errorf("script profile %d is archived", id)
+	}
+
+	activity := s.runLocked(fmt.Sprintf("Run script profile: %s", profile.detail.Title), s.profileComputersLocked(profile.detail))
+	profile.activities = append(profile.activities, activity.Id)
+
+	last, err := toMap(activity)
+	if err != nil {
+		return client.Activity{}, err
+	}
+	profile.detail.Activities.LastActivity = &last
+
+	now := time.Now().UTC()
+	if err := setLastRun(&profile.detail.Trigger, now); err != nil {
+		return client.Activity{}, err
+	}
+
+	return activity, nil
+}
+
+func (s *Server) getScript(_ *http.Request, _ []byte, pathParams []int) (int, any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	script, ok := s.scripts[pathParams[0]]
+	if !ok {
+		return 0, errorf(http.StatusNotFound, "NotFound", "no script with ID %d", pathParams[0])
+	}
+	if !script.V2 {
+		return http.StatusOK, s.legacyScriptLocked(script)
+	}
+
+	v2 := s.v2ScriptLocked(script)
+	if script.Status != client.REDACTED {
+		v2.Code = &script.Code
+	}
+	return http.StatusOK, v2
+}
+
+func (s *Server) getScriptAttachment(_ *http.Request, _ []byte, pathParams []int) (int, any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	script, ok := s.scripts[pathParams[0]]
+	if !ok {
+		return 0, errorf(http.StatusNotFound, "NotFound", "no script with ID %d", pathParams[0])
+	}
+	for _, attachment := range script.Attachments {
+		if attachment.ID == pathParams[1] {
+			return http.StatusOK, string(attachment.Content)
+		}
+	}
+	return 0, errorf(http.StatusNotFound, "NotFound", "script %d has no attachment with ID %d", script.ID, pathParams[1])
+}
+
+func (s *Server) listScriptProfilesByScript(_ *http.Request, _ []byte, pathParams []int) (int, any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.scripts[pathParams[0]]; !ok {
+		return 0, errorf(http.StatusNotFound, "NotFound", "no script with ID %d", pathParams[0])
+	}
+	return http.StatusOK, s.scriptProfilesLocked(pathParams[0])
+}
+
+// setScriptStatus returns a handler that archives or redacts a v2 script.
+// Redacting a script also removes its code and attachments.
+func (s *Server) setScriptStatus(status client.V2ScriptStatus) func(*http.Request, []byte, []int) (int, any) {
+	return func(_ *http.Request, _ []byte, pathParams []int) (int, any) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		script, ok := s.scripts[pathParams[0]]
+		if !ok {
+			return 0, errorf(http.StatusNotFound, "NotFound", "no script with ID %d", pathParams[0])
+		}
+		if !script.V2 {
+			return 0, errorf(http.StatusBadRequest, "BadRequest", "script %d is a v1 script", script.ID)
+		}
+		if script.Status == client.REDACTED {
+			return 0, errorf(http.StatusBadRequest, "BadRequest", "script %d has been redacted", script.ID)
+		}
+
+		script.Status = status
+		if status == client.REDACTED {
+			script.Code = ""
+			script.Attachments = nil
+		}
+		return http.StatusNoContent, nil
+	}
+}
+
+func (s *Server) getScriptProfileLimits(_ *http.Request, _ []byte, _ []int) (int, any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return http.StatusOK, s.limits
+}
+
+func (s *Server) listScriptProfiles(r *http.Request, _ []byte, _ []int) (int, any) {
+	query := r.URL.Query()
+
+	archived := client.ListScriptProfilesParamsArchived(query.Get("archived"))
+	switch archived {
+	case "":
+		archived = client.ListScriptProfilesParamsArchivedActive
+	case client.ListScriptProfilesParamsArchivedActive, client.ListScriptProfilesParamsArchivedAll, client.ListScriptProfilesParamsArchivedArchived:
+	default:
+		return 0, errorf(http.StatusBadRequest, "BadRequest", "invalid archived filter %q", archived)
+	}
+
+	var names []string
+	if query.Get("names") != "" {
+		names = strings.Split(query.Get("names"), ",")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results := []client.ScriptProfileDetail{}
+	for _, id := range s.profileIDsLocked() {
+		detail := s.profiles[id].detail
+		if archived == client.ListScriptProfilesParamsArchivedActive && detail.Archived ||
+			archived == client.ListScriptProfilesParamsArchivedArchived && !detail.Archived {
+			continue
+		}
+		if names != nil && !slices.Contains(names, detail.Title) {
+			continue
+		}
+		results = append(results, detail)
+	}
+
+	return http.StatusOK, client.ScriptProfileListResponse{Count: len(results), Results: results}
+}
+
+func (s *Server) createScriptProfile(_ *http.Request, body []byte, _ []int) (int, any) {
+	var req client.ScriptProfileCreateBody
+	if err := json.Unmarshal(body, &req); err != nil {
+		return 0, errorf(http.StatusBadRequest, "BadRequest", "invalid script profile: %v", err)
+	}
+	if req.Title == "" || req.Username == "" {
+		return 0, errorf(http.StatusBadRequest, "BadRequest", "title and username are required")
+	}
+
+	trigger, err := createTrigger(req.Trigger)
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	script, ok := s.scripts[req.ScriptId]
+	if !ok {
+		return 0, errorf(http.StatusNotFound, "NotFound", "no script with ID %d", req.ScriptId)
+	}
+	if !script.V2 || script.Status != client.ACTIVE {
+		return 0, errorf(http.StatusBadRequest, "BadRequest", "script %d must be an active v2 script", script.ID)
+	}
+	if s.duplicateProfileLocked(0, req.Title) {
+		return 0, errorf(http.StatusConflict, "Conflict", "a script profile named %q already exists", req.Title)
+	}
+
+	active := 0
+	for _, profile := range s.profiles {
+		if !profile.detail.Archived {
+			active++
+		}
+	}
+	if active >= s.limits.MaxNumProfiles {
+		return 0, errorf(http.StatusBadRequest, "BadRequest", "the maximum of %d script profiles has been reached", s.limits.MaxNumProfiles)
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	detail := client.ScriptProfileDetail{
+		Id:           s.newIDLocked(),
+		Title:        req.Title,
+		ScriptId:     script.ID,
+		AccessGroup:  script.AccessGroup,
+		AllComputers: req.AllComputers != nil && *req.AllComputers,
+		Tags:         []string{},
+		TimeLimit:    req.TimeLimit,
+		Username:     req.Username,
+		Trigger:      trigger,
+		CreatedAt:    now,
+		LastEditedAt: now,
+	}
+	if req.Tags != nil {
+		detail.Tags = slices.Clone(*req.Tags)
+	}
+	if err := s.checkComputersLocked(&detail); err != nil {
+		return 0, err
+	}
+
+	s.profiles[detail.Id] = &scriptProfile{detail: detail}
+	return http.StatusCreated, detail
+}
+
+func (s *Server) getScriptProfile(_ *http.Request, _ []byte, pathParams []int) (int, any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profile, ok := s.profiles[pathParams[0]]
+	if !ok {
+		return 0, errorf(http.StatusNotFound, "NotFound", "no script profile with ID %d", pathParams[0])
+	}
+	return http.StatusOK, profile.detail
+}
+
+func (s *Server) updateScriptProfile(_ *http.Request, body []byte, pathParams []int) (int, any) {
+	var req client.ScriptProfilePatchBody
+	if err := json.Unmarshal(body, &req); err != nil {
+		return 0, errorf(http.StatusBadRequest, "BadRequest", "invalid script profile: %v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profile, ok := s.profiles[pathParams[0]]
+	if !ok {
+		return 0, errorf(http.StatusNotFound, "NotFound", "no script profile with ID %d", pathParams[0])
+	}
+	if profile.detail.Archived {
+		return 0, errorf(http.StatusBadRequest, "BadRequest", "script profile %d is archived", profile.detail.Id)
+	}
+
+	detail := profile.detail
+	if req.Title != nil {
+		if *req.Title == "" {
+			return 0, errorf(http.StatusBadRequest, "BadRequest", "title must not be empty")
+		}
+		if s.duplicateProfileLocked(detail.Id, *req.Title) {
+			return 0, errorf(http.StatusConflict, "Conflict", "a script profile named %q already exists", *req.Title)
+		}
+		detail.Title = *req.Title
+	}
+	if req.AllComputers != nil {
+		detail.AllComputers = *req.AllComputers
+	}
+	if req.Tags != nil {
+		detail.Tags = slices.Clone(*req.Tags)
+	}
+	if req.TimeLimit != nil {
+		detail.TimeLimit = *req.TimeLimit
+	}
+	if req.Username != nil {
+		detail.Username = *req.Username
+	}
+	if req.Trigger != nil {
+		trigger, err := patchTrigger(detail.Trigger, *req.Trigger)
+		if err != nil {
+			return 0, err
+		}
+		detail.Trigger = trigger
+	}
+	if err := s.checkComputersLocked(&detail); err != nil {
+		return 0, err
+	}
+
+	detail.LastEditedAt = time.Now().UTC().Format(time.RFC3339)
+	profile.detail = detail
+	return http.StatusOK, detail
+}
+
+func (s *Server) archiveScriptProfile(_ *http.Request, _ []byte, pathParams []int) (int, any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profile, ok := s.profiles[pathParams[0]]
+	if !ok {
+		return 0, errorf(http.StatusNotFound, "NotFound", "no script profile with ID %d", pathParams[0])
+	}
+	profile.detail.Archived = true
+	return http.StatusNoContent, nil
+}
+
+func (s *Server) listScriptProfileActivities(_ *http.Request, _ []byte, pathParams []int) (int, any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profile, ok := s.profiles[pathParams[0]]
+	if !ok {
+		return 0, errorf(http.StatusNotFound, "NotFound", "no script profile with ID %d", pathParams[0])
+	}
+
+	results := []map[string]any{}
+	for _, activity := range s.activity {
+		if !slices.Contains(profile.activities, activity.Id) {
+			continue
+		}
+		result, err := toMap(activity)
+		if err != nil {
+			return 0, err
+		}
+		results = append(results, result)
+	}
+	return http.StatusOK, client.ScriptProfileActivitiesListResponse{Count: len(results), Results: results}
+}
+
+func (s *Server) listScriptProfileComputers(_ *http.Request, _ []byte, pathParams []int) (int, any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profile, ok := s.profiles[pathParams[0]]
+	if !ok {
+		return 0, errorf(http.StatusNotFound, "NotFound", "no script profile with ID %d", pathParams[0])
+	}
+
+	results := []map[string]any{}
+	for _, computer := range s.profileComputersLocked(profile.detail) {
+		result, err := toMap(computer)
+		if err != nil {
+			return 0, err
+		}
+		results = append(results, result)
+	}
+	return http.StatusOK, client.ScriptProfileComputersListResponse{Count: len(results), Results: results}
+}
+
+// v2ScriptLocked returns the v2 representation of a script, without its
+// code.
+func (s *Server) v2ScriptLocked(script *Script) client.V2Script {
+	attachments := make([]client.ScriptAttachment, len(script.Attachments))
+	for i, attachment := range script.Attachments {
+		attachments[i] = client.ScriptAttachment{Id: attachment.ID, Filename: attachment.Filename}
+	}
+	profiles := s.scriptProfilesLocked(script.ID)
+
+	active := script.Status == client.ACTIVE
+	createdAt := script.CreatedAt.Format(time.RFC3339)
+	lastEditedAt := script.LastEditedAt.Format(time.RFC3339)
+
+	return client.V2Script{
+		Id:             script.ID,
+		Title:          script.Title,
+		Status:         script.Status,
+		AccessGroup:    &script.AccessGroup,
+		Attachments:    &attachments,
+		TimeLimit:      &script.TimeLimit,
+		Username:       &script.Username,
+		VersionNumber:  &script.VersionNumber,
+		ScriptProfiles: &profiles,
+		CreatedAt:      &createdAt,
+		LastEditedAt:   &lastEditedAt,
+		IsEditable:     &active,
+		IsExecutable:   &active,
+		IsRedactable:   &active,
+	}
+}
+
+// scriptProfilesLocked returns the script profiles that run a script.
+func (s *Server) scriptProfilesLocked(scriptID int) []client.ScriptProfile {
+	profiles := []client.ScriptProfile{}
+	for _, id := range s.profileIDsLocked() {
+		detail := s.profiles[id].detail
+		if detail.ScriptId == scriptID {
+			profiles = append(profiles, client.ScriptProfile{Id: detail.Id, Title: detail.Title})
+		}
+	}
+	return profiles
+}
+
+func (s *Server) profileIDsLocked() []int {
+	ids := make([]int, 0, len(s.profiles))
+	for id := range s.profiles {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// duplicateProfileLocked reports whether a script profile other than the one
+// with the given ID is named title.
+func (s *Server) duplicateProfileLocked(id int, title string) bool {
+	for _, profile := range s.profiles {
+		if profile.detail.Id != id && profile.detail.Title == title {
+			return true
+		}
+	}
+	return false
+}
+
+// checkComputersLocked counts the computers detail targets, returning an
+// error if there are more than the limit allows.
+func (s *Server) checkComputersLocked(detail *client.ScriptProfileDetail) error {
+	n := len(s.profileComputersLocked(*detail))
+	if n > s.limits.MaxNumComputers {
+		return errorf(http.StatusBadRequest, "BadRequest", "script profile targets %d computers, more than the maximum of %d", n, s.limits.MaxNumComputers)
+	}
+	detail.Computers.NumAssociatedComputers = n
+	return nil
+}
+
+// profileComputersLocked returns the computers a script profile targets:
+// every computer, or those with any of its tags.
+func (s *Server) profileComputersLocked(detail client.ScriptProfileDetail) []client.Computer {
+	var computers []client.Computer
+	for _, computer := range s.computers {
+		if detail.AllComputers || slices.ContainsFunc(detail.Tags, func(tag string) bool { return slices.Contains(computer.Tags, tag) }) {
+			computers = append(computers, computer)
+		}
+	}
+	return computers
+}
+
+// createTrigger returns the response form of the trigger in a create
+// request.
+func createTrigger(req client.ScriptProfileTriggerCreateRequest) (client.ScriptProfileTriggerResponse, error) {
+	var trigger client.ScriptProfileTriggerResponse
+
+	value, err := req.ValueByDiscriminator()
+	if err != nil {
+		return trigger, errorf(http.StatusBadRequest, "BadRequest", "invalid trigger: %v", err)
+	}
+
+	switch value := value.(type) {
+	case client.ScriptProfileEventTrigger:
+		if value.EventType == "" {
+			return trigger, errorf(http.StatusBadRequest, "BadRequest", "event_type is required")
+		}
+		err = trigger.FromScriptProfileEventTrigger(value)
+	case client.ScriptProfileScheduleDraftTrigger:
+		if err := checkInterval(value.Interval); err != nil {
+			return trigger, err
+		}
+		err = trigger.FromScriptProfileScheduleTrigger(client.ScriptProfileScheduleTrigger{
+			Interval:   value.Interval,
+			StartAfter: value.StartAfter,
+		})
+	case client.ScriptProfileOneTimeDraftTrigger:
+		err = trigger.FromScriptProfileOneTimeTrigger(client.ScriptProfileOneTimeTrigger{
+			Timestamp: value.Timestamp,
+			NextRun:   &value.Timestamp,
+		})
+	}
+	return trigger, err
+}
+
+// patchTrigger applies the trigger in a patch request to current. A
+// recurring trigger only replaces the fields it sets if current is also
+// recurring.
+func patchTrigger(current client.ScriptProfileTriggerResponse, req client.ScriptProfileTriggerPatchRequest) (client.ScriptProfileTriggerResponse, error) {
+	var trigger client.ScriptProfileTriggerResponse
+
+	value, err := req.ValueByDiscriminator()
+	if err != nil {
+		return trigger, errorf(http.StatusBadRequest, "BadRequest", "invalid trigger: %v", err)
+	}
+
+	switch value := value.(type) {
+	case client.ScriptProfileEventTrigger:
+		if value.EventType == "" {
+			return trigger, errorf(http.StatusBadRequest, "BadRequest", "event_type is required")
+		}
+		err = trigger.FromScriptProfileEventTrigger(value)
+	case client.ScriptProfileScheduleDraftEditTrigger:
+		schedule := client.ScriptProfileScheduleTrigger{}
+		if discriminator, _ := current.Discriminator(); discriminator == "recurring" {
+			if schedule, err = current.AsScriptProfileScheduleTrigger(); err != nil {
+				return trigger, err
+			}
+		}
+		if value.Interval != nil {
+			schedule.Interval = *value.Interval
+		}
+		if value.StartAfter != nil {
+			schedule.StartAfter = *value.StartAfter
+		}
+		if err := checkInterval(schedule.Interval); err != nil {
+			return trigger, err
+		}
+		err = trigger.FromScriptProfileScheduleTrigger(schedule)
+	case client.ScriptProfileOneTimeDraftTrigger:
+		err = trigger.FromScriptProfileOneTimeTrigger(client.ScriptProfileOneTimeTrigger{
+			Timestamp: value.Timestamp,
+			NextRun:   &value.Timestamp,
+		})
+	}
+	return trigger, err
+}
+
+// checkInterval returns an error if interval isn't a five-field cron
+// expression.
+func checkInterval(interval string) error {
+	if len(strings.Fields(interval)) != 5 {
+		return errorf(http.StatusBadRequest, "BadRequest", "invalid cron expression %q", interval)
+	}
+	return nil
+}
+
+// setLastRun records that the script profile with the given trigger ran at
+// t.
+func setLastRun(trigger *client.ScriptProfileTriggerResponse, t time.Time) error {
+	value, err := trigger.ValueByDiscriminator()
+	if err != nil {
+		return err
+	}
+
+	switch value := value.(type) {
+	case client.ScriptProfileScheduleTrigger:
+		value.LastRun = &t
+		return trigger.FromScriptProfileScheduleTrigger(value)
+	case client.ScriptProfileOneTimeTrigger:
+		value.LastRun, value.NextRun, value.IsFinished = &t, nil, true
+		return trigger.FromScriptProfileOneTimeTrigger(value)
+	}
+	return nil
+}
+
+// matchRoute reports whether path matches a v2 route template, and returns
+// its path parameters.
+func matchRoute(template, path string) ([]int, bool) {
+	templateSegments := strings.Split(template, "/")
+	segments := strings.Split(path, "/")
+	if len(templateSegments) != len(segments) {
+		return nil, false
+	}
+
+	var params []int
+	for i, t := range templateSegments {
+		before, after, isParam := strings.Cut(t, "{}")
+		if !isParam {
+			if t != segments[i] {
+				return nil, false
+			}
+			continue
+		}
+
+		value, ok := strings.CutPrefix(segments[i], before)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = strings.CutSuffix(value, after); !ok {
+			return nil, false
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, false
+		}
+		params = append(params, n)
+	}
+	return params, true
+}
+
+// toMap converts v to the generic form used by list responses without a
+// schema.
+func toMap(v any) (map[string]any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	return m, json.Unmarshal(b, &m)
+}
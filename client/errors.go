@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// Sentinel errors that an *APIError matches with errors.Is, based on its
+// HTTP status.
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+)
+
+// requestIDHeaders are the response headers checked, in order, for an ID
+// identifying the request in the server logs.
+var requestIDHeaders = []string{"X-Request-Id", "X-Landscape-Request-Id", "X-Correlation-Id"}
+
+// APIError is returned when the Landscape API responds with a non-2xx status.
+type APIError struct {
+	// StatusCode HTTP status code of the response.
+	StatusCode int
+
+	// Code Landscape error code, ex. "UnknownComputer". Empty if the body didn't include one.
+	Code string
+
+	// Message Human-readable error message from the body, or the HTTP status text if there was none.
+	Message string
+
+	// RequestID Server-assigned ID of the request, if the response included one.
+	RequestID string
+
+	// Operation Legacy action name, or the method and path of the request.
+	Operation string
+
+	// Body Raw response body.
+	Body []byte
+}
+
+// Error implements error for APIError.
+func (e *APIError) Error() string {
+	var b strings.Builder
+
+	b.WriteString("landscape API")
+	if e.Operation != "" {
+		b.WriteString(" " + e.Operation)
+	}
+	fmt.Fprintf(&b, " failed with status: %d", e.StatusCode)
+	if e.Code != "" {
+		b.WriteString(" (" + e.Code + ")")
+	}
+	if e.Message != "" {
+		b.WriteString(": " + e.Message)
+	}
+	if e.RequestID != "" {
+		b.WriteString(" [request ID " + e.RequestID + "]")
+	}
+
+	return b.String()
+}
+
+// Is reports whether e matches one of ErrUnauthorized, ErrForbidden or
+// ErrNotFound.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	}
+	return false
+}
+
+// NewAPIError builds an *APIError from a response and its already-read body.
+// It understands both the v2 Error model and the {"error", "message"} bodies
+// returned by legacy actions.
+func NewAPIError(res *http.Response, body []byte) *APIError {
+	e := &APIError{Body: body}
+	if res == nil {
+		return e
+	}
+
+	e.StatusCode = res.StatusCode
+	for _, h := range requestIDHeaders {
+		if id := res.Header.Get(h); id != "" {
+			e.RequestID = id
+			break
+		}
+	}
+	if req := res.Request; req != nil && req.URL != nil {
+		if action := req.URL.Query().Get("action"); action != "" {
+			e.Operation = action
+		} else {
+			e.Operation = req.Method + " " + req.URL.Path
+		}
+	}
+
+	var payload struct {
+		Code    json.RawMessage `json:"code"`
+		Error   string          `json:"error"`
+		Message string          `json:"message"`
+		Detail  string          `json:"detail"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil {
+		e.Message = payload.Message
+		if e.Message == "" {
+			e.Message = payload.Detail
+		}
+
+		// The v2 API reports the HTTP status in "code"; legacy actions put
+		// a named error there or in "error".
+		var code string
+		if json.Unmarshal(payload.Code, &code) == nil {
+			e.Code = code
+		}
+		if payload.Error != "" {
+			e.Code = payload.Error
+		}
+	}
+
+	if e.Message == "" {
+		e.Message = http.StatusText(e.StatusCode)
+	}
+
+	return e
+}
+
+// CheckResponse returns an *APIError if resp, one of the generated
+// *WithResponse response types, has a non-2xx status. It returns nil
+// otherwise.
+func CheckResponse(resp any) error {
+	res, body, ok := responseParts(resp)
+	if !ok {
+		return fmt.Errorf("unsupported response type %T", resp)
+	}
+	if res == nil {
+		return errors.New("response has no HTTP response")
+	}
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return nil
+	}
+
+	return NewAPIError(res, body)
+}
+
+// responseParts extracts the HTTPResponse and Body fields shared by every
+// generated response type.
+func responseParts(resp any) (*http.Response, []byte, bool) {
+	v := reflect.ValueOf(resp)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil, nil, false
+	}
+	v = v.Elem()
+
+	resField := v.FieldByName("HTTPResponse")
+	bodyField := v.FieldByName("Body")
+	if !resField.IsValid() || !bodyField.IsValid() {
+		return nil, nil, false
+	}
+
+	res, ok := resField.Interface().(*http.Response)
+	if !ok {
+		return nil, nil, false
+	}
+	body, ok := bodyField.Interface().([]byte)
+	if !ok {
+		return nil, nil, false
+	}
+
+	return res, body, true
+}
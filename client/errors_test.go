@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCheckResponse(t *testing.T) {
+	handler := http.NewServeMux()
+	handler.HandleFunc("/api/scripts/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "req-123")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code": 404, "message": "Script not found"}`))
+	})
+	handler.HandleFunc("/api/scripts/2", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": 2, "title": "ok"}`))
+	})
+	handler.HandleFunc("/api/scripts/3", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte("<html>bad gateway</html>"))
+	})
+	handler.HandleFunc("/api/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error": "Forbidden", "message": "You don't have permission to do that."}`))
+	})
+	handler.HandleFunc("/api/login", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"code": 401, "message": "Invalid credentials"}`))
+	})
+
+	server := httptest.NewTLSServer(handler)
+	defer server.Close()
+
+	api, err := NewClientWithResponses(server.URL, WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("failed to init client with responses: %v", err)
+	}
+
+	ctx := context.Background()
+
+	t.Run("v2 error", func(t *testing.T) {
+		resp, err := api.GetScriptWithResponse(ctx, 1)
+		if err != nil {
+			t.Fatalf("GetScriptWithResponse failed: %v", err)
+		}
+
+		err = CheckResponse(resp)
+		if !errors.Is(err, ErrNotFound) || errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("expected *APIError, got %T", err)
+		}
+		if apiErr.StatusCode != http.StatusNotFound || apiErr.Message != "Script not found" || apiErr.RequestID != "req-123" || apiErr.Code != "" {
+			t.Fatalf("unexpected error: %+v", apiErr)
+		}
+		if apiErr.Operation != "GET /api/scripts/1" {
+			t.Fatalf("unexpected operation: %q", apiErr.Operation)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		resp, err := api.GetScriptWithResponse(ctx, 2)
+		if err != nil {
+			t.Fatalf("GetScriptWithResponse failed: %v", err)
+		}
+		if err := CheckResponse(resp); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	})
+
+	t.Run("non-JSON body", func(t *testing.T) {
+		resp, err := api.GetScriptWithResponse(ctx, 3)
+		if err != nil {
+			t.Fatalf("GetScriptWithResponse failed: %v", err)
+		}
+
+		var apiErr *APIError
+		if !errors.As(CheckResponse(resp), &apiErr) {
+			t.Fatal("expected *APIError")
+		}
+		if apiErr.StatusCode != http.StatusBadGateway || apiErr.Message != "Bad Gateway" || string(apiErr.Body) != "<html>bad gateway</html>" {
+			t.Fatalf("unexpected error: %+v", apiErr)
+		}
+	})
+
+	t.Run("legacy error", func(t *testing.T) {
+		_, err := api.GetComputersTyped(ctx, &LegacyGetComputersParams{})
+		if !errors.Is(err, ErrForbidden) {
+			t.Fatalf("expected ErrForbidden, got %v", err)
+		}
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("expected *APIError, got %T", err)
+		}
+		if apiErr.Code != "Forbidden" || apiErr.Operation != "GetComputers" {
+			t.Fatalf("unexpected error: %+v", apiErr)
+		}
+		if !strings.Contains(err.Error(), "GetComputers failed with status: 403 (Forbidden): You don't have permission to do that.") {
+			t.Fatalf("unexpected message: %s", err)
+		}
+	})
+
+	t.Run("login", func(t *testing.T) {
+		_, err := NewEmailPasswordProvider("jan@example.com", "wrong", nil).Login(ctx, api)
+		if !errors.Is(err, ErrUnauthorized) {
+			t.Fatalf("expected ErrUnauthorized, got %v", err)
+		}
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		if err := CheckResponse(&http.Response{StatusCode: http.StatusOK}); err == nil {
+			t.Fatal("expected an error for a non-generated response")
+		}
+	})
+}
@@ -0,0 +1,149 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Command legacyparamsgen reads the generated client and writes a table of the
+// query parameters accepted by each legacy action, recording which of them are
+// lists or maps. The client uses the table to encode those parameters in the
+// dotted form Landscape expects (ex. computer_ids.1, computer_ids.2).
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var actionPattern = regexp.MustCompile(`\?action=(\w+)`)
+
+func main() {
+	in := flag.String("in", "client.gen.go", "generated client to read")
+	out := flag.String("out", "legacy_params.gen.go", "file to write")
+	flag.Parse()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, *in, nil, 0)
+	if err != nil {
+		log.Fatalf("failed to parse %s: %v", *in, err)
+	}
+
+	structs := map[string]*ast.StructType{}
+	actions := map[string]string{}
+
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				ts, ok := spec.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+				if st, ok := ts.Type.(*ast.StructType); ok && strings.HasPrefix(ts.Name.Name, "Legacy") && strings.HasSuffix(ts.Name.Name, "Params") {
+					structs[ts.Name.Name] = st
+				}
+			}
+		case *ast.FuncDecl:
+			if d.Recv != nil || !strings.HasPrefix(d.Name.Name, "NewLegacy") || !strings.HasSuffix(d.Name.Name, "Request") {
+				continue
+			}
+			paramsType := ""
+			for _, field := range d.Type.Params.List {
+				if star, ok := field.Type.(*ast.StarExpr); ok {
+					if ident, ok := star.X.(*ast.Ident); ok {
+						paramsType = ident.Name
+					}
+				}
+			}
+			if paramsType == "" {
+				continue
+			}
+			ast.Inspect(d.Body, func(n ast.Node) bool {
+				lit, ok := n.(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					return true
+				}
+				if m := actionPattern.FindStringSubmatch(lit.Value); m != nil {
+					actions[paramsType] = m[1]
+				}
+				return true
+			})
+		}
+	}
+
+	type param struct{ name, kind string }
+	table := map[string][]param{}
+
+	for typeName, action := range actions {
+		st, ok := structs[typeName]
+		if !ok {
+			log.Fatalf("no struct found for %s", typeName)
+		}
+		for _, field := range st.Fields.List {
+			if field.Tag == nil {
+				continue
+			}
+			tag, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				log.Fatalf("bad tag on %s: %v", typeName, err)
+			}
+			name, _, _ := strings.Cut(reflect.StructTag(tag).Get("form"), ",")
+			if name == "" {
+				continue
+			}
+			table[action] = append(table[action], param{name, kindOf(field.Type)})
+		}
+	}
+
+	names := make([]string, 0, len(table))
+	for action := range table {
+		names = append(names, action)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "// Code generated by legacyparamsgen. DO NOT EDIT.")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "package client")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "// legacyActionParams maps each legacy action to the kind of each of its query parameters.")
+	fmt.Fprintln(&buf, "var legacyActionParams = map[string]map[string]legacyParamKind{")
+	for _, action := range names {
+		fmt.Fprintf(&buf, "\t%q: {\n", action)
+		for _, p := range table[action] {
+			fmt.Fprintf(&buf, "\t\t%q: %s,\n", p.name, p.kind)
+		}
+		fmt.Fprintln(&buf, "\t},")
+	}
+	fmt.Fprintln(&buf, "}")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("failed to format output: %v", err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatalf("failed to write %s: %v", *out, err)
+	}
+}
+
+func kindOf(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	switch expr.(type) {
+	case *ast.ArrayType:
+		return "legacyParamList"
+	case *ast.MapType:
+		return "legacyParamMap"
+	default:
+		return "legacyParamScalar"
+	}
+}
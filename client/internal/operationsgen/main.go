@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Command operationsgen reads the generated client and writes a table mapping
+// each request the client can send back to its OpenAPI operation ID: legacy
+// requests by their action, and v2 requests by their method and path
+// template. The client uses the table to name spans and metrics.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var actionPattern = regexp.MustCompile(`\?action=(\w+)`)
+
+// operation is a v2 operation, with each path parameter replaced by {}.
+type operation struct {
+	id, method, path string
+}
+
+func main() {
+	in := flag.String("in", "client.gen.go", "generated client to read")
+	out := flag.String("out", "operations.gen.go", "file to write")
+	flag.Parse()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, *in, nil, 0)
+	if err != nil {
+		log.Fatalf("failed to parse %s: %v", *in, err)
+	}
+
+	legacy := map[string]string{}
+	var operations []operation
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || !strings.HasPrefix(fn.Name.Name, "New") {
+			continue
+		}
+		id := strings.TrimPrefix(fn.Name.Name, "New")
+		id, ok = strings.CutSuffix(id, "RequestWithBody")
+		if !ok {
+			id, ok = strings.CutSuffix(id, "Request")
+		}
+		if !ok {
+			continue
+		}
+
+		// Builders that take a typed body delegate to the WithBody builder,
+		// so only builders that format a path and create a request count.
+		path, method := requestPathAndMethod(fn.Body)
+		if path == "" || method == "" {
+			continue
+		}
+
+		if m := actionPattern.FindStringSubmatch(path); m != nil {
+			legacy[m[1]] = id
+			continue
+		}
+		operations = append(operations, operation{
+			id:     id,
+			method: method,
+			path:   strings.ReplaceAll(path, "%s", "{}"),
+		})
+	}
+
+	actions := make([]string, 0, len(legacy))
+	for action := range legacy {
+		actions = append(actions, action)
+	}
+	sort.Strings(actions)
+
+	sort.Slice(operations, func(i, j int) bool {
+		if operations[i].path != operations[j].path {
+			return operations[i].path < operations[j].path
+		}
+		return operations[i].method < operations[j].method
+	})
+
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "// Code generated by operationsgen. DO NOT EDIT.")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "package client")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "// legacyOperations maps each legacy action to its operation ID.")
+	fmt.Fprintln(&buf, "var legacyOperations = map[string]string{")
+	for _, action := range actions {
+		fmt.Fprintf(&buf, "\t%q: %q,\n", action, legacy[action])
+	}
+	fmt.Fprintln(&buf, "}")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "// operations lists the v2 operations. {} stands for a path parameter.")
+	fmt.Fprintln(&buf, "var operations = []operation{")
+	for _, op := range operations {
+		fmt.Fprintf(&buf, "\t{ID: %q, Method: %q, Path: %q},\n", op.id, op.method, op.path)
+	}
+	fmt.Fprintln(&buf, "}")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("failed to format output: %v", err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatalf("failed to write %s: %v", *out, err)
+	}
+}
+
+// requestPathAndMethod returns the path format passed to fmt.Sprintf and the
+// method passed to http.NewRequest in a request builder.
+func requestPathAndMethod(body *ast.BlockStmt) (path, method string) {
+	ast.Inspect(body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		pkg, ok := sel.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		lit, ok := call.Args[0].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return true
+		}
+		value, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return true
+		}
+
+		switch {
+		case pkg.Name == "fmt" && sel.Sel.Name == "Sprintf" && strings.HasPrefix(value, "/"):
+			path = value
+		case pkg.Name == "http" && sel.Sel.Name == "NewRequest":
+			method = value
+		}
+		return true
+	})
+	return path, method
+}
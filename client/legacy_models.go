@@ -0,0 +1,446 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import "encoding/json"
+
+// The models in this file describe the JSON payloads returned by legacy
+// (?action=) API calls. The OpenAPI spec types those responses as
+// LegacyActionResponse (an untyped interface{}), so these are maintained by
+// hand. Fields whose shape differs between Landscape versions are kept as
+// json.RawMessage.
+
+// Computer is a computer registered with Landscape, as returned by GetComputers.
+type Computer struct {
+	// Id The ID of the computer.
+	Id int `json:"id"`
+
+	// Title The title of the computer.
+	Title string `json:"title"`
+
+	// Hostname The hostname reported by the computer.
+	Hostname string `json:"hostname"`
+
+	// Comment A free-form comment attached to the computer.
+	Comment string `json:"comment"`
+
+	// AccessGroup The access group the computer belongs to.
+	AccessGroup string `json:"access_group"`
+
+	// Tags The tags applied to the computer.
+	Tags []string `json:"tags"`
+
+	// Distribution The release of the distribution installed, ex. "22.04".
+	Distribution string `json:"distribution"`
+
+	// TotalMemory The total memory of the computer in MB.
+	TotalMemory *int `json:"total_memory"`
+
+	// TotalSwap The total swap of the computer in MB.
+	TotalSwap *int `json:"total_swap"`
+
+	// RebootRequiredFlag Whether the computer needs to be rebooted.
+	RebootRequiredFlag bool `json:"reboot_required_flag"`
+
+	// UpdateManagerPrompt The release upgrade prompt setting of the computer.
+	UpdateManagerPrompt string `json:"update_manager_prompt"`
+
+	// LastPingTime The last time the computer pinged Landscape.
+	LastPingTime *string `json:"last_ping_time"`
+
+	// LastExchangeTime The last time the computer exchanged data with Landscape.
+	LastExchangeTime *string `json:"last_exchange_time"`
+
+	// Annotations Custom annotations, when requested with with_annotations.
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// NetworkDevices Network devices, when requested with with_network.
+	NetworkDevices []ComputerNetworkDevice `json:"network_devices,omitempty"`
+
+	// Hardware Hardware information, when requested with with_hardware.
+	Hardware json.RawMessage `json:"hardware,omitempty"`
+
+	// CloudInstanceMetadata Metadata reported by cloud instances.
+	CloudInstanceMetadata json.RawMessage `json:"cloud_instance_metadata,omitempty"`
+}
+
+// ComputerNetworkDevice is a network device attached to a Computer.
+type ComputerNetworkDevice struct {
+	// Interface The name of the interface, ex. "eth0".
+	Interface string `json:"interface"`
+
+	// IpAddress The IPv4 address of the interface.
+	IpAddress string `json:"ip_address"`
+
+	// MacAddress The hardware address of the interface.
+	MacAddress string `json:"mac_address"`
+
+	// Netmask The netmask of the interface.
+	Netmask string `json:"netmask"`
+}
+
+// Activity is an operation queued for one or more computers, as returned by
+// GetActivities and by actions such as ExecuteScript or RebootComputers.
+type Activity struct {
+	// Id The ID of the activity.
+	Id int `json:"id"`
+
+	// Type The kind of activity, ex. "ExecuteScriptRequest".
+	Type string `json:"type"`
+
+	// Summary A short human-readable description of the activity.
+	Summary string `json:"summary"`
+
+	// ActivityStatus The status of the activity, ex. "succeeded".
+	ActivityStatus string `json:"activity_status"`
+
+	// ComputerId The computer the activity runs on, if it targets a single computer.
+	ComputerId *int `json:"computer_id"`
+
+	// ParentId The parent activity, if this is a child activity.
+	ParentId *int `json:"parent_id"`
+
+	// Creator The person who created the activity.
+	Creator *ActivityCreator `json:"creator"`
+
+	// CreationTime When the activity was created.
+	CreationTime *string `json:"creation_time"`
+
+	// ModificationTime When the activity was last modified.
+	ModificationTime *string `json:"modification_time"`
+
+	// ApprovalTime When the activity was approved.
+	ApprovalTime *string `json:"approval_time"`
+
+	// DeliveryTime When the activity was delivered to the computer.
+	DeliveryTime *string `json:"delivery_time"`
+
+	// CompletionTime When the activity completed.
+	CompletionTime *string `json:"completion_time"`
+
+	// DeliverAfter The earliest time the activity will be delivered.
+	DeliverAfter *string `json:"deliver_after"`
+
+	// ResultCode The exit code reported by the computer.
+	ResultCode *int `json:"result_code"`
+
+	// ResultText The output reported by the computer.
+	ResultText *string `json:"result_text"`
+
+	// Children The child activities, one per targeted computer.
+	Children []Activity `json:"children,omitempty"`
+}
+
+// ActivityCreator is the person who created an Activity.
+type ActivityCreator struct {
+	// Id The ID of the person.
+	Id *int `json:"id"`
+
+	// Name The name of the person.
+	Name *string `json:"name"`
+
+	// Email The email address of the person.
+	Email *string `json:"email"`
+}
+
+// Package is a package known to Landscape, as returned by GetPackages.
+type Package struct {
+	// Name The name of the package.
+	Name string `json:"name"`
+
+	// Summary The one-line description of the package.
+	Summary string `json:"summary"`
+
+	// Version The version of the package.
+	Version string `json:"version"`
+
+	// Computers The IDs of the computers the package is in each state on.
+	Computers PackageComputers `json:"computers"`
+}
+
+// PackageComputers groups computer IDs by the state of a Package on them.
+type PackageComputers struct {
+	Available []int `json:"available"`
+	Installed []int `json:"installed"`
+	Upgrades  []int `json:"upgrades"`
+	Held      []int `json:"held"`
+}
+
+// Distribution is a repository distribution, as returned by GetDistributions
+// and CreateDistribution.
+type Distribution struct {
+	// Name The name of the distribution.
+	Name string `json:"name"`
+
+	// AccessGroup The access group the distribution belongs to.
+	AccessGroup string `json:"access_group"`
+
+	// CreationTime When the distribution was created.
+	CreationTime *string `json:"creation_time"`
+
+	// Series The series in the distribution.
+	Series []Series `json:"series"`
+}
+
+// Series is a series within a Distribution, as returned by CreateSeries.
+type Series struct {
+	// Name The name of the series.
+	Name string `json:"name"`
+
+	// CreationTime When the series was created.
+	CreationTime *string `json:"creation_time"`
+
+	// Pockets The pockets in the series.
+	Pockets []Pocket `json:"pockets"`
+}
+
+// Pocket is a pocket within a Series, as returned by CreatePocket.
+type Pocket struct {
+	// Name The name of the pocket.
+	Name string `json:"name"`
+
+	// Mode The pocket mode: "pull", "mirror" or "upload".
+	Mode string `json:"mode"`
+
+	// Components The components the pocket handles.
+	Components []string `json:"components"`
+
+	// Architectures The architectures the pocket handles.
+	Architectures []string `json:"architectures"`
+
+	// GpgKey The key used to sign the pocket's package lists.
+	GpgKey *GPGKey `json:"gpg_key"`
+
+	// IncludeUdeb Whether udeb packages are included.
+	IncludeUdeb bool `json:"include_udeb"`
+
+	// MirrorUri The URI mirrored by mirror pockets.
+	MirrorUri *string `json:"mirror_uri,omitempty"`
+
+	// MirrorSuite The suite mirrored by mirror pockets.
+	MirrorSuite *string `json:"mirror_suite,omitempty"`
+
+	// MirrorGpgKey The key used to verify the mirrored archive.
+	MirrorGpgKey *GPGKey `json:"mirror_gpg_key,omitempty"`
+
+	// PullPocket The pocket packages are pulled from, for pull pockets.
+	PullPocket *string `json:"pull_pocket,omitempty"`
+
+	// PackageFilterType The filter type for pull pockets: "whitelist" or "blacklist".
+	PackageFilterType *string `json:"package_filter_type,omitempty"`
+
+	// Filters The package filters for pull pockets.
+	Filters []string `json:"filters,omitempty"`
+
+	// UploadAllowUnsigned Whether unsigned uploads are accepted, for upload pockets.
+	UploadAllowUnsigned *bool `json:"upload_allow_unsigned,omitempty"`
+
+	// UploadGpgKeys The keys accepted for uploads, for upload pockets.
+	UploadGpgKeys []GPGKey `json:"upload_gpg_keys,omitempty"`
+
+	// LastSyncStatus The status of the latest sync, when requested with include_latest_sync.
+	LastSyncStatus *string `json:"last_sync_status,omitempty"`
+
+	// CreationTime When the pocket was created.
+	CreationTime *string `json:"creation_time"`
+}
+
+// GPGKey is a GPG key stored in Landscape, as returned by GetGPGKeys and
+// ImportGPGKey.
+type GPGKey struct {
+	// Id The ID of the key.
+	Id int `json:"id"`
+
+	// Name The name of the key.
+	Name string `json:"name"`
+
+	// KeyId The GPG key ID.
+	KeyId string `json:"key_id"`
+
+	// Fingerprint The fingerprint of the key.
+	Fingerprint string `json:"fingerprint"`
+
+	// HasSecret Whether the secret part of the key is stored.
+	HasSecret bool `json:"has_secret"`
+}
+
+// AccessGroup is an access group, as returned by GetAccessGroups.
+type AccessGroup struct {
+	// Name The name of the access group.
+	Name string `json:"name"`
+
+	// Title The display title of the access group.
+	Title string `json:"title"`
+
+	// Parent The name of the parent access group.
+	Parent string `json:"parent"`
+}
+
+// Role is an administrator role, as returned by GetRoles.
+type Role struct {
+	// Name The name of the role.
+	Name string `json:"name"`
+
+	// Description The description of the role.
+	Description *string `json:"description"`
+
+	// Persons The email addresses of the administrators with the role.
+	Persons []string `json:"persons"`
+
+	// Permissions The access group permissions granted by the role.
+	Permissions []string `json:"permissions"`
+
+	// GlobalPermissions The account-wide permissions granted by the role.
+	GlobalPermissions []string `json:"global_permissions"`
+
+	// AccessGroups The access groups the permissions apply to.
+	AccessGroups []string `json:"access_groups"`
+}
+
+// UpgradeProfile is a package upgrade profile, as returned by
+// GetUpgradeProfiles.
+type UpgradeProfile struct {
+	// Id The ID of the profile.
+	Id int `json:"id"`
+
+	// Name The name of the profile.
+	Name string `json:"name"`
+
+	// Title The display title of the profile.
+	Title string `json:"title"`
+
+	// AccessGroup The access group the profile belongs to.
+	AccessGroup string `json:"access_group"`
+
+	// AllComputers Whether the profile applies to all computers.
+	AllComputers bool `json:"all_computers"`
+
+	// Tags The tags the profile applies to.
+	Tags []string `json:"tags"`
+
+	// UpgradeType The kind of upgrades applied: "all" or "security".
+	UpgradeType string `json:"upgrade_type"`
+
+	// Autoremove Whether unused packages are removed after upgrading.
+	Autoremove bool `json:"autoremove"`
+
+	// Every How often the profile runs: "hour" or "week".
+	Every string `json:"every"`
+
+	// OnDays The days of the week the profile runs on, for weekly profiles.
+	OnDays []string `json:"on_days"`
+
+	// AtHour The hour the profile runs at, for weekly profiles.
+	AtHour *int `json:"at_hour"`
+
+	// AtMinute The minute the profile runs at.
+	AtMinute int `json:"at_minute"`
+
+	// DeliverWithin The number of hours within which the upgrade is delivered.
+	DeliverWithin int `json:"deliver_within"`
+
+	// DeliverDelayWindow The number of minutes delivery is randomised over.
+	DeliverDelayWindow *int `json:"deliver_delay_window"`
+
+	// NextRun The next time the profile runs.
+	NextRun *string `json:"next_run"`
+}
+
+// RepositoryProfile is a repository profile, as returned by
+// GetRepositoryProfiles.
+type RepositoryProfile struct {
+	// Id The ID of the profile.
+	Id int `json:"id"`
+
+	// Name The name of the profile.
+	Name string `json:"name"`
+
+	// Title The display title of the profile.
+	Title string `json:"title"`
+
+	// Description The description of the profile.
+	Description string `json:"description"`
+
+	// AccessGroup The access group the profile belongs to.
+	AccessGroup string `json:"access_group"`
+
+	// AllComputers Whether the profile applies to all computers.
+	AllComputers bool `json:"all_computers"`
+
+	// Tags The tags the profile applies to.
+	Tags []string `json:"tags"`
+
+	// AptSources The APT sources in the profile.
+	AptSources []APTSource `json:"apt_sources"`
+
+	// Pockets The pockets in the profile.
+	Pockets []Pocket `json:"pockets"`
+
+	// PendingCount The number of computers the profile is pending on.
+	PendingCount int `json:"pending_count"`
+}
+
+// APTSource is an APT source, as returned by GetAPTSources and as part of a
+// RepositoryProfile.
+type APTSource struct {
+	// Id The ID of the source.
+	Id int `json:"id"`
+
+	// Name The name of the source.
+	Name string `json:"name"`
+
+	// Line The sources.list line of the source.
+	Line string `json:"line"`
+
+	// GpgKey The name of the key used to verify the source.
+	GpgKey *string `json:"gpg_key"`
+
+	// AccessGroup The access group the source belongs to.
+	AccessGroup string `json:"access_group"`
+}
+
+// SavedSearch is a saved computer search, as returned by GetSavedSearches.
+type SavedSearch struct {
+	// Name The name of the search.
+	Name string `json:"name"`
+
+	// Title The display title of the search.
+	Title string `json:"title"`
+
+	// Search The search query.
+	Search string `json:"search"`
+}
+
+// Alert is an alert configuration, as returned by GetAlerts.
+type Alert struct {
+	// AlertType The type of the alert, ex. "PackageUpgradesAlert".
+	AlertType string `json:"alert_type"`
+
+	// Description The description of the alert.
+	Description string `json:"description"`
+
+	// AllComputers Whether the alert is associated with all computers.
+	AllComputers bool `json:"all_computers"`
+
+	// Tags The tags the alert is associated with.
+	Tags []string `json:"tags"`
+
+	// Subscribed Whether the current user is subscribed to the alert.
+	Subscribed bool `json:"subscribed"`
+}
+
+// Administrator is an administrator of the account, as returned by
+// GetAdministrators.
+type Administrator struct {
+	// Id The ID of the administrator.
+	Id int `json:"id"`
+
+	// Name The name of the administrator.
+	Name string `json:"name"`
+
+	// Email The email address of the administrator.
+	Email string `json:"email"`
+
+	// Roles The roles assigned to the administrator.
+	Roles []string `json:"roles"`
+}
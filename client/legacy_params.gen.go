@@ -0,0 +1,669 @@
+// Code generated by legacyparamsgen. DO NOT EDIT.
+
+package client
+
+// legacyActionParams maps each legacy action to the kind of each of its query parameters.
+var legacyActionParams = map[string]map[string]legacyParamKind{
+	"AcceptPendingComputers": {
+		"computer_ids": legacyParamList,
+		"existing_ids": legacyParamMap,
+		"access_group": legacyParamScalar,
+	},
+	"AddAPTSourcesToRepositoryProfile": {
+		"name":        legacyParamScalar,
+		"apt_sources": legacyParamList,
+	},
+	"AddAccessGroupsToRole": {
+		"name":          legacyParamScalar,
+		"access_groups": legacyParamList,
+	},
+	"AddAnnotationToComputers": {
+		"query": legacyParamScalar,
+		"key":   legacyParamScalar,
+		"value": legacyParamScalar,
+	},
+	"AddPackageFiltersToPocket": {
+		"name":         legacyParamScalar,
+		"series":       legacyParamScalar,
+		"distribution": legacyParamScalar,
+		"packages":     legacyParamList,
+	},
+	"AddPermissionsToRole": {
+		"name":        legacyParamScalar,
+		"permissions": legacyParamList,
+	},
+	"AddPersonsToRole": {
+		"name":    legacyParamScalar,
+		"persons": legacyParamList,
+	},
+	"AddPocketsToRepositoryProfile": {
+		"name":         legacyParamScalar,
+		"pockets":      legacyParamList,
+		"series":       legacyParamScalar,
+		"distribution": legacyParamScalar,
+	},
+	"AddTagsToComputers": {
+		"query": legacyParamScalar,
+		"tags":  legacyParamList,
+	},
+	"AddUploaderGPGKeysToPocket": {
+		"name":         legacyParamScalar,
+		"series":       legacyParamScalar,
+		"distribution": legacyParamScalar,
+		"gpg_keys":     legacyParamList,
+	},
+	"ApproveActivities": {
+		"query": legacyParamScalar,
+	},
+	"AssociateAlert": {
+		"name":          legacyParamScalar,
+		"tags":          legacyParamList,
+		"all_computers": legacyParamScalar,
+	},
+	"AssociatePackageProfile": {
+		"name":          legacyParamScalar,
+		"tags":          legacyParamList,
+		"all_computers": legacyParamScalar,
+	},
+	"AssociateRemovalProfile": {
+		"name":          legacyParamScalar,
+		"tags":          legacyParamList,
+		"all_computers": legacyParamScalar,
+	},
+	"AssociateRepositoryProfile": {
+		"name":          legacyParamScalar,
+		"tags":          legacyParamList,
+		"all_computers": legacyParamScalar,
+	},
+	"AssociateUpgradeProfile": {
+		"name":          legacyParamScalar,
+		"tags":          legacyParamList,
+		"all_computers": legacyParamScalar,
+	},
+	"CancelActivities": {
+		"query": legacyParamScalar,
+	},
+	"ChangeComputersAccessGroup": {
+		"query":        legacyParamScalar,
+		"access_group": legacyParamScalar,
+	},
+	"CopyPackageProfile": {
+		"name":             legacyParamScalar,
+		"destination_name": legacyParamScalar,
+		"title":            legacyParamScalar,
+		"description":      legacyParamScalar,
+		"access_group":     legacyParamScalar,
+	},
+	"CopyRole": {
+		"name":             legacyParamScalar,
+		"destination_name": legacyParamScalar,
+		"description":      legacyParamScalar,
+	},
+	"CopyScript": {
+		"script_id":         legacyParamScalar,
+		"destination_title": legacyParamScalar,
+		"access_group":      legacyParamScalar,
+	},
+	"CreateAPTSource": {
+		"name":         legacyParamScalar,
+		"apt_line":     legacyParamScalar,
+		"gpg_key":      legacyParamScalar,
+		"access_group": legacyParamScalar,
+	},
+	"CreateAccessGroup": {
+		"title":  legacyParamScalar,
+		"parent": legacyParamScalar,
+	},
+	"CreateChildComputer": {
+		"computer_name": legacyParamScalar,
+		"cloud_init":    legacyParamScalar,
+		"rootfs_url":    legacyParamScalar,
+		"parent_id":     legacyParamScalar,
+	},
+	"CreateDistribution": {
+		"name":         legacyParamScalar,
+		"access_group": legacyParamScalar,
+	},
+	"CreatePackageProfile": {
+		"title":              legacyParamScalar,
+		"description":        legacyParamScalar,
+		"source_computer_id": legacyParamScalar,
+		"material":           legacyParamScalar,
+		"constraints":        legacyParamList,
+		"access_group":       legacyParamScalar,
+	},
+	"CreatePocket": {
+		"name":                  legacyParamScalar,
+		"series":                legacyParamScalar,
+		"distribution":          legacyParamScalar,
+		"components":            legacyParamList,
+		"architectures":         legacyParamList,
+		"mode":                  legacyParamScalar,
+		"gpg_key":               legacyParamScalar,
+		"include_udeb":          legacyParamScalar,
+		"mirror_uri":            legacyParamScalar,
+		"mirror_suite":          legacyParamScalar,
+		"mirror_gpg_key":        legacyParamScalar,
+		"pull_series":           legacyParamScalar,
+		"pull_pocket":           legacyParamScalar,
+		"filter_type":           legacyParamScalar,
+		"filter_packages":       legacyParamList,
+		"upload_allow_unsigned": legacyParamScalar,
+		"origin":                legacyParamScalar,
+	},
+	"CreateRemovalProfile": {
+		"title":                 legacyParamScalar,
+		"days_without_exchange": legacyParamScalar,
+		"access_group":          legacyParamScalar,
+		"cascade_to_children":   legacyParamScalar,
+		"tags":                  legacyParamList,
+		"all_computers":         legacyParamScalar,
+	},
+	"CreateRepositoryProfile": {
+		"title":        legacyParamScalar,
+		"description":  legacyParamScalar,
+		"access_group": legacyParamScalar,
+	},
+	"CreateRole": {
+		"name":        legacyParamScalar,
+		"description": legacyParamScalar,
+	},
+	"CreateSavedSearch": {
+		"name":   legacyParamScalar,
+		"title":  legacyParamScalar,
+		"search": legacyParamScalar,
+	},
+	"CreateScript": {
+		"title":        legacyParamScalar,
+		"time_limit":   legacyParamScalar,
+		"code":         legacyParamScalar,
+		"username":     legacyParamScalar,
+		"access_group": legacyParamScalar,
+		"script_type":  legacyParamScalar,
+	},
+	"CreateScriptAttachment": {
+		"script_id": legacyParamScalar,
+		"file":      legacyParamScalar,
+	},
+	"CreateSeries": {
+		"name":           legacyParamScalar,
+		"distribution":   legacyParamScalar,
+		"pockets":        legacyParamList,
+		"components":     legacyParamList,
+		"architectures":  legacyParamList,
+		"gpg_key":        legacyParamScalar,
+		"mirror_uri":     legacyParamScalar,
+		"mirror_series":  legacyParamScalar,
+		"mirror_gpg_key": legacyParamScalar,
+		"include_udeb":   legacyParamScalar,
+		"origin":         legacyParamScalar,
+	},
+	"CreateUpgradeProfile": {
+		"title":                legacyParamScalar,
+		"every":                legacyParamScalar,
+		"on_days":              legacyParamList,
+		"at_hour":              legacyParamScalar,
+		"at_minute":            legacyParamScalar,
+		"deliver_within":       legacyParamScalar,
+		"deliver_delay_window": legacyParamScalar,
+		"security_upgrade":     legacyParamScalar,
+		"upgrade_type":         legacyParamScalar,
+		"autoremove":           legacyParamScalar,
+		"access_group":         legacyParamScalar,
+		"tags":                 legacyParamList,
+		"all_computers":        legacyParamScalar,
+	},
+	"CreateUser": {
+		"computer_ids":           legacyParamList,
+		"username":               legacyParamScalar,
+		"name":                   legacyParamScalar,
+		"password":               legacyParamScalar,
+		"require_password_reset": legacyParamScalar,
+		"primary_groupname":      legacyParamScalar,
+		"location":               legacyParamScalar,
+		"home_phone":             legacyParamScalar,
+		"work_phone":             legacyParamScalar,
+	},
+	"DeleteChildComputers": {
+		"computer_ids": legacyParamList,
+	},
+	"DeriveSeries": {
+		"name":         legacyParamScalar,
+		"origin":       legacyParamScalar,
+		"distribution": legacyParamScalar,
+	},
+	"DiffPullPocket": {
+		"name":         legacyParamScalar,
+		"series":       legacyParamScalar,
+		"distribution": legacyParamScalar,
+	},
+	"DisableAdministrator": {
+		"email": legacyParamScalar,
+	},
+	"DisassociateAlert": {
+		"name":          legacyParamScalar,
+		"tags":          legacyParamList,
+		"all_computers": legacyParamScalar,
+	},
+	"DisassociatePackageProfile": {
+		"name":          legacyParamScalar,
+		"tags":          legacyParamList,
+		"all_computers": legacyParamScalar,
+	},
+	"DisassociateRemovalProfile": {
+		"name":          legacyParamScalar,
+		"tags":          legacyParamList,
+		"all_computers": legacyParamScalar,
+	},
+	"DisassociateRepositoryProfile": {
+		"name":          legacyParamScalar,
+		"tags":          legacyParamList,
+		"all_computers": legacyParamScalar,
+	},
+	"DisassociateUpgradeProfile": {
+		"name":          legacyParamScalar,
+		"tags":          legacyParamList,
+		"all_computers": legacyParamScalar,
+	},
+	"EditPackageProfile": {
+		"name":               legacyParamScalar,
+		"title":              legacyParamScalar,
+		"add_constraints":    legacyParamList,
+		"remove_constraints": legacyParamList,
+	},
+	"EditPocket": {
+		"name":                  legacyParamScalar,
+		"series":                legacyParamScalar,
+		"distribution":          legacyParamScalar,
+		"components":            legacyParamList,
+		"architectures":         legacyParamList,
+		"gpg_key":               legacyParamScalar,
+		"mirror_uri":            legacyParamScalar,
+		"mirror_suite":          legacyParamScalar,
+		"mirror_gpg_key":        legacyParamScalar,
+		"upload_allow_unsigned": legacyParamScalar,
+		"include_udeb":          legacyParamScalar,
+	},
+	"EditRemovalProfile": {
+		"name":                  legacyParamScalar,
+		"title":                 legacyParamScalar,
+		"days_without_exchange": legacyParamScalar,
+		"tags":                  legacyParamList,
+		"all_computers":         legacyParamScalar,
+	},
+	"EditRepositoryProfile": {
+		"name":        legacyParamScalar,
+		"title":       legacyParamScalar,
+		"description": legacyParamScalar,
+	},
+	"EditSavedSearch": {
+		"name":   legacyParamScalar,
+		"title":  legacyParamScalar,
+		"search": legacyParamScalar,
+	},
+	"EditScript": {
+		"script_id":  legacyParamScalar,
+		"title":      legacyParamScalar,
+		"time_limit": legacyParamScalar,
+		"code":       legacyParamScalar,
+		"username":   legacyParamScalar,
+	},
+	"EditUpgradeProfile": {
+		"name":                 legacyParamScalar,
+		"title":                legacyParamScalar,
+		"every":                legacyParamScalar,
+		"on_days":              legacyParamList,
+		"at_hour":              legacyParamScalar,
+		"at_minute":            legacyParamScalar,
+		"deliver_within":       legacyParamScalar,
+		"deliver_delay_window": legacyParamScalar,
+		"security_upgrade":     legacyParamScalar,
+		"upgrade_type":         legacyParamScalar,
+		"autoremove":           legacyParamScalar,
+		"tags":                 legacyParamList,
+		"all_computers":        legacyParamScalar,
+	},
+	"EditUser": {
+		"computer_ids":      legacyParamList,
+		"username":          legacyParamScalar,
+		"name":              legacyParamScalar,
+		"password":          legacyParamScalar,
+		"primary_groupname": legacyParamScalar,
+		"location":          legacyParamScalar,
+		"home_phone":        legacyParamScalar,
+		"work_phone":        legacyParamScalar,
+	},
+	"ExecuteScript": {
+		"query":           legacyParamScalar,
+		"script_id":       legacyParamScalar,
+		"username":        legacyParamScalar,
+		"deliver_after":   legacyParamScalar,
+		"time_limit":      legacyParamScalar,
+		"in_access_group": legacyParamScalar,
+	},
+	"GetAPTSources": {
+		"names": legacyParamList,
+	},
+	"GetAccessGroups": {
+		"names": legacyParamList,
+	},
+	"GetActivities": {
+		"query":  legacyParamScalar,
+		"limit":  legacyParamScalar,
+		"offset": legacyParamScalar,
+	},
+	"GetAlertSubscribers": {
+		"alert_type": legacyParamScalar,
+	},
+	"GetCSVComplianceData": {
+		"query":    legacyParamScalar,
+		"limit":    legacyParamScalar,
+		"offset":   legacyParamScalar,
+		"max_days": legacyParamScalar,
+		"by_cve":   legacyParamScalar,
+	},
+	"GetComputerProcesses": {
+		"computer_id": legacyParamScalar,
+		"offset":      legacyParamScalar,
+		"limit":       legacyParamScalar,
+	},
+	"GetComputers": {
+		"query":                 legacyParamScalar,
+		"limit":                 legacyParamScalar,
+		"offset":                legacyParamScalar,
+		"with_network":          legacyParamScalar,
+		"with_all_network":      legacyParamScalar,
+		"with_hardware":         legacyParamScalar,
+		"with_annotations":      legacyParamScalar,
+		"with_grouped_hardware": legacyParamScalar,
+	},
+	"GetComputersNotUpgraded": {
+		"query":  legacyParamScalar,
+		"limit":  legacyParamScalar,
+		"offset": legacyParamScalar,
+	},
+	"GetDistributions": {
+		"names":               legacyParamList,
+		"include_latest_sync": legacyParamScalar,
+	},
+	"GetEventLog": {
+		"days":   legacyParamScalar,
+		"limit":  legacyParamScalar,
+		"offset": legacyParamScalar,
+	},
+	"GetGPGKeys": {
+		"names": legacyParamList,
+	},
+	"GetNotPingingComputers": {
+		"query":         legacyParamScalar,
+		"limit":         legacyParamScalar,
+		"offset":        legacyParamScalar,
+		"since_minutes": legacyParamScalar,
+	},
+	"GetPackageProfiles": {
+		"names": legacyParamList,
+	},
+	"GetPackages": {
+		"query":     legacyParamScalar,
+		"search":    legacyParamScalar,
+		"names":     legacyParamList,
+		"installed": legacyParamScalar,
+		"available": legacyParamScalar,
+		"upgrade":   legacyParamScalar,
+		"held":      legacyParamScalar,
+		"offset":    legacyParamScalar,
+		"limit":     legacyParamScalar,
+	},
+	"GetRepoInfo": {
+		"mirror_uri": legacyParamScalar,
+	},
+	"GetRepositoryProfiles": {
+		"names": legacyParamList,
+	},
+	"GetRoles": {
+		"names": legacyParamList,
+	},
+	"GetSavedSearches": {
+		"offset": legacyParamScalar,
+		"limit":  legacyParamScalar,
+	},
+	"GetScriptCode": {
+		"script_id": legacyParamScalar,
+	},
+	"GetScripts": {
+		"limit":       legacyParamScalar,
+		"offset":      legacyParamScalar,
+		"script_type": legacyParamScalar,
+	},
+	"GetUSNTimeToFix": {
+		"query":           legacyParamScalar,
+		"limit":           legacyParamScalar,
+		"offset":          legacyParamScalar,
+		"fixed_in_days":   legacyParamList,
+		"pending_in_days": legacyParamScalar,
+		"in_last":         legacyParamScalar,
+	},
+	"GetUpgradeProfiles": {
+		"upgrade_type": legacyParamScalar,
+	},
+	"GetUpgradedComputersByFrequency": {
+		"query":  legacyParamScalar,
+		"limit":  legacyParamScalar,
+		"offset": legacyParamScalar,
+	},
+	"GetUsers": {
+		"computer_id": legacyParamScalar,
+	},
+	"GetWSLHosts": {
+		"query":  legacyParamScalar,
+		"limit":  legacyParamScalar,
+		"offset": legacyParamScalar,
+	},
+	"ImportGPGKey": {
+		"name":     legacyParamScalar,
+		"material": legacyParamScalar,
+	},
+	"InstallPackages": {
+		"query":                legacyParamScalar,
+		"packages":             legacyParamList,
+		"deliver_after":        legacyParamScalar,
+		"deliver_delay_window": legacyParamScalar,
+	},
+	"InviteAdministrator": {
+		"name":  legacyParamScalar,
+		"email": legacyParamScalar,
+		"roles": legacyParamList,
+	},
+	"KillComputerProcesses": {
+		"computer_id": legacyParamScalar,
+		"pids":        legacyParamList,
+	},
+	"ListPocket": {
+		"name":         legacyParamScalar,
+		"series":       legacyParamScalar,
+		"distribution": legacyParamScalar,
+		"search":       legacyParamScalar,
+		"offset":       legacyParamScalar,
+		"limit":        legacyParamScalar,
+	},
+	"ModifyPackageProfile": {
+		"name":               legacyParamScalar,
+		"title":              legacyParamScalar,
+		"add_constraints":    legacyParamList,
+		"remove_constraints": legacyParamList,
+	},
+	"PullPackagesToPocket": {
+		"name":         legacyParamScalar,
+		"series":       legacyParamScalar,
+		"distribution": legacyParamScalar,
+	},
+	"RebootComputers": {
+		"computer_ids":  legacyParamList,
+		"deliver_after": legacyParamScalar,
+	},
+	"RejectPendingComputers": {
+		"computer_ids": legacyParamList,
+	},
+	"RemoveAPTSource": {
+		"name": legacyParamScalar,
+	},
+	"RemoveAPTSourceFromRepositoryProfile": {
+		"name":       legacyParamScalar,
+		"apt_source": legacyParamScalar,
+	},
+	"RemoveAPTSources": {
+		"names": legacyParamList,
+	},
+	"RemoveAPTSourcesFromRepositoryProfile": {
+		"name":        legacyParamScalar,
+		"apt_sources": legacyParamList,
+	},
+	"RemoveAccessGroup": {
+		"name": legacyParamScalar,
+	},
+	"RemoveAccessGroupsFromRole": {
+		"name":          legacyParamScalar,
+		"access_groups": legacyParamList,
+	},
+	"RemoveAnnotationFromComputers": {
+		"query": legacyParamScalar,
+		"key":   legacyParamScalar,
+	},
+	"RemoveComputers": {
+		"computer_ids": legacyParamList,
+	},
+	"RemoveDistribution": {
+		"name": legacyParamScalar,
+	},
+	"RemoveGPGKey": {
+		"name": legacyParamScalar,
+	},
+	"RemovePackageFiltersFromPocket": {
+		"name":         legacyParamScalar,
+		"series":       legacyParamScalar,
+		"distribution": legacyParamScalar,
+		"packages":     legacyParamList,
+	},
+	"RemovePackageProfile": {
+		"name": legacyParamScalar,
+	},
+	"RemovePackages": {
+		"query":                legacyParamScalar,
+		"packages":             legacyParamList,
+		"deliver_after":        legacyParamScalar,
+		"deliver_delay_window": legacyParamScalar,
+	},
+	"RemovePackagesFromPocket": {
+		"name":         legacyParamScalar,
+		"series":       legacyParamScalar,
+		"distribution": legacyParamScalar,
+		"packages":     legacyParamList,
+	},
+	"RemovePermissionsFromRole": {
+		"name":        legacyParamScalar,
+		"permissions": legacyParamList,
+	},
+	"RemovePersonsFromRole": {
+		"name":    legacyParamScalar,
+		"persons": legacyParamList,
+	},
+	"RemovePocket": {
+		"name":         legacyParamScalar,
+		"series":       legacyParamScalar,
+		"distribution": legacyParamScalar,
+	},
+	"RemovePocketsFromRepositoryProfile": {
+		"name":         legacyParamScalar,
+		"pockets":      legacyParamList,
+		"series":       legacyParamScalar,
+		"distribution": legacyParamScalar,
+	},
+	"RemoveRemovalProfile": {
+		"name": legacyParamScalar,
+	},
+	"RemoveRepositoryProfile": {
+		"name": legacyParamScalar,
+	},
+	"RemoveRepositoryProfiles": {
+		"names": legacyParamList,
+	},
+	"RemoveRole": {
+		"name": legacyParamScalar,
+	},
+	"RemoveSavedSearch": {
+		"name": legacyParamScalar,
+	},
+	"RemoveScript": {
+		"script_id": legacyParamScalar,
+	},
+	"RemoveScriptAttachment": {
+		"script_id": legacyParamScalar,
+		"filename":  legacyParamScalar,
+	},
+	"RemoveSeries": {
+		"name":         legacyParamScalar,
+		"distribution": legacyParamScalar,
+	},
+	"RemoveTagsFromComputers": {
+		"query": legacyParamScalar,
+		"tags":  legacyParamList,
+	},
+	"RemoveUpgradeProfile": {
+		"name": legacyParamScalar,
+	},
+	"RemoveUploaderGPGKeysFromPocket": {
+		"name":         legacyParamScalar,
+		"series":       legacyParamScalar,
+		"distribution": legacyParamScalar,
+		"gpg_keys":     legacyParamList,
+	},
+	"RemoveWSLHosts": {
+		"computer_ids":        legacyParamList,
+		"cascade_to_children": legacyParamScalar,
+	},
+	"RenameComputers": {
+		"computer_titles": legacyParamMap,
+	},
+	"SetDefaultChildComputer": {
+		"parent_id": legacyParamScalar,
+		"child_id":  legacyParamScalar,
+	},
+	"SetSettings": {
+		"key_values": legacyParamList,
+	},
+	"ShutdownComputers": {
+		"computer_ids":  legacyParamList,
+		"deliver_after": legacyParamScalar,
+	},
+	"ShutdownHostComputer": {
+		"parent_id": legacyParamScalar,
+	},
+	"StartChildComputers": {
+		"computer_ids": legacyParamList,
+	},
+	"StopChildComputers": {
+		"computer_ids": legacyParamList,
+	},
+	"SubscribeToAlert": {
+		"alert_type": legacyParamScalar,
+	},
+	"SyncMirrorPocket": {
+		"name":         legacyParamScalar,
+		"series":       legacyParamScalar,
+		"distribution": legacyParamScalar,
+	},
+	"TerminateComputerProcesses": {
+		"computer_id": legacyParamScalar,
+		"pids":        legacyParamList,
+	},
+	"UnsubscribeFromAlert": {
+		"alert_type": legacyParamScalar,
+	},
+	"UpgradePackages": {
+		"query":                legacyParamScalar,
+		"packages":             legacyParamList,
+		"security_only":        legacyParamScalar,
+		"deliver_after":        legacyParamScalar,
+		"deliver_delay_window": legacyParamScalar,
+	},
+}
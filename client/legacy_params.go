@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// legacyParamKind describes how a legacy action query parameter is encoded.
+type legacyParamKind int
+
+const (
+	legacyParamScalar legacyParamKind = iota
+	legacyParamList
+	legacyParamMap
+)
+
+// WithLegacyParamEncoding encodes list and map parameters of legacy action
+// requests the way Landscape expects them. The generated client sends lists
+// as repeated keys (computer_ids=1&computer_ids=2) and flattens maps into
+// top-level keys, but Landscape expects computer_ids.1=1&computer_ids.2=2 and
+// existing_ids.<key>=<value>.
+//
+// NewLandscapeAPIClient enables this automatically. It should come before any
+// other WithRequestEditorFn option so that query arguments added by other
+// editors are left untouched.
+func WithLegacyParamEncoding() ClientOption {
+	return WithRequestEditorFn(LegacyParamsRequestEditor)
+}
+
+// LegacyParamsRequestEditor is the RequestEditorFn used by
+// WithLegacyParamEncoding. Requests that are not legacy actions are not
+// modified.
+func LegacyParamsRequestEditor(_ context.Context, req *http.Request) error {
+	if !strings.HasSuffix(req.URL.Path, "/api/") {
+		return nil
+	}
+
+	query := req.URL.Query()
+	params, ok := legacyActionParams[query.Get("action")]
+	if !ok {
+		return nil
+	}
+
+	mapParam := ""
+	for name, kind := range params {
+		switch kind {
+		case legacyParamList:
+			values, ok := query[name]
+			if !ok {
+				continue
+			}
+			query.Del(name)
+
+			// An empty list is sent by the generated client as a single
+			// empty value.
+			if len(values) == 1 && values[0] == "" {
+				continue
+			}
+			for i, v := range values {
+				query.Set(fmt.Sprintf("%s.%d", name, i+1), v)
+			}
+		case legacyParamMap:
+			mapParam = name
+		}
+	}
+
+	// Map entries end up as top-level keys that don't belong to the action.
+	// No action has more than one map parameter, so they can be moved back
+	// under it.
+	if mapParam != "" {
+		var stray []string
+		for key := range query {
+			if _, known := params[key]; known || key == "action" || key == "version" || strings.Contains(key, ".") {
+				continue
+			}
+			stray = append(stray, key)
+		}
+		for _, key := range stray {
+			query[mapParam+"."+key] = query[key]
+			query.Del(key)
+		}
+	}
+
+	req.URL.RawQuery = query.Encode()
+	return nil
+}
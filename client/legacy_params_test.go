@@ -0,0 +1,185 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestLegacyParamEncoding(t *testing.T) {
+	queries := make(chan url.Values, 1)
+
+	handler := http.NewServeMux()
+	handler.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		queries <- r.URL.Query()
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	server := httptest.NewTLSServer(handler)
+	defer server.Close()
+
+	api, err := NewClient(server.URL, WithHTTPClient(server.Client()), WithLegacyParamEncoding())
+	if err != nil {
+		t.Fatalf("failed to init client: %v", err)
+	}
+
+	ctx := context.Background()
+	existing := map[string]int{"5": 12, "6": 13}
+	names := []string{"ubuntu", "internal"}
+	empty := []string{}
+
+	tests := []struct {
+		name string
+		call func() (*http.Response, error)
+		want url.Values
+	}{
+		{
+			name: "required int list",
+			call: func() (*http.Response, error) {
+				return api.LegacyRebootComputers(ctx, &LegacyRebootComputersParams{ComputerIds: []int{1, 2, 3}})
+			},
+			want: url.Values{
+				"action":         {"RebootComputers"},
+				"version":        {"2011-08-01"},
+				"computer_ids.1": {"1"},
+				"computer_ids.2": {"2"},
+				"computer_ids.3": {"3"},
+			},
+		},
+		{
+			name: "single element list",
+			call: func() (*http.Response, error) {
+				return api.LegacyInstallPackages(ctx, &LegacyInstallPackagesParams{Query: "tag:web", Packages: []string{"openssl"}})
+			},
+			want: url.Values{
+				"action":     {"InstallPackages"},
+				"version":    {"2011-08-01"},
+				"query":      {"tag:web"},
+				"packages.1": {"openssl"},
+			},
+		},
+		{
+			name: "optional string list",
+			call: func() (*http.Response, error) {
+				return api.LegacyGetDistributions(ctx, &LegacyGetDistributionsParams{Names: &names})
+			},
+			want: url.Values{
+				"action":  {"GetDistributions"},
+				"version": {"2011-08-01"},
+				"names.1": {"ubuntu"},
+				"names.2": {"internal"},
+			},
+		},
+		{
+			name: "empty list",
+			call: func() (*http.Response, error) {
+				return api.LegacyGetDistributions(ctx, &LegacyGetDistributionsParams{Names: &empty})
+			},
+			want: url.Values{
+				"action":  {"GetDistributions"},
+				"version": {"2011-08-01"},
+			},
+		},
+		{
+			name: "list and optional map",
+			call: func() (*http.Response, error) {
+				return api.LegacyAcceptPendingComputers(ctx, &LegacyAcceptPendingComputersParams{
+					ComputerIds: []int{5, 6},
+					ExistingIds: &existing,
+				})
+			},
+			want: url.Values{
+				"action":         {"AcceptPendingComputers"},
+				"version":        {"2011-08-01"},
+				"computer_ids.1": {"5"},
+				"computer_ids.2": {"6"},
+				"existing_ids.5": {"12"},
+				"existing_ids.6": {"13"},
+			},
+		},
+		{
+			name: "required map",
+			call: func() (*http.Response, error) {
+				return api.LegacyRenameComputers(ctx, &LegacyRenameComputersParams{
+					ComputerTitles: map[string]string{"12": "web 01"},
+				})
+			},
+			want: url.Values{
+				"action":             {"RenameComputers"},
+				"version":            {"2011-08-01"},
+				"computer_titles.12": {"web 01"},
+			},
+		},
+		{
+			name: "scalars only",
+			call: func() (*http.Response, error) {
+				return api.LegacyRemoveScript(ctx, &LegacyRemoveScriptParams{ScriptId: 42})
+			},
+			want: url.Values{
+				"action":    {"RemoveScript"},
+				"version":   {"2011-08-01"},
+				"script_id": {"42"},
+			},
+		},
+		{
+			name: "non-legacy request",
+			call: func() (*http.Response, error) {
+				archived := ListScriptProfilesParamsArchived("all")
+				return api.ListScriptProfiles(ctx, &ListScriptProfilesParams{Archived: &archived})
+			},
+			want: url.Values{
+				"archived": {"all"},
+			},
+		},
+		{
+			name: "later editors are untouched",
+			call: func() (*http.Response, error) {
+				return api.LegacyRebootComputers(ctx, &LegacyRebootComputersParams{ComputerIds: []int{1}},
+					EncodeQueryRequestEditor(url.Values{"deliver_after": {"2025-01-01T00:00:00Z"}}))
+			},
+			want: url.Values{
+				"action":         {"RebootComputers"},
+				"version":        {"2011-08-01"},
+				"computer_ids.1": {"1"},
+				"deliver_after":  {"2025-01-01T00:00:00Z"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := tt.call()
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			resp.Body.Close()
+
+			if got := <-queries; !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("unexpected query:\n got: %v\nwant: %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLegacyActionParamsTable(t *testing.T) {
+	if legacyActionParams["RebootComputers"]["computer_ids"] != legacyParamList {
+		t.Fatal("expected computer_ids to be a list parameter of RebootComputers")
+	}
+	if legacyActionParams["AcceptPendingComputers"]["existing_ids"] != legacyParamMap {
+		t.Fatal("expected existing_ids to be a map parameter of AcceptPendingComputers")
+	}
+	for action, params := range legacyActionParams {
+		maps := 0
+		for _, kind := range params {
+			if kind == legacyParamMap {
+				maps++
+			}
+		}
+		if maps > 1 {
+			t.Fatalf("%s has %d map parameters; LegacyParamsRequestEditor assumes at most one", action, maps)
+		}
+	}
+}
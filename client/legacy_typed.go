@@ -0,0 +1,223 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// parseLegacyResult decodes the body of a successful legacy action response
+// into T, or returns an *APIError if the action did not succeed.
+func parseLegacyResult[T any](action string, res *http.Response, body []byte) (T, error) {
+	if res == nil || res.StatusCode < 200 || res.StatusCode >= 300 {
+		var zero T
+		return zero, NewAPIError(res, body)
+	}
+
+	result, err := ParseLegacyResponse[T](body)
+	if err != nil {
+		return result, fmt.Errorf("failed to decode %s response: %w", action, err)
+	}
+
+	return result, nil
+}
+
+// GetComputersTyped calls the GetComputers legacy action and returns the computers matching params.
+func (c *ClientWithResponses) GetComputersTyped(ctx context.Context, params *LegacyGetComputersParams, reqEditors ...RequestEditorFn) ([]Computer, error) {
+	resp, err := c.LegacyGetComputersWithResponse(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return parseLegacyResult[[]Computer]("GetComputers", resp.HTTPResponse, resp.Body)
+}
+
+// GetActivitiesTyped calls the GetActivities legacy action and returns the activities matching params.
+func (c *ClientWithResponses) GetActivitiesTyped(ctx context.Context, params *LegacyGetActivitiesParams, reqEditors ...RequestEditorFn) ([]Activity, error) {
+	resp, err := c.LegacyGetActivitiesWithResponse(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return parseLegacyResult[[]Activity]("GetActivities", resp.HTTPResponse, resp.Body)
+}
+
+// GetPackagesTyped calls the GetPackages legacy action and returns the packages matching params.
+func (c *ClientWithResponses) GetPackagesTyped(ctx context.Context, params *LegacyGetPackagesParams, reqEditors ...RequestEditorFn) ([]Package, error) {
+	resp, err := c.LegacyGetPackagesWithResponse(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return parseLegacyResult[[]Package]("GetPackages", resp.HTTPResponse, resp.Body)
+}
+
+// GetDistributionsTyped calls the GetDistributions legacy action and returns the distributions in the account.
+func (c *ClientWithResponses) GetDistributionsTyped(ctx context.Context, params *LegacyGetDistributionsParams, reqEditors ...RequestEditorFn) ([]Distribution, error) {
+	resp, err := c.LegacyGetDistributionsWithResponse(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return parseLegacyResult[[]Distribution]("GetDistributions", resp.HTTPResponse, resp.Body)
+}
+
+// CreateDistributionTyped calls the CreateDistribution legacy action and returns the created distribution.
+func (c *ClientWithResponses) CreateDistributionTyped(ctx context.Context, params *LegacyCreateDistributionParams, reqEditors ...RequestEditorFn) (Distribution, error) {
+	resp, err := c.LegacyCreateDistributionWithResponse(ctx, params, reqEditors...)
+	if err != nil {
+		return Distribution{}, err
+	}
+	return parseLegacyResult[Distribution]("CreateDistribution", resp.HTTPResponse, resp.Body)
+}
+
+// CreateSeriesTyped calls the CreateSeries legacy action and returns the created series.
+func (c *ClientWithResponses) CreateSeriesTyped(ctx context.Context, params *LegacyCreateSeriesParams, reqEditors ...RequestEditorFn) (Series, error) {
+	resp, err := c.LegacyCreateSeriesWithResponse(ctx, params, reqEditors...)
+	if err != nil {
+		return Series{}, err
+	}
+	return parseLegacyResult[Series]("CreateSeries", resp.HTTPResponse, resp.Body)
+}
+
+// CreatePocketTyped calls the CreatePocket legacy action and returns the created pocket.
+func (c *ClientWithResponses) CreatePocketTyped(ctx context.Context, params *LegacyCreatePocketParams, reqEditors ...RequestEditorFn) (Pocket, error) {
+	resp, err := c.LegacyCreatePocketWithResponse(ctx, params, reqEditors...)
+	if err != nil {
+		return Pocket{}, err
+	}
+	return parseLegacyResult[Pocket]("CreatePocket", resp.HTTPResponse, resp.Body)
+}
+
+// GetGPGKeysTyped calls the GetGPGKeys legacy action and returns the GPG keys in the account.
+func (c *ClientWithResponses) GetGPGKeysTyped(ctx context.Context, params *LegacyGetGPGKeysParams, reqEditors ...RequestEditorFn) ([]GPGKey, error) {
+	resp, err := c.LegacyGetGPGKeysWithResponse(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return parseLegacyResult[[]GPGKey]("GetGPGKeys", resp.HTTPResponse, resp.Body)
+}
+
+// ImportGPGKeyTyped calls the ImportGPGKey legacy action and returns the imported key.
+func (c *ClientWithResponses) ImportGPGKeyTyped(ctx context.Context, params *LegacyImportGPGKeyParams, reqEditors ...RequestEditorFn) (GPGKey, error) {
+	resp, err := c.LegacyImportGPGKeyWithResponse(ctx, params, reqEditors...)
+	if err != nil {
+		return GPGKey{}, err
+	}
+	return parseLegacyResult[GPGKey]("ImportGPGKey", resp.HTTPResponse, resp.Body)
+}
+
+// GetAPTSourcesTyped calls the GetAPTSources legacy action and returns the APT sources in the account.
+func (c *ClientWithResponses) GetAPTSourcesTyped(ctx context.Context, params *LegacyGetAPTSourcesParams, reqEditors ...RequestEditorFn) ([]APTSource, error) {
+	resp, err := c.LegacyGetAPTSourcesWithResponse(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return parseLegacyResult[[]APTSource]("GetAPTSources", resp.HTTPResponse, resp.Body)
+}
+
+// GetAccessGroupsTyped calls the GetAccessGroups legacy action and returns the access groups in the account.
+func (c *ClientWithResponses) GetAccessGroupsTyped(ctx context.Context, params *LegacyGetAccessGroupsParams, reqEditors ...RequestEditorFn) ([]AccessGroup, error) {
+	resp, err := c.LegacyGetAccessGroupsWithResponse(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return parseLegacyResult[[]AccessGroup]("GetAccessGroups", resp.HTTPResponse, resp.Body)
+}
+
+// GetRolesTyped calls the GetRoles legacy action and returns the roles in the account.
+func (c *ClientWithResponses) GetRolesTyped(ctx context.Context, params *LegacyGetRolesParams, reqEditors ...RequestEditorFn) ([]Role, error) {
+	resp, err := c.LegacyGetRolesWithResponse(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return parseLegacyResult[[]Role]("GetRoles", resp.HTTPResponse, resp.Body)
+}
+
+// GetUpgradeProfilesTyped calls the GetUpgradeProfiles legacy action and returns the upgrade profiles in the account.
+func (c *ClientWithResponses) GetUpgradeProfilesTyped(ctx context.Context, params *LegacyGetUpgradeProfilesParams, reqEditors ...RequestEditorFn) ([]UpgradeProfile, error) {
+	resp, err := c.LegacyGetUpgradeProfilesWithResponse(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return parseLegacyResult[[]UpgradeProfile]("GetUpgradeProfiles", resp.HTTPResponse, resp.Body)
+}
+
+// GetRepositoryProfilesTyped calls the GetRepositoryProfiles legacy action and returns the repository profiles in the account.
+func (c *ClientWithResponses) GetRepositoryProfilesTyped(ctx context.Context, params *LegacyGetRepositoryProfilesParams, reqEditors ...RequestEditorFn) ([]RepositoryProfile, error) {
+	resp, err := c.LegacyGetRepositoryProfilesWithResponse(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return parseLegacyResult[[]RepositoryProfile]("GetRepositoryProfiles", resp.HTTPResponse, resp.Body)
+}
+
+// GetSavedSearchesTyped calls the GetSavedSearches legacy action and returns the saved searches in the account.
+func (c *ClientWithResponses) GetSavedSearchesTyped(ctx context.Context, params *LegacyGetSavedSearchesParams, reqEditors ...RequestEditorFn) ([]SavedSearch, error) {
+	resp, err := c.LegacyGetSavedSearchesWithResponse(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return parseLegacyResult[[]SavedSearch]("GetSavedSearches", resp.HTTPResponse, resp.Body)
+}
+
+// GetAlertsTyped calls the GetAlerts legacy action and returns the alerts configured for the account.
+func (c *ClientWithResponses) GetAlertsTyped(ctx context.Context, reqEditors ...RequestEditorFn) ([]Alert, error) {
+	resp, err := c.LegacyGetAlertsWithResponse(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return parseLegacyResult[[]Alert]("GetAlerts", resp.HTTPResponse, resp.Body)
+}
+
+// GetAdministratorsTyped calls the GetAdministrators legacy action and returns the administrators of the account.
+func (c *ClientWithResponses) GetAdministratorsTyped(ctx context.Context, reqEditors ...RequestEditorFn) ([]Administrator, error) {
+	resp, err := c.LegacyGetAdministratorsWithResponse(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return parseLegacyResult[[]Administrator]("GetAdministrators", resp.HTTPResponse, resp.Body)
+}
+
+// ExecuteScriptTyped calls the ExecuteScript legacy action and returns the activity created to run the script.
+func (c *ClientWithResponses) ExecuteScriptTyped(ctx context.Context, params *LegacyExecuteScriptParams, reqEditors ...RequestEditorFn) (Activity, error) {
+	resp, err := c.LegacyExecuteScriptWithResponse(ctx, params, reqEditors...)
+	if err != nil {
+		return Activity{}, err
+	}
+	return parseLegacyResult[Activity]("ExecuteScript", resp.HTTPResponse, resp.Body)
+}
+
+// RebootComputersTyped calls the RebootComputers legacy action and returns the activity created to reboot the computers.
+func (c *ClientWithResponses) RebootComputersTyped(ctx context.Context, params *LegacyRebootComputersParams, reqEditors ...RequestEditorFn) (Activity, error) {
+	resp, err := c.LegacyRebootComputersWithResponse(ctx, params, reqEditors...)
+	if err != nil {
+		return Activity{}, err
+	}
+	return parseLegacyResult[Activity]("RebootComputers", resp.HTTPResponse, resp.Body)
+}
+
+// InstallPackagesTyped calls the InstallPackages legacy action and returns the activity created to install the packages.
+func (c *ClientWithResponses) InstallPackagesTyped(ctx context.Context, params *LegacyInstallPackagesParams, reqEditors ...RequestEditorFn) (Activity, error) {
+	resp, err := c.LegacyInstallPackagesWithResponse(ctx, params, reqEditors...)
+	if err != nil {
+		return Activity{}, err
+	}
+	return parseLegacyResult[Activity]("InstallPackages", resp.HTTPResponse, resp.Body)
+}
+
+// UpgradePackagesTyped calls the UpgradePackages legacy action and returns the activity created to upgrade the packages.
+func (c *ClientWithResponses) UpgradePackagesTyped(ctx context.Context, params *LegacyUpgradePackagesParams, reqEditors ...RequestEditorFn) (Activity, error) {
+	resp, err := c.LegacyUpgradePackagesWithResponse(ctx, params, reqEditors...)
+	if err != nil {
+		return Activity{}, err
+	}
+	return parseLegacyResult[Activity]("UpgradePackages", resp.HTTPResponse, resp.Body)
+}
+
+// SyncMirrorPocketTyped calls the SyncMirrorPocket legacy action and returns the activity created to sync the pocket.
+func (c *ClientWithResponses) SyncMirrorPocketTyped(ctx context.Context, params *LegacySyncMirrorPocketParams, reqEditors ...RequestEditorFn) (Activity, error) {
+	resp, err := c.LegacySyncMirrorPocketWithResponse(ctx, params, reqEditors...)
+	if err != nil {
+		return Activity{}, err
+	}
+	return parseLegacyResult[Activity]("SyncMirrorPocket", resp.HTTPResponse, resp.Body)
+}
@@ -0,0 +1,275 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newLegacyFixtureServer serves testdata/legacy/<action>.json for each legacy
+// action request.
+func newLegacyFixtureServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	handler := http.NewServeMux()
+	handler.HandleFunc("/api/", func(w http.ResponseWriter, r *http.Request) {
+		action := r.URL.Query().Get("action")
+		body, err := os.ReadFile(filepath.Join("testdata", "legacy", action+".json"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	})
+
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestLegacyTypedResponses(t *testing.T) {
+	server := newLegacyFixtureServer(t)
+
+	authEditor := func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer test-token")
+		return nil
+	}
+
+	api, err := NewClientWithResponses(server.URL, WithHTTPClient(server.Client()), WithRequestEditorFn(authEditor))
+	if err != nil {
+		t.Fatalf("failed to init client with responses: %v", err)
+	}
+
+	ctx := context.Background()
+
+	tests := []struct {
+		action string
+		check  func(t *testing.T)
+	}{
+		{"GetComputers", func(t *testing.T) {
+			computers, err := api.GetComputersTyped(ctx, &LegacyGetComputersParams{})
+			if err != nil {
+				t.Fatalf("GetComputersTyped failed: %v", err)
+			}
+			if len(computers) != 2 || computers[0].Hostname != "web-01.example.com" || computers[0].NetworkDevices[0].IpAddress != "10.0.0.12" {
+				t.Fatalf("unexpected computers: %+v", computers)
+			}
+			if computers[1].TotalMemory != nil || computers[1].AccessGroup != "databases" {
+				t.Fatalf("unexpected second computer: %+v", computers[1])
+			}
+		}},
+		{"GetActivities", func(t *testing.T) {
+			activities, err := api.GetActivitiesTyped(ctx, &LegacyGetActivitiesParams{})
+			if err != nil {
+				t.Fatalf("GetActivitiesTyped failed: %v", err)
+			}
+			if len(activities) != 1 || len(activities[0].Children) != 1 {
+				t.Fatalf("unexpected activities: %+v", activities)
+			}
+			child := activities[0].Children[0]
+			if *child.ComputerId != 12 || *child.ResultCode != 0 || *child.ResultText != "deployed\n" {
+				t.Fatalf("unexpected child activity: %+v", child)
+			}
+		}},
+		{"GetPackages", func(t *testing.T) {
+			packages, err := api.GetPackagesTyped(ctx, &LegacyGetPackagesParams{Query: "tag:web"})
+			if err != nil {
+				t.Fatalf("GetPackagesTyped failed: %v", err)
+			}
+			if len(packages) != 1 || packages[0].Name != "openssl" || packages[0].Computers.Upgrades[0] != 12 {
+				t.Fatalf("unexpected packages: %+v", packages)
+			}
+		}},
+		{"GetDistributions", func(t *testing.T) {
+			distributions, err := api.GetDistributionsTyped(ctx, &LegacyGetDistributionsParams{})
+			if err != nil {
+				t.Fatalf("GetDistributionsTyped failed: %v", err)
+			}
+			pocket := distributions[0].Series[0].Pockets[0]
+			if pocket.Mode != "mirror" || pocket.GpgKey.Name != "mirror-key" || *pocket.LastSyncStatus != "succeeded" {
+				t.Fatalf("unexpected pocket: %+v", pocket)
+			}
+		}},
+		{"CreateDistribution", func(t *testing.T) {
+			distribution, err := api.CreateDistributionTyped(ctx, &LegacyCreateDistributionParams{Name: "ubuntu"})
+			if err != nil {
+				t.Fatalf("CreateDistributionTyped failed: %v", err)
+			}
+			if distribution.Name != "ubuntu" || len(distribution.Series) != 0 {
+				t.Fatalf("unexpected distribution: %+v", distribution)
+			}
+		}},
+		{"CreateSeries", func(t *testing.T) {
+			series, err := api.CreateSeriesTyped(ctx, &LegacyCreateSeriesParams{Name: "noble", Distribution: "ubuntu"})
+			if err != nil {
+				t.Fatalf("CreateSeriesTyped failed: %v", err)
+			}
+			if series.Name != "noble" || len(series.Pockets) != 2 || series.Pockets[1].Architectures[1] != "arm64" {
+				t.Fatalf("unexpected series: %+v", series)
+			}
+		}},
+		{"CreatePocket", func(t *testing.T) {
+			pocket, err := api.CreatePocketTyped(ctx, &LegacyCreatePocketParams{Name: "internal"})
+			if err != nil {
+				t.Fatalf("CreatePocketTyped failed: %v", err)
+			}
+			if pocket.Mode != "upload" || pocket.UploadAllowUnsigned == nil || *pocket.UploadAllowUnsigned {
+				t.Fatalf("unexpected pocket: %+v", pocket)
+			}
+		}},
+		{"GetGPGKeys", func(t *testing.T) {
+			keys, err := api.GetGPGKeysTyped(ctx, &LegacyGetGPGKeysParams{})
+			if err != nil {
+				t.Fatalf("GetGPGKeysTyped failed: %v", err)
+			}
+			if len(keys) != 2 || keys[1].KeyId != "871920D1991BC93C" || keys[1].HasSecret {
+				t.Fatalf("unexpected keys: %+v", keys)
+			}
+		}},
+		{"ImportGPGKey", func(t *testing.T) {
+			key, err := api.ImportGPGKeyTyped(ctx, &LegacyImportGPGKeyParams{Name: "sign-key"})
+			if err != nil {
+				t.Fatalf("ImportGPGKeyTyped failed: %v", err)
+			}
+			if key.Id != 4 || !key.HasSecret {
+				t.Fatalf("unexpected key: %+v", key)
+			}
+		}},
+		{"GetAPTSources", func(t *testing.T) {
+			sources, err := api.GetAPTSourcesTyped(ctx, &LegacyGetAPTSourcesParams{})
+			if err != nil {
+				t.Fatalf("GetAPTSourcesTyped failed: %v", err)
+			}
+			if len(sources) != 1 || *sources[0].GpgKey != "ppa-key" {
+				t.Fatalf("unexpected sources: %+v", sources)
+			}
+		}},
+		{"GetAccessGroups", func(t *testing.T) {
+			groups, err := api.GetAccessGroupsTyped(ctx, &LegacyGetAccessGroupsParams{})
+			if err != nil {
+				t.Fatalf("GetAccessGroupsTyped failed: %v", err)
+			}
+			if len(groups) != 2 || groups[1].Parent != "global" {
+				t.Fatalf("unexpected access groups: %+v", groups)
+			}
+		}},
+		{"GetRoles", func(t *testing.T) {
+			roles, err := api.GetRolesTyped(ctx, &LegacyGetRolesParams{})
+			if err != nil {
+				t.Fatalf("GetRolesTyped failed: %v", err)
+			}
+			if len(roles) != 1 || roles[0].GlobalPermissions[0] != "ManageAccount" {
+				t.Fatalf("unexpected roles: %+v", roles)
+			}
+		}},
+		{"GetUpgradeProfiles", func(t *testing.T) {
+			profiles, err := api.GetUpgradeProfilesTyped(ctx, &LegacyGetUpgradeProfilesParams{})
+			if err != nil {
+				t.Fatalf("GetUpgradeProfilesTyped failed: %v", err)
+			}
+			if len(profiles) != 1 || profiles[0].UpgradeType != "security" || *profiles[0].AtHour != 3 {
+				t.Fatalf("unexpected upgrade profiles: %+v", profiles)
+			}
+		}},
+		{"GetRepositoryProfiles", func(t *testing.T) {
+			profiles, err := api.GetRepositoryProfilesTyped(ctx, &LegacyGetRepositoryProfilesParams{})
+			if err != nil {
+				t.Fatalf("GetRepositoryProfilesTyped failed: %v", err)
+			}
+			if len(profiles) != 1 || profiles[0].AptSources[0].Id != 8 || profiles[0].Pockets[0].GpgKey != nil {
+				t.Fatalf("unexpected repository profiles: %+v", profiles)
+			}
+		}},
+		{"GetSavedSearches", func(t *testing.T) {
+			searches, err := api.GetSavedSearchesTyped(ctx, &LegacyGetSavedSearchesParams{})
+			if err != nil {
+				t.Fatalf("GetSavedSearchesTyped failed: %v", err)
+			}
+			if len(searches) != 1 || searches[0].Search != "tag:web" {
+				t.Fatalf("unexpected saved searches: %+v", searches)
+			}
+		}},
+		{"GetAlerts", func(t *testing.T) {
+			alerts, err := api.GetAlertsTyped(ctx)
+			if err != nil {
+				t.Fatalf("GetAlertsTyped failed: %v", err)
+			}
+			if len(alerts) != 2 || !alerts[0].Subscribed || !alerts[1].AllComputers {
+				t.Fatalf("unexpected alerts: %+v", alerts)
+			}
+		}},
+		{"GetAdministrators", func(t *testing.T) {
+			admins, err := api.GetAdministratorsTyped(ctx)
+			if err != nil {
+				t.Fatalf("GetAdministratorsTyped failed: %v", err)
+			}
+			if len(admins) != 1 || admins[0].Email != "jan@example.com" {
+				t.Fatalf("unexpected administrators: %+v", admins)
+			}
+		}},
+		{"ExecuteScript", func(t *testing.T) {
+			activity, err := api.ExecuteScriptTyped(ctx, &LegacyExecuteScriptParams{Query: "tag:web", ScriptId: 1})
+			if err != nil {
+				t.Fatalf("ExecuteScriptTyped failed: %v", err)
+			}
+			if activity.Id != 601 || activity.ActivityStatus != "undelivered" {
+				t.Fatalf("unexpected activity: %+v", activity)
+			}
+		}},
+		{"RebootComputers", func(t *testing.T) {
+			activity, err := api.RebootComputersTyped(ctx, &LegacyRebootComputersParams{ComputerIds: []int{12}})
+			if err != nil {
+				t.Fatalf("RebootComputersTyped failed: %v", err)
+			}
+			if activity.Id != 602 {
+				t.Fatalf("unexpected activity: %+v", activity)
+			}
+		}},
+		{"InstallPackages", func(t *testing.T) {
+			activity, err := api.InstallPackagesTyped(ctx, &LegacyInstallPackagesParams{Query: "tag:web", Packages: []string{"openssl"}})
+			if err != nil {
+				t.Fatalf("InstallPackagesTyped failed: %v", err)
+			}
+			if activity.Id != 603 {
+				t.Fatalf("unexpected activity: %+v", activity)
+			}
+		}},
+		{"UpgradePackages", func(t *testing.T) {
+			activity, err := api.UpgradePackagesTyped(ctx, &LegacyUpgradePackagesParams{Query: "tag:web"})
+			if err != nil {
+				t.Fatalf("UpgradePackagesTyped failed: %v", err)
+			}
+			if activity.Id != 604 {
+				t.Fatalf("unexpected activity: %+v", activity)
+			}
+		}},
+		{"SyncMirrorPocket", func(t *testing.T) {
+			activity, err := api.SyncMirrorPocketTyped(ctx, &LegacySyncMirrorPocketParams{Name: "release", Series: "jammy", Distribution: "ubuntu"})
+			if err != nil {
+				t.Fatalf("SyncMirrorPocketTyped failed: %v", err)
+			}
+			if activity.Id != 605 || activity.Creator == nil || *activity.Creator.Id != 1234 {
+				t.Fatalf("unexpected activity: %+v", activity)
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.action, tt.check)
+	}
+
+	t.Run("error status", func(t *testing.T) {
+		resp, err := api.LegacyGetScriptsWithResponse(ctx, &LegacyGetScriptsParams{})
+		if err != nil {
+			t.Fatalf("LegacyGetScriptsWithResponse failed: %v", err)
+		}
+		if _, err := parseLegacyResult[[]V1Script]("GetScripts", resp.HTTPResponse, resp.Body); err == nil {
+			t.Fatal("expected an error for an action without a fixture")
+		}
+	})
+}
@@ -0,0 +1,275 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// redacted replaces secrets in logged URLs, headers and bodies.
+const redacted = "REDACTED"
+
+// redactedHeaders are the headers whose values are never logged.
+var redactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// redactedFields are the query parameters, form fields and JSON object keys
+// whose values are never logged. They're compared case-insensitively.
+// Besides credentials, this covers key material, like GPG keys, and the
+// tokens returned by the login endpoints.
+var redactedFields = map[string]bool{
+	"secret_key":    true,
+	"password":      true,
+	"material":      true,
+	"token":         true,
+	"id_token":      true,
+	"access_token":  true,
+	"refresh_token": true,
+}
+
+// LoggerOption configures WithLogger.
+type LoggerOption func(*httpLogger)
+
+// WithLogBodies also logs request and response headers and bodies, cut off
+// after maxBytes. Bodies are redacted like URLs before they are cut off.
+//
+// This reads each response body into memory before returning it, so avoid it
+// for large downloads.
+func WithLogBodies(maxBytes int) LoggerOption {
+	return func(l *httpLogger) {
+		l.maxBodyBytes = maxBytes
+	}
+}
+
+// WithLogger logs every request sent through the client to logger at debug
+// level, with its method, URL, status and latency. Legacy requests also
+// include their action. Values of the Authorization header, of secret_key,
+// password and material parameters, and of login tokens are redacted.
+//
+// This wraps the HttpRequestDoer configured by earlier options, so it must
+// come after WithHTTPClient. Put it before WithRetry so that each retry
+// attempt is logged.
+func WithLogger(logger *slog.Logger, opts ...LoggerOption) ClientOption {
+	l := &httpLogger{logger: logger}
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	return func(c *Client) error {
+		if c.Client == nil {
+			c.Client = &http.Client{}
+		}
+		c.Client = &loggingDoer{next: c.Client, logger: l}
+		return nil
+	}
+}
+
+type httpLogger struct {
+	logger       *slog.Logger
+	maxBodyBytes int
+}
+
+type loggingDoer struct {
+	next   HttpRequestDoer
+	logger *httpLogger
+}
+
+// Do implements HttpRequestDoer for loggingDoer.
+func (d *loggingDoer) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	logger := d.logger.logger
+	if !logger.Enabled(ctx, slog.LevelDebug) {
+		return d.next.Do(req)
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("url", redactURL(req.URL)),
+	}
+	if action := legacyAction(req); action != "" {
+		attrs = append(attrs, slog.String("action", action))
+	}
+
+	logBodies := d.logger.maxBodyBytes > 0
+	if logBodies {
+		body, err := peekRequestBody(req)
+		if err != nil {
+			return nil, err
+		}
+		attrs = append(attrs,
+			slog.Any("request_headers", redactHeaders(req.Header)),
+			slog.String("request_body", d.logger.body(body, req.Header.Get("Content-Type"))),
+		)
+	}
+
+	start := time.Now()
+	res, err := d.next.Do(req)
+	attrs = append(attrs, slog.Duration("duration", time.Since(start)))
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+		logger.LogAttrs(ctx, slog.LevelDebug, "HTTP request failed", attrs...)
+		return res, err
+	}
+
+	attrs = append(attrs, slog.Int("status", res.StatusCode))
+	if logBodies {
+		body, err := io.ReadAll(res.Body)
+		_ = res.Body.Close()
+		res.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), errReader{err}))
+		if err != nil {
+			attrs = append(attrs, slog.String("error", err.Error()))
+		}
+		attrs = append(attrs,
+			slog.Any("response_headers", redactHeaders(res.Header)),
+			slog.String("response_body", d.logger.body(body, res.Header.Get("Content-Type"))),
+		)
+	}
+
+	logger.LogAttrs(ctx, slog.LevelDebug, "HTTP request", attrs...)
+	return res, nil
+}
+
+// body returns the redacted body to log, cut off after maxBodyBytes.
+func (l *httpLogger) body(body []byte, contentType string) string {
+	s := redactBody(body, contentType)
+	if len(s) > l.maxBodyBytes {
+		return s[:l.maxBodyBytes] + "... (truncated)"
+	}
+	return s
+}
+
+// peekRequestBody returns the body of req, leaving req with an unread body.
+func peekRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		return io.ReadAll(body)
+	}
+
+	data, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	return data, nil
+}
+
+// errReader returns err, or io.EOF if it's nil, once the rest of a response
+// body has been read, so logging doesn't hide read errors from the caller.
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	if r.err == nil {
+		return 0, io.EOF
+	}
+	return 0, r.err
+}
+
+func redactURL(u *url.URL) string {
+	redactedURL := *u
+	if u.RawQuery != "" {
+		redactedURL.RawQuery = redactValues(u.Query()).Encode()
+	}
+	return redactedURL.Redacted()
+}
+
+func redactValues(values url.Values) url.Values {
+	for key, vals := range values {
+		if isRedactedField(key) {
+			for i := range vals {
+				vals[i] = redacted
+			}
+		}
+	}
+	return values
+}
+
+func redactHeaders(header http.Header) http.Header {
+	header = header.Clone()
+	for _, name := range redactedHeaders {
+		if _, ok := header[name]; ok {
+			header.Set(name, redacted)
+		}
+	}
+	return header
+}
+
+// redactBody redacts JSON and form-encoded bodies. Other bodies are returned
+// as they are.
+func redactBody(body []byte, contentType string) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return redacted
+		}
+		return redactValues(values).Encode()
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") || json.Valid(body):
+		var v any
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		if err := decoder.Decode(&v); err != nil {
+			// Don't risk logging a secret from a body that can't be parsed.
+			return redacted
+		}
+		out, err := json.Marshal(redactJSON(v))
+		if err != nil {
+			return redacted
+		}
+		return string(out)
+	default:
+		return string(body)
+	}
+}
+
+func redactJSON(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if isRedactedField(key) {
+				v[key] = redacted
+			} else {
+				v[key] = redactJSON(value)
+			}
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = redactJSON(value)
+		}
+	}
+	return v
+}
+
+// isRedactedField reports whether the value of a parameter or key must not
+// be logged. Legacy list and map parameters, like material.1, are matched by
+// their name.
+func isRedactedField(key string) bool {
+	name, _, _ := strings.Cut(strings.ToLower(key), ".")
+	return redactedFields[name]
+}
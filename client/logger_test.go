@@ -0,0 +1,154 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithLogger(t *testing.T) {
+	token := testJWT(t, time.Now().Add(time.Hour), "logger")
+
+	handler := http.NewServeMux()
+	handler.HandleFunc("/api/login/access-key", func(w http.ResponseWriter, r *http.Request) {
+		var body LoginWithAccessKeyJSONRequestBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.SecretKey != "s3cr3t" {
+			t.Errorf("expected the request body to reach the server intact, got %+v (%v)", body, err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(LoginResponse{Email: "jane@example.com", Token: token})
+	})
+	handler.HandleFunc("/api/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": 3, "name": "mirror-key"}`))
+	})
+
+	server := httptest.NewTLSServer(handler)
+	defer server.Close()
+
+	newClient := func(t *testing.T, out io.Writer, level slog.Level, opts ...LoggerOption) *ClientWithResponses {
+		t.Helper()
+		logger := slog.New(slog.NewJSONHandler(out, &slog.HandlerOptions{Level: level}))
+		api, err := NewLandscapeAPIClient(server.URL, NewAccessKeyProvider("AK", "s3cr3t"),
+			WithHTTPClient(server.Client()), WithLogger(logger, opts...))
+		if err != nil {
+			t.Fatalf("failed to init client: %v", err)
+		}
+		return api
+	}
+
+	importKey := func(t *testing.T, api *ClientWithResponses) {
+		t.Helper()
+		resp, err := api.LegacyImportGPGKeyWithResponse(context.Background(), &LegacyImportGPGKeyParams{
+			Name:     "mirror-key",
+			Material: "-----BEGIN PGP PUBLIC KEY BLOCK-----",
+		})
+		if err != nil {
+			t.Fatalf("LegacyImportGPGKeyWithResponse failed: %v", err)
+		}
+		if !strings.Contains(string(resp.Body), "mirror-key") {
+			t.Fatalf("expected the response body to be readable after logging, got %q", resp.Body)
+		}
+	}
+
+	records := func(t *testing.T, out *bytes.Buffer) []map[string]any {
+		t.Helper()
+		var records []map[string]any
+		decoder := json.NewDecoder(out)
+		for decoder.More() {
+			var record map[string]any
+			if err := decoder.Decode(&record); err != nil {
+				t.Fatalf("failed to decode log record: %v", err)
+			}
+			records = append(records, record)
+		}
+		return records
+	}
+
+	t.Run("logs requests with redacted URLs", func(t *testing.T) {
+		var out bytes.Buffer
+		importKey(t, newClient(t, &out, slog.LevelDebug))
+
+		logged := out.String()
+		if strings.Contains(logged, "s3cr3t") || strings.Contains(logged, "PGP") || strings.Contains(logged, token) {
+			t.Fatalf("expected secrets to be redacted, got %s", logged)
+		}
+
+		got := records(t, &out)
+		if len(got) != 2 {
+			t.Fatalf("expected 2 records, got %d: %s", len(got), logged)
+		}
+
+		record := got[1]
+		if record["method"] != http.MethodGet || record["action"] != "ImportGPGKey" || record["status"] != float64(http.StatusOK) {
+			t.Fatalf("unexpected record: %v", record)
+		}
+		url, _ := record["url"].(string)
+		if !strings.Contains(url, "material=REDACTED") || !strings.Contains(url, "name=mirror-key") {
+			t.Fatalf("expected material to be redacted from the URL, got %q", url)
+		}
+		if _, ok := record["duration"]; !ok {
+			t.Fatalf("expected the latency to be logged: %v", record)
+		}
+		if _, ok := record["request_body"]; ok {
+			t.Fatalf("expected bodies not to be logged by default: %v", record)
+		}
+	})
+
+	t.Run("logs redacted bodies and headers", func(t *testing.T) {
+		var out bytes.Buffer
+		importKey(t, newClient(t, &out, slog.LevelDebug, WithLogBodies(1024)))
+
+		logged := out.String()
+		if strings.Contains(logged, "s3cr3t") || strings.Contains(logged, "PGP") || strings.Contains(logged, token) {
+			t.Fatalf("expected secrets to be redacted, got %s", logged)
+		}
+
+		got := records(t, &out)
+		if len(got) != 2 {
+			t.Fatalf("expected 2 records, got %d: %s", len(got), logged)
+		}
+
+		login := got[0]
+		if body, _ := login["request_body"].(string); !strings.Contains(body, `"secret_key":"REDACTED"`) || !strings.Contains(body, `"access_key":"AK"`) {
+			t.Fatalf("expected the secret key to be redacted from the request body, got %q", body)
+		}
+		if body, _ := login["response_body"].(string); !strings.Contains(body, `"token":"REDACTED"`) {
+			t.Fatalf("expected the token to be redacted from the response body, got %q", body)
+		}
+
+		headers, _ := got[1]["request_headers"].(map[string]any)
+		if auth, _ := headers["Authorization"].([]any); len(auth) != 1 || auth[0] != "REDACTED" {
+			t.Fatalf("expected the Authorization header to be redacted, got %v", headers)
+		}
+		if body, _ := got[1]["response_body"].(string); body != `{"id":3,"name":"mirror-key"}` {
+			t.Fatalf("unexpected response body %q", body)
+		}
+	})
+
+	t.Run("truncates bodies", func(t *testing.T) {
+		var out bytes.Buffer
+		importKey(t, newClient(t, &out, slog.LevelDebug, WithLogBodies(8)))
+
+		got := records(t, &out)
+		if body, _ := got[1]["response_body"].(string); body != `{"id":3,... (truncated)` {
+			t.Fatalf("expected the body to be truncated, got %q", body)
+		}
+	})
+
+	t.Run("logs nothing above debug level", func(t *testing.T) {
+		var out bytes.Buffer
+		importKey(t, newClient(t, &out, slog.LevelInfo, WithLogBodies(1024)))
+
+		if out.Len() != 0 {
+			t.Fatalf("expected no records, got %s", out.String())
+		}
+	})
+}
@@ -0,0 +1,372 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// DefaultOIDCScopes are the scopes OIDCProvider requests if Scopes is empty.
+// offline_access asks the issuer for a refresh token.
+var DefaultOIDCScopes = []string{"openid", "email", "profile", "offline_access"}
+
+// DeviceCode is what the user needs to approve an OIDC device login.
+type DeviceCode struct {
+	// UserCode is the code the user enters at VerificationURI.
+	UserCode string
+
+	// VerificationURI is the page where the user approves the login.
+	VerificationURI string
+
+	// VerificationURIComplete, if the issuer provides it, includes the user
+	// code so the user doesn't have to type it.
+	VerificationURIComplete string
+
+	// Expiry is when the code stops being accepted.
+	Expiry time.Time
+}
+
+// OIDCProvider logs in through an external OpenID Connect identity provider,
+// such as Ubuntu One, using the OAuth 2.0 device authorization flow (RFC
+// 8628). The ID token the issuer returns is exchanged for a Landscape JWT.
+//
+// If RefreshTokenFile is set, the issuer's refresh token is stored there and
+// later logins use it instead of asking the user to approve the login again.
+type OIDCProvider struct {
+	// Issuer is the OIDC issuer URL. Its endpoints are discovered from
+	// Issuer/.well-known/openid-configuration.
+	Issuer string
+
+	// ClientID and ClientSecret identify the client registered with the
+	// issuer. ClientSecret is empty for public clients.
+	ClientID     string
+	ClientSecret string
+
+	// Scopes to request. Defaults to DefaultOIDCScopes.
+	Scopes []string
+
+	// Prompt is called with the code the user must approve, ex. to print it.
+	// Login fails if the device flow is needed and Prompt is nil.
+	Prompt func(DeviceCode) error
+
+	// RefreshTokenFile is where the refresh token is cached. If empty, the
+	// refresh token is only kept in memory.
+	RefreshTokenFile string
+
+	// ExchangePath is the Landscape endpoint, relative to the base URL, that
+	// exchanges an ID token for a JWT. The published OpenAPI spec doesn't
+	// document one, so it depends on the deployment and must be set.
+	ExchangePath string
+
+	// HTTPClient is used to talk to the issuer. Defaults to
+	// http.DefaultClient.
+	HTTPClient *http.Client
+
+	mu           sync.Mutex
+	refreshToken string
+}
+
+// NewOIDCProvider creates an OIDCProvider that exchanges ID tokens at
+// exchangePath and caches its refresh token in DefaultTokenCacheDir.
+func NewOIDCProvider(issuer, clientID, exchangePath string) *OIDCProvider {
+	p := &OIDCProvider{
+		Issuer:       issuer,
+		ClientID:     clientID,
+		ExchangePath: exchangePath,
+	}
+
+	if dir, err := DefaultTokenCacheDir(); err == nil {
+		sum := sha256.Sum256([]byte(strings.TrimRight(issuer, "/") + "\x00" + clientID))
+		p.RefreshTokenFile = filepath.Join(dir, "oidc-"+hex.EncodeToString(sum[:])+".json")
+	}
+
+	return p
+}
+
+// Login implements LoginProvider for OIDCProvider.
+func (p *OIDCProvider) Login(ctx context.Context, c *ClientWithResponses) (string, error) {
+	session, err := p.LoginSession(ctx, c)
+	if err != nil {
+		return "", err
+	}
+	return session.Token, nil
+}
+
+// LoginSession implements SessionLoginProvider for OIDCProvider.
+func (p *OIDCProvider) LoginSession(ctx context.Context, c *ClientWithResponses) (*Session, error) {
+	// Check before asking the user to approve a login that can't be used.
+	if p.ExchangePath == "" {
+		return nil, fmt.Errorf("OIDC login requires the Landscape endpoint that exchanges ID tokens; set ExchangePath")
+	}
+
+	idToken, err := p.IDToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.exchange(ctx, c, idToken)
+}
+
+// IDToken returns an ID token from the issuer, using the refresh token if
+// there is one, and otherwise running the device flow.
+func (p *OIDCProvider) IDToken(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.HTTPClient != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, p.HTTPClient)
+	}
+
+	config, err := p.config(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	token, refreshErr := p.refresh(ctx, config)
+	if refreshErr == nil && token != nil && idTokenOf(token) == "" {
+		// Issuers don't have to return an ID token on refresh, but without
+		// one there's nothing to exchange, so log in from scratch.
+		token, refreshErr = nil, fmt.Errorf("OIDC issuer didn't return an ID token when refreshing the login")
+	}
+	if token == nil {
+		if token, err = p.deviceFlow(ctx, config); err != nil {
+			if refreshErr != nil {
+				return "", fmt.Errorf("failed to refresh the OIDC login: %w; then %w", refreshErr, err)
+			}
+			return "", err
+		}
+	}
+
+	if token.RefreshToken != "" {
+		p.refreshToken = token.RefreshToken
+		_ = p.saveRefreshToken(token.RefreshToken)
+	}
+
+	idToken := idTokenOf(token)
+	if idToken == "" {
+		return "", fmt.Errorf("OIDC issuer didn't return an ID token")
+	}
+
+	return idToken, nil
+}
+
+func idTokenOf(token *oauth2.Token) string {
+	idToken, _ := token.Extra("id_token").(string)
+	return idToken
+}
+
+// ClearRefreshToken forgets the refresh token, so the next login runs the
+// device flow.
+func (p *OIDCProvider) ClearRefreshToken() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.refreshToken = ""
+	if p.RefreshTokenFile == "" {
+		return nil
+	}
+	if err := os.Remove(p.RefreshTokenFile); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (p *OIDCProvider) config(ctx context.Context) (*oauth2.Config, error) {
+	issuer := strings.TrimRight(p.Issuer, "/")
+	if issuer == "" {
+		return nil, fmt.Errorf("OIDC issuer must be set")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := p.httpClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("OIDC discovery request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OIDC discovery failed with status: %d", res.StatusCode)
+	}
+
+	var discovery struct {
+		DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint"`
+		TokenEndpoint               string `json:"token_endpoint"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&discovery); err != nil {
+		return nil, fmt.Errorf("failed to decode OIDC discovery document: %w", err)
+	}
+	if discovery.TokenEndpoint == "" {
+		return nil, fmt.Errorf("OIDC issuer %s has no token endpoint", issuer)
+	}
+
+	scopes := p.Scopes
+	if len(scopes) == 0 {
+		scopes = DefaultOIDCScopes
+	}
+
+	return &oauth2.Config{
+		ClientID:     p.ClientID,
+		ClientSecret: p.ClientSecret,
+		Scopes:       scopes,
+		Endpoint: oauth2.Endpoint{
+			DeviceAuthURL: discovery.DeviceAuthorizationEndpoint,
+			TokenURL:      discovery.TokenEndpoint,
+		},
+	}, nil
+}
+
+// refresh returns a new token using the cached refresh token, or nil if
+// there isn't one.
+func (p *OIDCProvider) refresh(ctx context.Context, config *oauth2.Config) (*oauth2.Token, error) {
+	refreshToken := p.refreshToken
+	if refreshToken == "" {
+		refreshToken = p.loadRefreshToken()
+	}
+	if refreshToken == "" {
+		return nil, nil
+	}
+
+	token, err := config.TokenSource(ctx, &oauth2.Token{RefreshToken: refreshToken}).Token()
+	if err != nil {
+		// The refresh token was revoked or expired; fall back to the device
+		// flow.
+		p.refreshToken = ""
+		return nil, err
+	}
+
+	return token, nil
+}
+
+func (p *OIDCProvider) deviceFlow(ctx context.Context, config *oauth2.Config) (*oauth2.Token, error) {
+	if config.Endpoint.DeviceAuthURL == "" {
+		return nil, fmt.Errorf("OIDC issuer %s doesn't support the device authorization flow", p.Issuer)
+	}
+	if p.Prompt == nil {
+		return nil, fmt.Errorf("OIDC login requires approval, but no prompt is configured")
+	}
+
+	auth, err := config.DeviceAuth(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("OIDC device authorization failed: %w", err)
+	}
+
+	if err := p.Prompt(DeviceCode{
+		UserCode:                auth.UserCode,
+		VerificationURI:         auth.VerificationURI,
+		VerificationURIComplete: auth.VerificationURIComplete,
+		Expiry:                  auth.Expiry,
+	}); err != nil {
+		return nil, err
+	}
+
+	token, err := config.DeviceAccessToken(ctx, auth)
+	if err != nil {
+		return nil, fmt.Errorf("OIDC device login failed: %w", err)
+	}
+
+	return token, nil
+}
+
+// exchange trades an ID token for a Landscape session.
+func (p *OIDCProvider) exchange(ctx context.Context, c *ClientWithResponses, idToken string) (*Session, error) {
+	inner, ok := c.ClientInterface.(*Client)
+	if !ok {
+		return nil, fmt.Errorf("OIDC login requires a *Client, got %T", c.ClientInterface)
+	}
+
+	body, err := json.Marshal(map[string]string{"id_token": idToken})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, inner.Server+strings.TrimLeft(p.ExchangePath, "/"), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := inner.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("login with OIDC request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, NewAPIError(res, resBody)
+	}
+
+	var login LoginResponse
+	if err := json.Unmarshal(resBody, &login); err != nil {
+		return nil, fmt.Errorf("failed to decode login response: %w", err)
+	}
+	if login.Token == "" {
+		return nil, fmt.Errorf("login with OIDC returned no token")
+	}
+
+	return NewSession(&login), nil
+}
+
+func (p *OIDCProvider) httpClient() *http.Client {
+	if p.HTTPClient != nil {
+		return p.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+type cachedRefreshToken struct {
+	Issuer       string `json:"issuer"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (p *OIDCProvider) loadRefreshToken() string {
+	if p.RefreshTokenFile == "" {
+		return ""
+	}
+
+	data, err := os.ReadFile(p.RefreshTokenFile)
+	if err != nil {
+		return ""
+	}
+
+	var cached cachedRefreshToken
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return ""
+	}
+	return cached.RefreshToken
+}
+
+func (p *OIDCProvider) saveRefreshToken(refreshToken string) error {
+	if p.RefreshTokenFile == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(cachedRefreshToken{Issuer: p.Issuer, RefreshToken: refreshToken})
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(p.RefreshTokenFile, data)
+}
@@ -0,0 +1,249 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+// fakeOIDCIssuer is a stand-in OIDC issuer supporting discovery, the device
+// flow and refresh tokens.
+type fakeOIDCIssuer struct {
+	*httptest.Server
+
+	mu                    sync.Mutex
+	deviceLogins          int
+	refreshes             int
+	revokedRefresh        bool
+	refreshWithoutIDToken bool
+}
+
+func newFakeOIDCIssuer(t *testing.T) *fakeOIDCIssuer {
+	t.Helper()
+
+	issuer := &fakeOIDCIssuer{}
+	handler := http.NewServeMux()
+	handler.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                        issuer.URL,
+			"device_authorization_endpoint": issuer.URL + "/device",
+			"token_endpoint":                issuer.URL + "/token",
+		})
+	})
+	handler.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("client_id") != "landscape-cli" {
+			t.Errorf("unexpected client ID %q", r.FormValue("client_id"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"device_code":      "device-code",
+			"user_code":        "ABCD-EFGH",
+			"verification_uri": issuer.URL + "/activate",
+			"expires_in":       300,
+			"interval":         1,
+		})
+	})
+	handler.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		issuer.mu.Lock()
+		defer issuer.mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.FormValue("grant_type") {
+		case "urn:ietf:params:oauth:grant-type:device_code":
+			issuer.deviceLogins++
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"access_token":  "access",
+				"token_type":    "Bearer",
+				"refresh_token": "refresh-1",
+				"id_token":      "id-device",
+			})
+		case "refresh_token":
+			if issuer.revokedRefresh || r.FormValue("refresh_token") != "refresh-1" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error": "invalid_grant"}`))
+				return
+			}
+			issuer.refreshes++
+			token := map[string]any{
+				"access_token":  "access",
+				"token_type":    "Bearer",
+				"refresh_token": "refresh-1",
+				"id_token":      "id-refreshed",
+			}
+			if issuer.refreshWithoutIDToken {
+				delete(token, "id_token")
+			}
+			_ = json.NewEncoder(w).Encode(token)
+		default:
+			t.Errorf("unexpected grant type %q", r.FormValue("grant_type"))
+		}
+	})
+
+	issuer.Server = httptest.NewTLSServer(handler)
+	t.Cleanup(issuer.Close)
+
+	return issuer
+}
+
+func (i *fakeOIDCIssuer) counts() (int, int) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.deviceLogins, i.refreshes
+}
+
+func TestOIDCProvider(t *testing.T) {
+	issuer := newFakeOIDCIssuer(t)
+
+	handler := http.NewServeMux()
+	handler.HandleFunc("/api/login/oidc", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			IDToken string `json:"id_token"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(LoginResponse{
+			Token:          "landscape-" + req.IDToken,
+			Email:          "jan@example.com",
+			CurrentAccount: "example-org",
+		})
+	})
+
+	landscape := httptest.NewTLSServer(handler)
+	defer landscape.Close()
+
+	api, err := NewClientWithResponses(landscape.URL, WithHTTPClient(landscape.Client()))
+	if err != nil {
+		t.Fatalf("failed to init client: %v", err)
+	}
+
+	cacheFile := filepath.Join(t.TempDir(), "oidc.json")
+	newProvider := func(prompts *[]DeviceCode) *OIDCProvider {
+		return &OIDCProvider{
+			Issuer:           issuer.URL,
+			ClientID:         "landscape-cli",
+			HTTPClient:       issuer.Client(),
+			RefreshTokenFile: cacheFile,
+			ExchangePath:     "api/login/oidc",
+			Prompt: func(code DeviceCode) error {
+				*prompts = append(*prompts, code)
+				return nil
+			},
+		}
+	}
+
+	t.Run("device flow on first login", func(t *testing.T) {
+		var prompts []DeviceCode
+		session, err := Login(context.Background(), api, newProvider(&prompts))
+		if err != nil {
+			t.Fatalf("Login failed: %v", err)
+		}
+
+		if session.Token != "landscape-id-device" || session.CurrentAccount != "example-org" {
+			t.Fatalf("unexpected session: %+v", session)
+		}
+		if len(prompts) != 1 || prompts[0].UserCode != "ABCD-EFGH" || prompts[0].VerificationURI != issuer.URL+"/activate" {
+			t.Fatalf("unexpected prompts: %+v", prompts)
+		}
+	})
+
+	t.Run("cached refresh token skips the device flow", func(t *testing.T) {
+		var prompts []DeviceCode
+		token, err := newProvider(&prompts).Login(context.Background(), api)
+		if err != nil {
+			t.Fatalf("Login failed: %v", err)
+		}
+
+		if token != "landscape-id-refreshed" {
+			t.Fatalf("expected refreshed token, got %s", token)
+		}
+		if len(prompts) != 0 {
+			t.Fatalf("expected no prompts, got %+v", prompts)
+		}
+		if devices, refreshes := issuer.counts(); devices != 1 || refreshes != 1 {
+			t.Fatalf("expected 1 device login and 1 refresh, got %d and %d", devices, refreshes)
+		}
+	})
+
+	t.Run("revoked refresh token falls back to the device flow", func(t *testing.T) {
+		issuer.mu.Lock()
+		issuer.revokedRefresh = true
+		issuer.mu.Unlock()
+
+		var prompts []DeviceCode
+		token, err := newProvider(&prompts).Login(context.Background(), api)
+		if err != nil {
+			t.Fatalf("Login failed: %v", err)
+		}
+
+		if token != "landscape-id-device" || len(prompts) != 1 {
+			t.Fatalf("expected a device login, got %s with %d prompts", token, len(prompts))
+		}
+	})
+
+	t.Run("refresh without an ID token falls back to the device flow", func(t *testing.T) {
+		issuer.mu.Lock()
+		issuer.revokedRefresh = false
+		issuer.refreshWithoutIDToken = true
+		issuer.mu.Unlock()
+
+		var prompts []DeviceCode
+		token, err := newProvider(&prompts).Login(context.Background(), api)
+		if err != nil {
+			t.Fatalf("Login failed: %v", err)
+		}
+
+		if token != "landscape-id-device" || len(prompts) != 1 {
+			t.Fatalf("expected a device login, got %s with %d prompts", token, len(prompts))
+		}
+	})
+
+	t.Run("failed refresh is reported with the device flow error", func(t *testing.T) {
+		issuer.mu.Lock()
+		issuer.revokedRefresh = true
+		issuer.mu.Unlock()
+
+		promptErr := errors.New("no terminal to prompt on")
+		p := newProvider(nil)
+		p.Prompt = func(DeviceCode) error { return promptErr }
+
+		_, err := p.Login(context.Background(), api)
+		var refreshErr *oauth2.RetrieveError
+		if !errors.Is(err, promptErr) || !errors.As(err, &refreshErr) {
+			t.Fatalf("expected both the refresh and the prompt errors, got %v", err)
+		}
+	})
+
+	t.Run("device flow requires a prompt", func(t *testing.T) {
+		p := &OIDCProvider{Issuer: issuer.URL, ClientID: "landscape-cli", ExchangePath: "api/login/oidc", HTTPClient: issuer.Client()}
+		if _, err := p.Login(context.Background(), api); err == nil {
+			t.Fatal("expected an error without a prompt")
+		}
+	})
+
+	t.Run("exchange path is required", func(t *testing.T) {
+		var prompts []DeviceCode
+		p := newProvider(&prompts)
+		p.ExchangePath = ""
+		p.RefreshTokenFile = ""
+
+		if _, err := p.Login(context.Background(), api); err == nil || !strings.Contains(err.Error(), "ExchangePath") {
+			t.Fatalf("expected an error about ExchangePath, got %v", err)
+		}
+		if len(prompts) != 0 {
+			t.Fatalf("expected no prompts before failing, got %+v", prompts)
+		}
+	})
+}
@@ -0,0 +1,163 @@
+// Code generated by operationsgen. DO NOT EDIT.
+
+package client
+
+// legacyOperations maps each legacy action to its operation ID.
+var legacyOperations = map[string]string{
+	"AcceptPendingComputers":                "LegacyAcceptPendingComputers",
+	"AddAPTSourcesToRepositoryProfile":      "LegacyAddAPTSourcesToRepositoryProfile",
+	"AddAccessGroupsToRole":                 "LegacyAddAccessGroupsToRole",
+	"AddAnnotationToComputers":              "LegacyAddAnnotationToComputers",
+	"AddPackageFiltersToPocket":             "LegacyAddPackageFiltersToPocket",
+	"AddPermissionsToRole":                  "LegacyAddPermissionsToRole",
+	"AddPersonsToRole":                      "LegacyAddPersonsToRole",
+	"AddPocketsToRepositoryProfile":         "LegacyAddPocketsToRepositoryProfile",
+	"AddTagsToComputers":                    "LegacyAddTagsToComputers",
+	"AddUploaderGPGKeysToPocket":            "LegacyAddUploaderGPGKeysToPocket",
+	"ApproveActivities":                     "LegacyApproveActivities",
+	"AssociateAlert":                        "LegacyAssociateAlert",
+	"AssociatePackageProfile":               "LegacyAssociatePackageProfile",
+	"AssociateRemovalProfile":               "LegacyAssociateRemovalProfile",
+	"AssociateRepositoryProfile":            "LegacyAssociateRepositoryProfile",
+	"AssociateUpgradeProfile":               "LegacyAssociateUpgradeProfile",
+	"CancelActivities":                      "LegacyCancelActivities",
+	"ChangeComputersAccessGroup":            "LegacyChangeComputersAccessGroup",
+	"CopyPackageProfile":                    "LegacyCopyPackageProfile",
+	"CopyRole":                              "LegacyCopyRole",
+	"CopyScript":                            "LegacyCopyScript",
+	"CreateAPTSource":                       "LegacyCreateAPTSource",
+	"CreateAccessGroup":                     "LegacyCreateAccessGroup",
+	"CreateChildComputer":                   "LegacyCreateChildComputer",
+	"CreateDistribution":                    "LegacyCreateDistribution",
+	"CreatePackageProfile":                  "LegacyCreatePackageProfile",
+	"CreatePocket":                          "LegacyCreatePocket",
+	"CreateRemovalProfile":                  "LegacyCreateRemovalProfile",
+	"CreateRepositoryProfile":               "LegacyCreateRepositoryProfile",
+	"CreateRole":                            "LegacyCreateRole",
+	"CreateSavedSearch":                     "LegacyCreateSavedSearch",
+	"CreateScript":                          "LegacyCreateScript",
+	"CreateScriptAttachment":                "LegacyCreateScriptAttachment",
+	"CreateSeries":                          "LegacyCreateSeries",
+	"CreateUpgradeProfile":                  "LegacyCreateUpgradeProfile",
+	"CreateUser":                            "LegacyCreateUser",
+	"DeleteChildComputers":                  "LegacyDeleteChildComputers",
+	"DeriveSeries":                          "LegacyDeriveSeries",
+	"DiffPullPocket":                        "LegacyDiffPullPocket",
+	"DisableAdministrator":                  "LegacyDisableAdministrator",
+	"DisassociateAlert":                     "LegacyDisassociateAlert",
+	"DisassociatePackageProfile":            "LegacyDisassociatePackageProfile",
+	"DisassociateRemovalProfile":            "LegacyDisassociateRemovalProfile",
+	"DisassociateRepositoryProfile":         "LegacyDisassociateRepositoryProfile",
+	"DisassociateUpgradeProfile":            "LegacyDisassociateUpgradeProfile",
+	"EditPackageProfile":                    "LegacyEditPackageProfile",
+	"EditPocket":                            "LegacyEditPocket",
+	"EditRemovalProfile":                    "LegacyEditRemovalProfile",
+	"EditRepositoryProfile":                 "LegacyEditRepositoryProfile",
+	"EditSavedSearch":                       "LegacyEditSavedSearch",
+	"EditScript":                            "LegacyEditScript",
+	"EditUpgradeProfile":                    "LegacyEditUpgradeProfile",
+	"EditUser":                              "LegacyEditUser",
+	"ExecuteScript":                         "LegacyExecuteScript",
+	"GetAPTSources":                         "LegacyGetAPTSources",
+	"GetAccessGroups":                       "LegacyGetAccessGroups",
+	"GetActivities":                         "LegacyGetActivities",
+	"GetActivityTypes":                      "LegacyGetActivityTypes",
+	"GetAdministrators":                     "LegacyGetAdministrators",
+	"GetAlertSubscribers":                   "LegacyGetAlertSubscribers",
+	"GetAlerts":                             "LegacyGetAlerts",
+	"GetCSVComplianceData":                  "LegacyGetCSVComplianceData",
+	"GetComputerProcesses":                  "LegacyGetComputerProcesses",
+	"GetComputers":                          "LegacyGetComputers",
+	"GetComputersNotUpgraded":               "LegacyGetComputersNotUpgraded",
+	"GetDistributions":                      "LegacyGetDistributions",
+	"GetEventLog":                           "LegacyGetEventLog",
+	"GetGPGKeys":                            "LegacyGetGPGKeys",
+	"GetNotPingingComputers":                "LegacyGetNotPingingComputers",
+	"GetPackageProfiles":                    "LegacyGetPackageProfiles",
+	"GetPackages":                           "LegacyGetPackages",
+	"GetPendingComputers":                   "LegacyGetPendingComputers",
+	"GetPermissions":                        "LegacyGetPermissions",
+	"GetRemovalProfiles":                    "LegacyGetRemovalProfiles",
+	"GetRepoInfo":                           "LegacyGetRepoInfo",
+	"GetRepositoryProfiles":                 "LegacyGetRepositoryProfiles",
+	"GetRoles":                              "LegacyGetRoles",
+	"GetSavedSearches":                      "LegacyGetSavedSearches",
+	"GetScriptCode":                         "LegacyGetScriptCode",
+	"GetScripts":                            "LegacyGetScripts",
+	"GetSettings":                           "LegacyGetSettings",
+	"GetUSNTimeToFix":                       "LegacyGetUSNTimeToFix",
+	"GetUpgradeProfiles":                    "LegacyGetUpgradeProfiles",
+	"GetUpgradedComputersByFrequency":       "LegacyGetUpgradedComputersByFrequency",
+	"GetUsers":                              "LegacyGetUsers",
+	"GetWSLHosts":                           "LegacyGetWSLHosts",
+	"ImportGPGKey":                          "LegacyImportGPGKey",
+	"InstallPackages":                       "LegacyInstallPackages",
+	"InviteAdministrator":                   "LegacyInviteAdministrator",
+	"KillComputerProcesses":                 "LegacyKillComputerProcesses",
+	"ListPocket":                            "LegacyListPocket",
+	"ModifyPackageProfile":                  "LegacyModifyPackageProfile",
+	"PullPackagesToPocket":                  "LegacyPullPackagesToPocket",
+	"RebootComputers":                       "LegacyRebootComputers",
+	"RejectPendingComputers":                "LegacyRejectPendingComputers",
+	"RemoveAPTSource":                       "LegacyRemoveAPTSource",
+	"RemoveAPTSourceFromRepositoryProfile":  "LegacyRemoveAPTSourceFromRepositoryProfile",
+	"RemoveAPTSources":                      "LegacyRemoveAPTSources",
+	"RemoveAPTSourcesFromRepositoryProfile": "LegacyRemoveAPTSourcesFromRepositoryProfile",
+	"RemoveAccessGroup":                     "LegacyRemoveAccessGroup",
+	"RemoveAccessGroupsFromRole":            "LegacyRemoveAccessGroupsFromRole",
+	"RemoveAnnotationFromComputers":         "LegacyRemoveAnnotationFromComputers",
+	"RemoveComputers":                       "LegacyRemoveComputers",
+	"RemoveDistribution":                    "LegacyRemoveDistribution",
+	"RemoveGPGKey":                          "LegacyRemoveGPGKey",
+	"RemovePackageFiltersFromPocket":        "LegacyRemovePackageFiltersFromPocket",
+	"RemovePackageProfile":                  "LegacyRemovePackageProfile",
+	"RemovePackages":                        "LegacyRemovePackages",
+	"RemovePackagesFromPocket":              "LegacyRemovePackagesFromPocket",
+	"RemovePermissionsFromRole":             "LegacyRemovePermissionsFromRole",
+	"RemovePersonsFromRole":                 "LegacyRemovePersonsFromRole",
+	"RemovePocket":                          "LegacyRemovePocket",
+	"RemovePocketsFromRepositoryProfile":    "LegacyRemovePocketsFromRepositoryProfile",
+	"RemoveRemovalProfile":                  "LegacyRemoveRemovalProfile",
+	"RemoveRepositoryProfile":               "LegacyRemoveRepositoryProfile",
+	"RemoveRepositoryProfiles":              "LegacyRemoveRepositoryProfiles",
+	"RemoveRole":                            "LegacyRemoveRole",
+	"RemoveSavedSearch":                     "LegacyRemoveSavedSearch",
+	"RemoveScript":                          "LegacyRemoveScript",
+	"RemoveScriptAttachment":                "LegacyRemoveScriptAttachment",
+	"RemoveSeries":                          "LegacyRemoveSeries",
+	"RemoveTagsFromComputers":               "LegacyRemoveTagsFromComputers",
+	"RemoveUpgradeProfile":                  "LegacyRemoveUpgradeProfile",
+	"RemoveUploaderGPGKeysFromPocket":       "LegacyRemoveUploaderGPGKeysFromPocket",
+	"RemoveWSLHosts":                        "LegacyRemoveWSLHosts",
+	"RenameComputers":                       "LegacyRenameComputers",
+	"SetDefaultChildComputer":               "LegacySetDefaultChildComputer",
+	"SetSettings":                           "LegacySetSettings",
+	"ShutdownComputers":                     "LegacyShutdownComputers",
+	"ShutdownHostComputer":                  "LegacyShutdownHostComputer",
+	"StartChildComputers":                   "LegacyStartChildComputers",
+	"StopChildComputers":                    "LegacyStopChildComputers",
+	"SubscribeToAlert":                      "LegacySubscribeToAlert",
+	"SyncMirrorPocket":                      "LegacySyncMirrorPocket",
+	"TerminateComputerProcesses":            "LegacyTerminateComputerProcesses",
+	"UnsubscribeFromAlert":                  "LegacyUnsubscribeFromAlert",
+	"UpgradePackages":                       "LegacyUpgradePackages",
+}
+
+// operations lists the v2 operations. {} stands for a path parameter.
+var operations = []operation{
+	{ID: "LoginWithPassword", Method: "POST", Path: "/api/login"},
+	{ID: "LoginWithAccessKey", Method: "POST", Path: "/api/login/access-key"},
+	{ID: "GetScriptProfileLimits", Method: "GET", Path: "/api/script-profile-limits"},
+	{ID: "ListScriptProfiles", Method: "GET", Path: "/api/script-profiles"},
+	{ID: "CreateScriptProfile", Method: "POST", Path: "/api/script-profiles"},
+	{ID: "GetScriptProfile", Method: "GET", Path: "/api/script-profiles/{}"},
+	{ID: "UpdateScriptProfile", Method: "PATCH", Path: "/api/script-profiles/{}"},
+	{ID: "ListScriptProfileActivities", Method: "GET", Path: "/api/script-profiles/{}/activities"},
+	{ID: "ListScriptProfileComputers", Method: "GET", Path: "/api/script-profiles/{}/computers"},
+	{ID: "ArchiveScriptProfile", Method: "POST", Path: "/api/script-profiles/{}:archive"},
+	{ID: "GetScript", Method: "GET", Path: "/api/scripts/{}"},
+	{ID: "GetScriptAttachment", Method: "GET", Path: "/api/scripts/{}/attachments/{}"},
+	{ID: "ListScriptProfilesByScript", Method: "GET", Path: "/api/scripts/{}/script-profiles"},
+	{ID: "ArchiveScript", Method: "POST", Path: "/api/scripts/{}:archive"},
+	{ID: "RedactScript", Method: "POST", Path: "/api/scripts/{}:redact"},
+}
@@ -0,0 +1,269 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName is the name of the tracer and meter used by WithOTel.
+const instrumentationName = "github.com/jansdhillon/landscape-go-api-client/client"
+
+const (
+	operationKey = attribute.Key("landscape.operation")
+	actionKey    = attribute.Key("landscape.action")
+)
+
+// operation is a v2 operation of the API, as listed in operations.gen.go.
+type operation struct {
+	ID     string
+	Method string
+	Path   string
+}
+
+// OTelOption configures WithOTel.
+type OTelOption func(*otelConfig)
+
+// WithPropagator sets the propagator that injects the trace context into
+// requests. It defaults to W3C Trace Context and Baggage.
+func WithPropagator(propagator propagation.TextMapPropagator) OTelOption {
+	return func(c *otelConfig) {
+		c.propagator = propagator
+	}
+}
+
+type otelConfig struct {
+	propagator propagation.TextMapPropagator
+}
+
+// WithOTel instruments the client with OpenTelemetry. Each request gets a
+// client span named after its operation ID, ex. LegacyGetComputers or
+// GetScriptProfile, and the trace context is injected into its headers. It
+// also records these metrics, by operation, method and status:
+//
+//   - landscape.client.requests: the number of requests.
+//   - landscape.client.request.errors: the number of requests that failed,
+//     either with a transport error or an HTTP status of 400 or more.
+//   - landscape.client.request.duration: the latency of requests, in seconds.
+//
+// A nil tracerProvider or meterProvider means the global one.
+//
+// This wraps the HttpRequestDoer configured by earlier options, so it must
+// come after WithHTTPClient. Put it after WithRetry so that each span covers
+// every attempt of its operation.
+func WithOTel(tracerProvider trace.TracerProvider, meterProvider metric.MeterProvider, opts ...OTelOption) ClientOption {
+	config := &otelConfig{
+		propagator: propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}),
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return func(c *Client) error {
+		if tracerProvider == nil {
+			tracerProvider = otel.GetTracerProvider()
+		}
+		if meterProvider == nil {
+			meterProvider = otel.GetMeterProvider()
+		}
+
+		server, err := url.Parse(c.Server)
+		if err != nil {
+			return err
+		}
+
+		meter := meterProvider.Meter(instrumentationName)
+		requests, err := meter.Int64Counter("landscape.client.requests",
+			metric.WithDescription("Number of requests sent to Landscape."),
+			metric.WithUnit("{request}"))
+		if err != nil {
+			return fmt.Errorf("failed to create requests counter: %w", err)
+		}
+		failures, err := meter.Int64Counter("landscape.client.request.errors",
+			metric.WithDescription("Number of requests to Landscape that failed."),
+			metric.WithUnit("{request}"))
+		if err != nil {
+			return fmt.Errorf("failed to create errors counter: %w", err)
+		}
+		duration, err := meter.Float64Histogram("landscape.client.request.duration",
+			metric.WithDescription("Latency of requests to Landscape."),
+			metric.WithUnit("s"))
+		if err != nil {
+			return fmt.Errorf("failed to create duration histogram: %w", err)
+		}
+
+		if c.Client == nil {
+			c.Client = &http.Client{}
+		}
+		c.Client = &otelDoer{
+			next:       c.Client,
+			basePath:   strings.TrimSuffix(server.Path, "/"),
+			tracer:     tracerProvider.Tracer(instrumentationName),
+			propagator: config.propagator,
+			requests:   requests,
+			failures:   failures,
+			duration:   duration,
+		}
+		return nil
+	}
+}
+
+type otelDoer struct {
+	next       HttpRequestDoer
+	basePath   string
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+	requests   metric.Int64Counter
+	failures   metric.Int64Counter
+	duration   metric.Float64Histogram
+}
+
+// Do implements HttpRequestDoer for otelDoer.
+func (d *otelDoer) Do(req *http.Request) (*http.Response, error) {
+	id, route := operationID(req, d.basePath)
+	spanName := id
+	if spanName == "" {
+		spanName = req.Method
+	}
+
+	attrs := []attribute.KeyValue{semconv.HTTPRequestMethodKey.String(req.Method)}
+	if id != "" {
+		attrs = append(attrs, operationKey.String(id))
+	}
+
+	spanAttrs := append([]attribute.KeyValue{semconv.URLFull(redactURL(req.URL))}, attrs...)
+	if route != "" {
+		spanAttrs = append(spanAttrs, semconv.HTTPRoute(route))
+	}
+	if action := legacyAction(req); action != "" {
+		spanAttrs = append(spanAttrs, actionKey.String(action))
+	}
+	if host, port, err := net.SplitHostPort(req.URL.Host); err == nil {
+		spanAttrs = append(spanAttrs, semconv.ServerAddress(host))
+		if n, err := strconv.Atoi(port); err == nil {
+			spanAttrs = append(spanAttrs, semconv.ServerPort(n))
+		}
+	} else {
+		spanAttrs = append(spanAttrs, semconv.ServerAddress(req.URL.Host))
+	}
+
+	ctx, span := d.tracer.Start(req.Context(), spanName,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(spanAttrs...))
+	defer span.End()
+
+	req = req.WithContext(ctx)
+	req.Header = req.Header.Clone()
+	if req.Header == nil {
+		req.Header = http.Header{}
+	}
+	d.propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	start := time.Now()
+	res, err := d.next.Do(req)
+	elapsed := time.Since(start).Seconds()
+
+	failed := false
+	switch {
+	case err != nil:
+		failed = true
+		attrs = append(attrs, semconv.ErrorTypeKey.String(fmt.Sprintf("%T", err)))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	case res.StatusCode >= http.StatusBadRequest:
+		failed = true
+		attrs = append(attrs,
+			semconv.HTTPResponseStatusCode(res.StatusCode),
+			semconv.ErrorTypeKey.String(strconv.Itoa(res.StatusCode)))
+		span.SetStatus(codes.Error, http.StatusText(res.StatusCode))
+	default:
+		attrs = append(attrs, semconv.HTTPResponseStatusCode(res.StatusCode))
+	}
+	if res != nil {
+		span.SetAttributes(semconv.HTTPResponseStatusCode(res.StatusCode))
+	}
+
+	set := metric.WithAttributeSet(attribute.NewSet(attrs...))
+	d.requests.Add(ctx, 1, set)
+	d.duration.Record(ctx, elapsed, set)
+	if failed {
+		d.failures.Add(ctx, 1, set)
+	}
+
+	return res, err
+}
+
+// operationID returns the operation ID of req and the path template it
+// matched, or "" if req isn't a known operation. basePath is the path of the
+// server URL, which the API paths are relative to.
+func operationID(req *http.Request, basePath string) (id, route string) {
+	if action := legacyAction(req); action != "" {
+		if id, ok := legacyOperations[action]; ok {
+			return id, "/api/"
+		}
+		return "", ""
+	}
+
+	path, ok := strings.CutPrefix(req.URL.Path, basePath)
+	if !ok {
+		return "", ""
+	}
+	segments := strings.Split(path, "/")
+
+	// Prefer the template with the most literal characters, so ex.
+	// /api/scripts/{}:archive wins over /api/scripts/{}.
+	best := -1
+	for _, op := range operations {
+		if op.Method != req.Method {
+			continue
+		}
+		if score, ok := matchPath(strings.Split(op.Path, "/"), segments); ok && score > best {
+			best = score
+			id, route = op.ID, op.Path
+		}
+	}
+
+	return id, route
+}
+
+// matchPath reports whether the segments of a path match those of a
+// template, and how many literal characters the template matched.
+func matchPath(template, segments []string) (int, bool) {
+	if len(template) != len(segments) {
+		return 0, false
+	}
+
+	score := 0
+	for i, t := range template {
+		before, after, isParam := strings.Cut(t, "{}")
+		if !isParam {
+			if t != segments[i] {
+				return 0, false
+			}
+			score += len(t)
+			continue
+		}
+
+		s := segments[i]
+		if len(s) <= len(before)+len(after) || !strings.HasPrefix(s, before) || !strings.HasSuffix(s, after) {
+			return 0, false
+		}
+		score += len(before) + len(after)
+	}
+
+	return score, true
+}
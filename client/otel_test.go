@@ -0,0 +1,161 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestWithOTel(t *testing.T) {
+	traceparents := make(chan string, 10)
+
+	handler := http.NewServeMux()
+	handler.HandleFunc("/landscape/api/", func(w http.ResponseWriter, r *http.Request) {
+		traceparents <- r.Header.Get("Traceparent")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("[]"))
+	})
+	handler.HandleFunc("/landscape/api/script-profiles/7", func(w http.ResponseWriter, r *http.Request) {
+		traceparents <- r.Header.Get("Traceparent")
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	server := httptest.NewTLSServer(handler)
+	defer server.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	reader := sdkmetric.NewManualReader()
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	api, err := NewClientWithResponses(server.URL+"/landscape", WithHTTPClient(server.Client()), WithOTel(tracerProvider, meterProvider))
+	if err != nil {
+		t.Fatalf("failed to init client: %v", err)
+	}
+
+	ctx, parent := tracerProvider.Tracer("test").Start(context.Background(), "parent")
+
+	if _, err := api.LegacyGetComputersWithResponse(ctx, &LegacyGetComputersParams{}); err != nil {
+		t.Fatalf("LegacyGetComputersWithResponse failed: %v", err)
+	}
+	if _, err := api.GetScriptProfileWithResponse(ctx, 7); err != nil {
+		t.Fatalf("GetScriptProfileWithResponse failed: %v", err)
+	}
+	parent.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 3 {
+		t.Fatalf("expected 3 spans, got %d", len(spans))
+	}
+
+	computers, profile := spans[0], spans[1]
+	if computers.Name != "LegacyGetComputers" || profile.Name != "GetScriptProfile" {
+		t.Fatalf("expected spans named after the operation IDs, got %q and %q", computers.Name, profile.Name)
+	}
+	for _, span := range []tracetest.SpanStub{computers, profile} {
+		if span.SpanKind != trace.SpanKindClient {
+			t.Fatalf("expected a client span, got %v", span.SpanKind)
+		}
+		if span.Parent.SpanID() != parent.SpanContext().SpanID() {
+			t.Fatalf("expected %s to be a child of the parent span", span.Name)
+		}
+	}
+	if !hasAttribute(computers.Attributes, attribute.String("landscape.action", "GetComputers")) {
+		t.Fatalf("expected the legacy action to be recorded, got %v", computers.Attributes)
+	}
+	if !hasAttribute(profile.Attributes, attribute.String("http.route", "/api/script-profiles/{}")) ||
+		!hasAttribute(profile.Attributes, attribute.Int("http.response.status_code", http.StatusNotFound)) {
+		t.Fatalf("unexpected attributes %v", profile.Attributes)
+	}
+	if computers.Status.Code == codes.Error || profile.Status.Code != codes.Error {
+		t.Fatalf("expected only the 404 to be an error, got %v and %v", computers.Status, profile.Status)
+	}
+
+	for _, span := range []tracetest.SpanStub{computers, profile} {
+		want := "00-" + span.SpanContext.TraceID().String() + "-" + span.SpanContext.SpanID().String() + "-01"
+		if got := <-traceparents; got != want {
+			t.Fatalf("expected traceparent %q, got %q", want, got)
+		}
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("failed to collect metrics: %v", err)
+	}
+
+	counts := map[string]map[string]int64{}
+	var histograms uint64
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, point := range data.DataPoints {
+					op, _ := point.Attributes.Value("landscape.operation")
+					if counts[m.Name] == nil {
+						counts[m.Name] = map[string]int64{}
+					}
+					counts[m.Name][op.AsString()] += point.Value
+				}
+			case metricdata.Histogram[float64]:
+				for _, point := range data.DataPoints {
+					histograms += point.Count
+				}
+			}
+		}
+	}
+
+	requests := counts["landscape.client.requests"]
+	if requests["LegacyGetComputers"] != 1 || requests["GetScriptProfile"] != 1 {
+		t.Fatalf("unexpected request counts %v", requests)
+	}
+	errors := counts["landscape.client.request.errors"]
+	if errors["LegacyGetComputers"] != 0 || errors["GetScriptProfile"] != 1 {
+		t.Fatalf("unexpected error counts %v", errors)
+	}
+	if histograms != 2 {
+		t.Fatalf("expected 2 latency measurements, got %d", histograms)
+	}
+}
+
+func TestOperationID(t *testing.T) {
+	tests := []struct {
+		method, url, id string
+	}{
+		{http.MethodGet, "https://landscape.example.com/api/?action=GetComputers&version=2011-08-01", "LegacyGetComputers"},
+		{http.MethodGet, "https://landscape.example.com/api/scripts/12", "GetScript"},
+		{http.MethodGet, "https://landscape.example.com/api/scripts/12/attachments/deploy.conf", "GetScriptAttachment"},
+		{http.MethodPost, "https://landscape.example.com/api/script-profiles/3:archive", "ArchiveScriptProfile"},
+		{http.MethodGet, "https://landscape.example.com/api/script-profiles/3/activities", "ListScriptProfileActivities"},
+		{http.MethodDelete, "https://landscape.example.com/api/scripts/12", ""},
+		{http.MethodGet, "https://landscape.example.com/api/?action=NoSuchAction", ""},
+		{http.MethodGet, "https://landscape.example.com/elsewhere", ""},
+	}
+
+	for _, tt := range tests {
+		req, err := http.NewRequest(tt.method, tt.url, nil)
+		if err != nil {
+			t.Fatalf("failed to create request: %v", err)
+		}
+		if id, _ := operationID(req, ""); id != tt.id {
+			t.Fatalf("expected %s %s to be %q, got %q", tt.method, tt.url, tt.id, id)
+		}
+	}
+}
+
+func hasAttribute(attrs []attribute.KeyValue, want attribute.KeyValue) bool {
+	for _, attr := range attrs {
+		if attr == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,227 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+)
+
+// DefaultPageSize is the page size used by Paginate when none is given.
+const DefaultPageSize = 100
+
+// PageFunc fetches up to limit items starting at offset. It returns the items
+// and the total number of items available, or -1 if the endpoint doesn't
+// report a total.
+type PageFunc[T any] func(ctx context.Context, offset, limit int) (items []T, total int, err error)
+
+// Paginate returns an iterator over every item returned by fetch, requesting
+// pageSize items at a time (DefaultPageSize if pageSize <= 0). Pages are only
+// fetched as the iterator is consumed, so breaking out of the loop stops
+// further requests.
+//
+// Iteration ends after a page shorter than pageSize, or once the reported
+// total has been reached. If fetching a page fails, the error is yielded
+// once and iteration ends.
+func Paginate[T any](ctx context.Context, pageSize int, fetch PageFunc[T]) iter.Seq2[T, error] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	return func(yield func(T, error) bool) {
+		var zero T
+		offset := 0
+
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			items, total, err := fetch(ctx, offset, pageSize)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			offset += len(items)
+			if len(items) == 0 || (total >= 0 && offset >= total) || (total < 0 && len(items) < pageSize) {
+				return
+			}
+		}
+	}
+}
+
+// CollectAll consumes seq and returns every item, or the first error.
+func CollectAll[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var all []T
+	for item, err := range seq {
+		if err != nil {
+			return all, err
+		}
+		all = append(all, item)
+	}
+	return all, nil
+}
+
+// GetComputersPages returns a PageFunc over the GetComputers legacy action.
+// The Offset and Limit fields of params are overridden for each page.
+func GetComputersPages(c *ClientWithResponses, params *LegacyGetComputersParams) PageFunc[Computer] {
+	return func(ctx context.Context, offset, limit int) ([]Computer, int, error) {
+		p := derefOrZero(params)
+		p.Offset, p.Limit = &offset, &limit
+
+		resp, err := c.LegacyGetComputersWithResponse(ctx, &p)
+		if err != nil {
+			return nil, 0, err
+		}
+		items, err := parseLegacyResult[[]Computer]("GetComputers", resp.HTTPResponse, resp.Body)
+		return items, -1, err
+	}
+}
+
+// GetActivitiesPages returns a PageFunc over the GetActivities legacy action.
+// The Offset and Limit fields of params are overridden for each page.
+func GetActivitiesPages(c *ClientWithResponses, params *LegacyGetActivitiesParams) PageFunc[Activity] {
+	return func(ctx context.Context, offset, limit int) ([]Activity, int, error) {
+		p := derefOrZero(params)
+		p.Offset, p.Limit = &offset, &limit
+
+		resp, err := c.LegacyGetActivitiesWithResponse(ctx, &p)
+		if err != nil {
+			return nil, 0, err
+		}
+		items, err := parseLegacyResult[[]Activity]("GetActivities", resp.HTTPResponse, resp.Body)
+		return items, -1, err
+	}
+}
+
+// GetPackagesPages returns a PageFunc over the GetPackages legacy action.
+// The Offset and Limit fields of params are overridden for each page.
+func GetPackagesPages(c *ClientWithResponses, params *LegacyGetPackagesParams) PageFunc[Package] {
+	return func(ctx context.Context, offset, limit int) ([]Package, int, error) {
+		p := derefOrZero(params)
+		p.Offset, p.Limit = &offset, &limit
+
+		resp, err := c.LegacyGetPackagesWithResponse(ctx, &p)
+		if err != nil {
+			return nil, 0, err
+		}
+		items, err := parseLegacyResult[[]Package]("GetPackages", resp.HTTPResponse, resp.Body)
+		return items, -1, err
+	}
+}
+
+// GetEventLogPages returns a PageFunc over the GetEventLog legacy action.
+// Entries are returned undecoded. The Offset and Limit fields of params are
+// overridden for each page.
+func GetEventLogPages(c *ClientWithResponses, params *LegacyGetEventLogParams) PageFunc[json.RawMessage] {
+	return func(ctx context.Context, offset, limit int) ([]json.RawMessage, int, error) {
+		p := derefOrZero(params)
+		p.Offset, p.Limit = &offset, &limit
+
+		resp, err := c.LegacyGetEventLogWithResponse(ctx, &p)
+		if err != nil {
+			return nil, 0, err
+		}
+		items, err := parseLegacyResult[[]json.RawMessage]("GetEventLog", resp.HTTPResponse, resp.Body)
+		return items, -1, err
+	}
+}
+
+// GetCSVComplianceDataPages returns a PageFunc over the GetCSVComplianceData
+// legacy action. Rows are returned undecoded. The Offset and Limit fields of
+// params are overridden for each page.
+func GetCSVComplianceDataPages(c *ClientWithResponses, params *LegacyGetCSVComplianceDataParams) PageFunc[json.RawMessage] {
+	return func(ctx context.Context, offset, limit int) ([]json.RawMessage, int, error) {
+		p := derefOrZero(params)
+		p.Offset, p.Limit = &offset, &limit
+
+		resp, err := c.LegacyGetCSVComplianceDataWithResponse(ctx, &p)
+		if err != nil {
+			return nil, 0, err
+		}
+		items, err := parseLegacyResult[[]json.RawMessage]("GetCSVComplianceData", resp.HTTPResponse, resp.Body)
+		return items, -1, err
+	}
+}
+
+// GetScriptsPages returns a PageFunc over the GetScripts legacy action. Use
+// AsV1Script or AsV2Script on each item, depending on its status. The Offset
+// and Limit fields of params are overridden for each page.
+func GetScriptsPages(c *ClientWithResponses, params *LegacyGetScriptsParams) PageFunc[ScriptResult] {
+	return func(ctx context.Context, offset, limit int) ([]ScriptResult, int, error) {
+		p := derefOrZero(params)
+		p.Offset, p.Limit = &offset, &limit
+
+		resp, err := c.LegacyGetScriptsWithResponse(ctx, &p)
+		if err != nil {
+			return nil, 0, err
+		}
+		items, err := parseLegacyResult[[]ScriptResult]("GetScripts", resp.HTTPResponse, resp.Body)
+		return items, -1, err
+	}
+}
+
+// ListScriptProfilesPages returns a PageFunc over the ListScriptProfiles
+// endpoint, passing offset and limit as query arguments.
+func ListScriptProfilesPages(c *ClientWithResponses, params *ListScriptProfilesParams) PageFunc[ScriptProfileDetail] {
+	return func(ctx context.Context, offset, limit int) ([]ScriptProfileDetail, int, error) {
+		resp, err := c.ListScriptProfilesWithResponse(ctx, params, pageQuery(offset, limit))
+		if err != nil {
+			return nil, 0, err
+		}
+		if err := CheckResponse(resp); err != nil {
+			return nil, 0, err
+		}
+		if resp.JSON200 == nil {
+			return nil, 0, fmt.Errorf("ListScriptProfiles failed with status: %d", resp.StatusCode())
+		}
+		return resp.JSON200.Results, resp.JSON200.Count, nil
+	}
+}
+
+// ListScriptProfileActivitiesPages returns a PageFunc over the activity runs
+// of a script profile, passing offset and limit as query arguments.
+func ListScriptProfileActivitiesPages(c *ClientWithResponses, scriptProfileID int) PageFunc[map[string]interface{}] {
+	return func(ctx context.Context, offset, limit int) ([]map[string]interface{}, int, error) {
+		resp, err := c.ListScriptProfileActivitiesWithResponse(ctx, scriptProfileID, pageQuery(offset, limit))
+		if err != nil {
+			return nil, 0, err
+		}
+		if err := CheckResponse(resp); err != nil {
+			return nil, 0, err
+		}
+		if resp.JSON200 == nil {
+			return nil, 0, fmt.Errorf("ListScriptProfileActivities failed with status: %d", resp.StatusCode())
+		}
+		return resp.JSON200.Results, resp.JSON200.Count, nil
+	}
+}
+
+// pageQuery adds offset and limit query arguments to endpoints whose
+// generated params don't include them.
+func pageQuery(offset, limit int) RequestEditorFn {
+	return EncodeQueryRequestEditor(url.Values{
+		"offset": {strconv.Itoa(offset)},
+		"limit":  {strconv.Itoa(limit)},
+	})
+}
+
+func derefOrZero[T any](v *T) T {
+	if v == nil {
+		var zero T
+		return zero
+	}
+	return *v
+}
@@ -0,0 +1,218 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+// newPagedServer serves total computers through GetComputers, total scripts
+// through GetScripts and total script profiles through ListScriptProfiles,
+// honouring offset and limit.
+func newPagedServer(t *testing.T, total int, requests *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	page := func(r *http.Request) (int, int) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		if err != nil {
+			limit = 1000
+		}
+		end := min(offset+limit, total)
+		return min(offset, total), end
+	}
+
+	handler := http.NewServeMux()
+	handler.HandleFunc("/api/", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		start, end := page(r)
+		var items []any
+		switch r.URL.Query().Get("action") {
+		case "GetComputers":
+			for id := start; id < end; id++ {
+				items = append(items, Computer{Id: id, Title: "computer-" + strconv.Itoa(id)})
+			}
+		case "GetScripts":
+			for id := start; id < end; id++ {
+				items = append(items, V1Script{Id: id, Title: "script-" + strconv.Itoa(id), Status: V1})
+			}
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if items == nil {
+			items = []any{}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(items)
+	})
+	handler.HandleFunc("/api/script-profiles", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		start, end := page(r)
+		profiles := []ScriptProfileDetail{}
+		for id := start; id < end; id++ {
+			profiles = append(profiles, ScriptProfileDetail{Id: id})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(ScriptProfileListResponse{Count: total, Results: profiles})
+	})
+
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestPaginate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("legacy offset/limit", func(t *testing.T) {
+		var requests atomic.Int32
+		server := newPagedServer(t, 25, &requests)
+		api, err := NewClientWithResponses(server.URL, WithHTTPClient(server.Client()))
+		if err != nil {
+			t.Fatalf("failed to init client with responses: %v", err)
+		}
+
+		computers, err := CollectAll(Paginate(ctx, 10, GetComputersPages(api, nil)))
+		if err != nil {
+			t.Fatalf("CollectAll failed: %v", err)
+		}
+
+		if len(computers) != 25 {
+			t.Fatalf("expected 25 computers, got %d", len(computers))
+		}
+		for i, c := range computers {
+			if c.Id != i {
+				t.Fatalf("expected computer %d at index %d, got %d", i, i, c.Id)
+			}
+		}
+		if requests.Load() != 3 {
+			t.Fatalf("expected 3 requests, got %d", requests.Load())
+		}
+	})
+
+	t.Run("legacy exact multiple of page size", func(t *testing.T) {
+		var requests atomic.Int32
+		server := newPagedServer(t, 20, &requests)
+		api, err := NewClientWithResponses(server.URL, WithHTTPClient(server.Client()))
+		if err != nil {
+			t.Fatalf("failed to init client with responses: %v", err)
+		}
+
+		computers, err := CollectAll(Paginate(ctx, 10, GetComputersPages(api, &LegacyGetComputersParams{})))
+		if err != nil {
+			t.Fatalf("CollectAll failed: %v", err)
+		}
+
+		if len(computers) != 20 || requests.Load() != 3 {
+			t.Fatalf("expected 20 computers in 3 requests, got %d in %d", len(computers), requests.Load())
+		}
+	})
+
+	t.Run("v2 count/results", func(t *testing.T) {
+		var requests atomic.Int32
+		server := newPagedServer(t, 20, &requests)
+		api, err := NewClientWithResponses(server.URL, WithHTTPClient(server.Client()))
+		if err != nil {
+			t.Fatalf("failed to init client with responses: %v", err)
+		}
+
+		profiles, err := CollectAll(Paginate(ctx, 10, ListScriptProfilesPages(api, nil)))
+		if err != nil {
+			t.Fatalf("CollectAll failed: %v", err)
+		}
+
+		if len(profiles) != 20 || requests.Load() != 2 {
+			t.Fatalf("expected 20 profiles in 2 requests, got %d in %d", len(profiles), requests.Load())
+		}
+	})
+
+	t.Run("scripts", func(t *testing.T) {
+		var requests atomic.Int32
+		server := newPagedServer(t, 15, &requests)
+		api, err := NewClientWithResponses(server.URL, WithHTTPClient(server.Client()))
+		if err != nil {
+			t.Fatalf("failed to init client with responses: %v", err)
+		}
+
+		scripts, err := CollectAll(Paginate(ctx, 10, GetScriptsPages(api, nil)))
+		if err != nil {
+			t.Fatalf("CollectAll failed: %v", err)
+		}
+		if len(scripts) != 15 || requests.Load() != 2 {
+			t.Fatalf("expected 15 scripts in 2 requests, got %d in %d", len(scripts), requests.Load())
+		}
+
+		script, err := scripts[14].AsV1Script()
+		if err != nil || script.Id != 14 || script.Title != "script-14" {
+			t.Fatalf("unexpected script %+v: %v", script, err)
+		}
+	})
+
+	t.Run("early termination", func(t *testing.T) {
+		var requests atomic.Int32
+		server := newPagedServer(t, 100, &requests)
+		api, err := NewClientWithResponses(server.URL, WithHTTPClient(server.Client()))
+		if err != nil {
+			t.Fatalf("failed to init client with responses: %v", err)
+		}
+
+		seen := 0
+		for c, err := range Paginate(ctx, 10, GetComputersPages(api, nil)) {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			seen++
+			if c.Id == 14 {
+				break
+			}
+		}
+
+		if seen != 15 || requests.Load() != 2 {
+			t.Fatalf("expected 15 computers in 2 requests, got %d in %d", seen, requests.Load())
+		}
+	})
+
+	t.Run("error is yielded", func(t *testing.T) {
+		failure := errors.New("boom")
+		calls := 0
+		fetch := func(ctx context.Context, offset, limit int) ([]int, int, error) {
+			calls++
+			if offset > 0 {
+				return nil, 0, failure
+			}
+			return []int{1, 2}, -1, nil
+		}
+
+		items, err := CollectAll(Paginate(ctx, 2, fetch))
+		if !errors.Is(err, failure) {
+			t.Fatalf("expected %v, got %v", failure, err)
+		}
+		if len(items) != 2 || calls != 2 {
+			t.Fatalf("expected 2 items from 2 calls, got %d from %d", len(items), calls)
+		}
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+
+		fetch := func(ctx context.Context, offset, limit int) ([]int, int, error) {
+			t.Fatal("fetch should not be called")
+			return nil, 0, nil
+		}
+
+		if _, err := CollectAll(Paginate(canceled, 0, fetch)); !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimitOption configures WithRateLimit.
+type RateLimitOption func(*rateLimits)
+
+// WithMaxConcurrency limits how many requests can be in flight at once. A
+// request counts as in flight until its response body is closed.
+func WithMaxConcurrency(n int) RateLimitOption {
+	return func(l *rateLimits) {
+		if n > 0 {
+			l.sem = make(chan struct{}, n)
+		}
+	}
+}
+
+// WithRateLimitWaitHook calls fn after each request has waited for its turn,
+// with how long it waited. Use it to report client-side throttling.
+func WithRateLimitWaitHook(fn func(req *http.Request, wait time.Duration)) RateLimitOption {
+	return func(l *rateLimits) {
+		l.onWait = fn
+	}
+}
+
+// WithRateLimit limits requests to rps per second, allowing bursts of up to
+// burst requests. A non-positive rps disables the rate limit, which is useful
+// together with WithMaxConcurrency on its own. Waiting stops early if the
+// request's context is canceled.
+//
+// The limits are shared by every request made through the client, including
+// from other goroutines. Since NewLandscapeAPIClient applies its options to
+// both its login client and the returned client, they share the rate limit
+// too. Logins don't take a WithMaxConcurrency slot though, so logging in
+// again never waits for the requests that need the new token.
+//
+// This wraps the HttpRequestDoer configured by earlier options, so it must
+// come after WithHTTPClient. Put it before WithRetry so that each retry
+// attempt is also limited.
+func WithRateLimit(rps float64, burst int, opts ...RateLimitOption) ClientOption {
+	limits := &rateLimits{}
+	if rps > 0 {
+		limits.limiter = rate.NewLimiter(rate.Limit(rps), max(burst, 1))
+	}
+	for _, opt := range opts {
+		opt(limits)
+	}
+
+	return func(c *Client) error {
+		if c.Client == nil {
+			c.Client = &http.Client{}
+		}
+		c.Client = &rateLimitDoer{next: c.Client, limits: limits}
+		return nil
+	}
+}
+
+// rateLimits is the state shared by every client built with the same
+// WithRateLimit option.
+type rateLimits struct {
+	limiter *rate.Limiter
+	sem     chan struct{}
+	onWait  func(req *http.Request, wait time.Duration)
+}
+
+type rateLimitDoer struct {
+	next   HttpRequestDoer
+	limits *rateLimits
+}
+
+// Do implements HttpRequestDoer for rateLimitDoer.
+func (d *rateLimitDoer) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	limits := d.limits
+	start := time.Now()
+
+	release := func() {}
+	if limits.sem != nil && !isLoginRequest(ctx) {
+		select {
+		case limits.sem <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		var once sync.Once
+		release = func() { once.Do(func() { <-limits.sem }) }
+	}
+
+	if limits.limiter != nil {
+		if err := limits.limiter.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	if limits.onWait != nil {
+		limits.onWait(req, time.Since(start))
+	}
+
+	res, err := d.next.Do(req)
+	if err != nil || res.Body == nil {
+		release()
+		return res, err
+	}
+
+	res.Body = &releaseOnClose{ReadCloser: res.Body, release: release}
+	return res, nil
+}
+
+// releaseOnClose frees a concurrency slot when the response body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releaseOnClose) Close() error {
+	defer b.release()
+	return b.ReadCloser.Close()
+}
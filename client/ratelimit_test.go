@@ -0,0 +1,180 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithRateLimit(t *testing.T) {
+	var inFlight, peak atomic.Int32
+
+	handler := http.NewServeMux()
+	handler.HandleFunc("/api/", func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("[]"))
+	})
+
+	server := httptest.NewTLSServer(handler)
+	defer server.Close()
+
+	ctx := context.Background()
+
+	t.Run("limits request rate", func(t *testing.T) {
+		var waited atomic.Int64
+		hook := func(req *http.Request, wait time.Duration) {
+			waited.Add(int64(wait))
+		}
+
+		api, err := NewClientWithResponses(server.URL, WithHTTPClient(server.Client()), WithRateLimit(50, 1, WithRateLimitWaitHook(hook)))
+		if err != nil {
+			t.Fatalf("failed to init client with responses: %v", err)
+		}
+
+		start := time.Now()
+		for range 6 {
+			if _, err := api.GetComputersTyped(ctx, &LegacyGetComputersParams{}); err != nil {
+				t.Fatalf("GetComputersTyped failed: %v", err)
+			}
+		}
+
+		// The first request is free, the other 5 wait for 20ms each.
+		if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+			t.Fatalf("expected requests to be rate limited, took %v", elapsed)
+		}
+		if time.Duration(waited.Load()) < 20*time.Millisecond {
+			t.Fatalf("expected wait hook to report throttling, got %v", time.Duration(waited.Load()))
+		}
+	})
+
+	t.Run("caps concurrency across goroutines", func(t *testing.T) {
+		peak.Store(0)
+		api, err := NewClientWithResponses(server.URL, WithHTTPClient(server.Client()), WithRateLimit(0, 0, WithMaxConcurrency(2)))
+		if err != nil {
+			t.Fatalf("failed to init client with responses: %v", err)
+		}
+
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := api.GetComputersTyped(ctx, &LegacyGetComputersParams{}); err != nil {
+					t.Errorf("GetComputersTyped failed: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if peak.Load() > 2 {
+			t.Fatalf("expected at most 2 requests in flight, got %d", peak.Load())
+		}
+	})
+
+	t.Run("shared between clients built from one option", func(t *testing.T) {
+		limit := WithRateLimit(0, 0, WithMaxConcurrency(1))
+
+		a, err := NewClientWithResponses(server.URL, WithHTTPClient(server.Client()), limit)
+		if err != nil {
+			t.Fatalf("failed to init client with responses: %v", err)
+		}
+		b, err := NewClientWithResponses(server.URL, WithHTTPClient(server.Client()), limit)
+		if err != nil {
+			t.Fatalf("failed to init client with responses: %v", err)
+		}
+
+		// Hold the only slot by leaving a response body open.
+		res, err := a.LegacyGetComputers(ctx, &LegacyGetComputersParams{})
+		if err != nil {
+			t.Fatalf("LegacyGetComputers failed: %v", err)
+		}
+
+		timeout, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		if _, err := b.LegacyGetComputers(timeout, &LegacyGetComputersParams{}); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected second client to wait for the shared slot, got %v", err)
+		}
+
+		_ = res.Body.Close()
+		res, err = b.LegacyGetComputers(ctx, &LegacyGetComputersParams{})
+		if err != nil {
+			t.Fatalf("LegacyGetComputers failed after the slot was released: %v", err)
+		}
+		_ = res.Body.Close()
+	})
+
+	t.Run("logging in again doesn't wait for a slot", func(t *testing.T) {
+		var logins atomic.Int32
+		handler := http.NewServeMux()
+		handler.HandleFunc("/api/login/access-key", func(w http.ResponseWriter, r *http.Request) {
+			n := logins.Add(1)
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(LoginResponse{Email: "jan@example.com", Token: fmt.Sprintf("token-%d", n)})
+		})
+		handler.HandleFunc("/api/scripts/1", func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer token-2" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(V1Script{Id: 1, Title: "diagnostic script"})
+		})
+		authServer := httptest.NewTLSServer(handler)
+		defer authServer.Close()
+
+		provider := &AccessKeyProvider{AccessKey: "key", SecretKey: "secret"}
+		limit := WithRateLimit(0, 0, WithMaxConcurrency(1))
+
+		// Hold the only slot by leaving a response body open, then log in
+		// through another client sharing it.
+		holder, err := NewClientWithResponses(authServer.URL, WithHTTPClient(authServer.Client()), limit)
+		if err != nil {
+			t.Fatalf("failed to init client with responses: %v", err)
+		}
+		res, err := holder.GetScript(ctx, 1)
+		if err != nil {
+			t.Fatalf("GetScript failed: %v", err)
+		}
+		loginClient, err := NewClientWithResponses(authServer.URL, WithHTTPClient(authServer.Client()), limit)
+		if err != nil {
+			t.Fatalf("failed to init client with responses: %v", err)
+		}
+		timeout, cancel := context.WithTimeout(ctx, 2*time.Second)
+		defer cancel()
+		if _, err := NewTokenSource(provider, loginClient).Token(timeout); err != nil {
+			t.Fatalf("expected login not to wait for the held slot, got %v", err)
+		}
+		_ = res.Body.Close()
+		logins.Store(0)
+
+		api, err := NewLandscapeAPIClient(authServer.URL, provider, WithHTTPClient(authServer.Client()), limit)
+		if err != nil {
+			t.Fatalf("failed to init client: %v", err)
+		}
+
+		resp, err := api.GetScriptWithResponse(timeout, 1)
+		if err != nil {
+			t.Fatalf("GetScriptWithResponse failed: %v", err)
+		}
+		if resp.StatusCode() != http.StatusOK || logins.Load() != 2 {
+			t.Fatalf("expected 200 after logging in again, got %d after %d logins", resp.StatusCode(), logins.Load())
+		}
+	})
+}
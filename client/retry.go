@@ -0,0 +1,204 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy configures WithRetry.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	// Values below 1 are treated as 1.
+	MaxAttempts int
+
+	// MinBackoff is the base delay before the first retry. Each later retry
+	// doubles it, up to MaxBackoff, and a random jitter is applied.
+	MinBackoff time.Duration
+
+	// MaxBackoff caps the computed backoff. Values below 1 use 30s.
+	MaxBackoff time.Duration
+
+	// MaxRetryAfter caps delays requested by the server with Retry-After, so
+	// a misbehaving server can't stall a request indefinitely. Values below 1
+	// use MaxBackoff.
+	MaxRetryAfter time.Duration
+
+	// StatusCodes are the response statuses that are retried.
+	StatusCodes []int
+
+	// Actions opts non-idempotent requests in to being retried. Entries are
+	// legacy action names, ex. "ExecuteScript", or a method and path for v2
+	// endpoints, ex. "POST /api/scripts".
+	Actions []string
+}
+
+// defaultMaxBackoff is the MaxBackoff of DefaultRetryPolicy, and the one used
+// when a policy doesn't set it.
+const defaultMaxBackoff = 30 * time.Second
+
+// DefaultRetryPolicy returns a RetryPolicy that makes up to 4 attempts,
+// backing off from 500ms to 30s, on 429, 502 and 503 responses.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  defaultMaxBackoff,
+		StatusCodes: []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable},
+	}
+}
+
+// WithRetry retries requests that fail with one of policy.StatusCodes or a
+// transport error, waiting with jittered exponential backoff between attempts
+// and honoring the Retry-After header, up to policy.MaxRetryAfter. Waiting
+// stops early if the request's context is canceled.
+//
+// Only idempotent requests are retried: legacy Get* actions and v2 GET
+// requests, plus anything listed in policy.Actions. Request bodies are
+// replayed on each attempt.
+//
+// This wraps the HttpRequestDoer configured by earlier options, so it must
+// come after WithHTTPClient.
+func WithRetry(policy RetryPolicy) ClientOption {
+	return func(c *Client) error {
+		if c.Client == nil {
+			c.Client = &http.Client{}
+		}
+		c.Client = &retryDoer{next: c.Client, policy: policy}
+		return nil
+	}
+}
+
+type retryDoer struct {
+	next   HttpRequestDoer
+	policy RetryPolicy
+}
+
+// Do implements HttpRequestDoer for retryDoer.
+func (d *retryDoer) Do(req *http.Request) (*http.Response, error) {
+	if d.policy.MaxAttempts <= 1 || !d.retryable(req) {
+		return d.next.Do(req)
+	}
+
+	ctx := req.Context()
+	next := req
+
+	for attempt := 1; ; attempt++ {
+		retry, canRetry := rewindRequest(next)
+
+		res, err := d.next.Do(next)
+		if attempt >= d.policy.MaxAttempts || !canRetry || !d.shouldRetry(ctx, res, err) {
+			return res, err
+		}
+
+		wait := d.backoff(attempt)
+		if res != nil {
+			if after, ok := retryAfter(res.Header.Get("Retry-After")); ok {
+				wait = d.capRetryAfter(after)
+			}
+			drainBody(res)
+		}
+
+		if err := sleepContext(ctx, wait); err != nil {
+			return nil, err
+		}
+
+		next = retry
+	}
+}
+
+// retryable reports whether req is idempotent or has been opted in.
+func (d *retryDoer) retryable(req *http.Request) bool {
+	if action := legacyAction(req); action != "" {
+		return strings.HasPrefix(action, "Get") || slices.Contains(d.policy.Actions, action)
+	}
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return true
+	}
+	return slices.Contains(d.policy.Actions, req.Method+" "+req.URL.Path)
+}
+
+func (d *retryDoer) shouldRetry(ctx context.Context, res *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil
+	}
+	return slices.Contains(d.policy.StatusCodes, res.StatusCode)
+}
+
+// backoff returns the delay before the given retry, between half and all of
+// MinBackoff*2^(attempt-1), capped at MaxBackoff.
+func (d *retryDoer) backoff(attempt int) time.Duration {
+	limit := d.maxBackoff()
+	wait := d.policy.MinBackoff
+	for i := 1; i < attempt && wait < limit; i++ {
+		wait *= 2
+	}
+	wait = min(wait, limit)
+	if wait <= 0 {
+		return 0
+	}
+	return wait/2 + rand.N(wait/2+1)
+}
+
+// capRetryAfter caps a delay requested with Retry-After at MaxRetryAfter, or
+// at MaxBackoff if that isn't set.
+func (d *retryDoer) capRetryAfter(wait time.Duration) time.Duration {
+	limit := d.policy.MaxRetryAfter
+	if limit <= 0 {
+		limit = d.maxBackoff()
+	}
+	return min(wait, limit)
+}
+
+// maxBackoff returns MaxBackoff, or defaultMaxBackoff if it isn't set.
+func (d *retryDoer) maxBackoff() time.Duration {
+	if d.policy.MaxBackoff <= 0 {
+		return defaultMaxBackoff
+	}
+	return d.policy.MaxBackoff
+}
+
+// legacyAction returns the action of a legacy API request, or "" if req is
+// not one.
+func legacyAction(req *http.Request) string {
+	if !strings.HasSuffix(req.URL.Path, "/api/") {
+		return ""
+	}
+	return req.URL.Query().Get("action")
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
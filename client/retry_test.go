@@ -0,0 +1,276 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testRetryPolicy() RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.MinBackoff = time.Millisecond
+	policy.MaxBackoff = 5 * time.Millisecond
+	return policy
+}
+
+func TestWithRetry(t *testing.T) {
+	var scripts, throttled, computers, executes, creates atomic.Int32
+
+	handler := http.NewServeMux()
+	handler.HandleFunc("/api/scripts/1", func(w http.ResponseWriter, r *http.Request) {
+		if scripts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(V1Script{Id: 1, Title: "diagnostic script"})
+	})
+	handler.HandleFunc("/api/scripts/2", func(w http.ResponseWriter, r *http.Request) {
+		if throttled.Add(1) < 2 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(V1Script{Id: 2, Title: "throttled script"})
+	})
+	handler.HandleFunc("/api/", func(w http.ResponseWriter, r *http.Request) {
+		var n int32
+		switch r.URL.Query().Get("action") {
+		case "GetComputers":
+			n = computers.Add(1)
+		case "ExecuteScript":
+			n = executes.Add(1)
+		}
+		if n < 2 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("[]"))
+	})
+	handler.HandleFunc("/api/script-profiles", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req ScriptProfileCreateBody
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Errorf("request body was not replayed: %q", body)
+		}
+		if creates.Add(1) < 2 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(ScriptProfileDetail{Id: 7, Title: req.Title})
+	})
+
+	server := httptest.NewTLSServer(handler)
+	defer server.Close()
+
+	ctx := context.Background()
+
+	reset := func() {
+		scripts.Store(0)
+		throttled.Store(0)
+		computers.Store(0)
+		executes.Store(0)
+		creates.Store(0)
+	}
+
+	t.Run("retries v2 GET", func(t *testing.T) {
+		reset()
+		api, err := NewClientWithResponses(server.URL, WithHTTPClient(server.Client()), WithRetry(testRetryPolicy()))
+		if err != nil {
+			t.Fatalf("failed to init client with responses: %v", err)
+		}
+
+		resp, err := api.GetScriptWithResponse(ctx, 1)
+		if err != nil {
+			t.Fatalf("GetScriptWithResponse failed: %v", err)
+		}
+		if resp.StatusCode() != http.StatusOK || scripts.Load() != 3 {
+			t.Fatalf("expected HTTP 200 after 3 attempts, got %d after %d", resp.StatusCode(), scripts.Load())
+		}
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		reset()
+		policy := testRetryPolicy()
+		policy.MaxAttempts = 2
+		api, err := NewClientWithResponses(server.URL, WithHTTPClient(server.Client()), WithRetry(policy))
+		if err != nil {
+			t.Fatalf("failed to init client with responses: %v", err)
+		}
+
+		resp, err := api.GetScriptWithResponse(ctx, 1)
+		if err != nil {
+			t.Fatalf("GetScriptWithResponse failed: %v", err)
+		}
+		if resp.StatusCode() != http.StatusServiceUnavailable || scripts.Load() != 2 {
+			t.Fatalf("expected HTTP 503 after 2 attempts, got %d after %d", resp.StatusCode(), scripts.Load())
+		}
+	})
+
+	t.Run("caps Retry-After", func(t *testing.T) {
+		reset()
+		api, err := NewClientWithResponses(server.URL, WithHTTPClient(server.Client()), WithRetry(testRetryPolicy()))
+		if err != nil {
+			t.Fatalf("failed to init client with responses: %v", err)
+		}
+
+		start := time.Now()
+		resp, err := api.GetScriptWithResponse(ctx, 2)
+		if err != nil {
+			t.Fatalf("GetScriptWithResponse failed: %v", err)
+		}
+		if resp.StatusCode() != http.StatusOK || throttled.Load() != 2 {
+			t.Fatalf("expected HTTP 200 after 2 attempts, got %d after %d", resp.StatusCode(), throttled.Load())
+		}
+		if time.Since(start) > 5*time.Second {
+			t.Fatal("retry waited for the full Retry-After instead of MaxBackoff")
+		}
+	})
+
+	t.Run("retries legacy Get actions only", func(t *testing.T) {
+		reset()
+		api, err := NewClientWithResponses(server.URL, WithHTTPClient(server.Client()), WithRetry(testRetryPolicy()))
+		if err != nil {
+			t.Fatalf("failed to init client with responses: %v", err)
+		}
+
+		if _, err := api.GetComputersTyped(ctx, &LegacyGetComputersParams{}); err != nil {
+			t.Fatalf("GetComputersTyped failed: %v", err)
+		}
+		if computers.Load() != 2 {
+			t.Fatalf("expected 2 attempts, got %d", computers.Load())
+		}
+
+		resp, err := api.LegacyExecuteScriptWithResponse(ctx, &LegacyExecuteScriptParams{Query: "tag:web", ScriptId: 1})
+		if err != nil {
+			t.Fatalf("LegacyExecuteScriptWithResponse failed: %v", err)
+		}
+		if resp.StatusCode() != http.StatusTooManyRequests || executes.Load() != 1 {
+			t.Fatalf("expected mutating action not to be retried, got %d after %d attempts", resp.StatusCode(), executes.Load())
+		}
+	})
+
+	t.Run("opted in actions are retried with their body", func(t *testing.T) {
+		reset()
+		policy := testRetryPolicy()
+		policy.Actions = []string{"ExecuteScript", "POST /api/script-profiles"}
+		api, err := NewClientWithResponses(server.URL, WithHTTPClient(server.Client()), WithRetry(policy))
+		if err != nil {
+			t.Fatalf("failed to init client with responses: %v", err)
+		}
+
+		if _, err := api.LegacyExecuteScriptWithResponse(ctx, &LegacyExecuteScriptParams{Query: "tag:web", ScriptId: 1}); err != nil {
+			t.Fatalf("LegacyExecuteScriptWithResponse failed: %v", err)
+		}
+		if executes.Load() != 2 {
+			t.Fatalf("expected 2 attempts, got %d", executes.Load())
+		}
+
+		resp, err := api.CreateScriptProfileWithResponse(ctx, ScriptProfileCreateBody{ScriptId: 1, Title: "nightly"})
+		if err != nil {
+			t.Fatalf("CreateScriptProfileWithResponse failed: %v", err)
+		}
+		if resp.JSON201 == nil || resp.JSON201.Title != "nightly" || creates.Load() != 2 {
+			t.Fatalf("unexpected response after %d attempts: %s", creates.Load(), resp.Body)
+		}
+	})
+
+	t.Run("context cancellation stops waiting", func(t *testing.T) {
+		reset()
+		policy := testRetryPolicy()
+		policy.MinBackoff = time.Hour
+		policy.MaxBackoff = time.Hour
+		api, err := NewClientWithResponses(server.URL, WithHTTPClient(server.Client()), WithRetry(policy))
+		if err != nil {
+			t.Fatalf("failed to init client with responses: %v", err)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, err = api.GetScriptWithResponse(ctx, 1)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+		}
+		if time.Since(start) > 5*time.Second {
+			t.Fatal("retry did not stop when the context was done")
+		}
+	})
+}
+
+func TestRetryAfter(t *testing.T) {
+	if d, ok := retryAfter("3"); !ok || d != 3*time.Second {
+		t.Fatalf("expected 3s, got %v %v", d, ok)
+	}
+
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if d, ok := retryAfter(date); !ok || d <= 0 || d > time.Minute {
+		t.Fatalf("expected up to 1m, got %v %v", d, ok)
+	}
+
+	for _, value := range []string{"", "soon", "-1"} {
+		if _, ok := retryAfter(value); ok {
+			t.Fatalf("expected %q to be rejected", value)
+		}
+	}
+}
+
+func TestRetryAfterCap(t *testing.T) {
+	for _, tt := range []struct {
+		name                      string
+		maxBackoff, maxRetryAfter time.Duration
+		after, want               time.Duration
+	}{
+		{name: "below the cap", maxRetryAfter: time.Minute, after: 3 * time.Second, want: 3 * time.Second},
+		{name: "above the cap", maxRetryAfter: time.Minute, after: time.Hour, want: time.Minute},
+		{name: "max backoff without a cap", maxBackoff: 30 * time.Second, after: time.Hour, want: 30 * time.Second},
+		{name: "cap over max backoff", maxBackoff: 30 * time.Second, maxRetryAfter: time.Minute, after: time.Hour, want: time.Minute},
+		{name: "no limits", after: time.Hour, want: defaultMaxBackoff},
+	} {
+		d := &retryDoer{policy: RetryPolicy{MaxBackoff: tt.maxBackoff, MaxRetryAfter: tt.maxRetryAfter}}
+		if got := d.capRetryAfter(tt.after); got != tt.want {
+			t.Fatalf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		policy RetryPolicy
+		want   map[int]time.Duration
+	}{
+		{
+			name:   "max backoff",
+			policy: RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second},
+			want:   map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 400 * time.Millisecond, 10: time.Second},
+		},
+		{
+			name:   "default max backoff",
+			policy: RetryPolicy{MinBackoff: 100 * time.Millisecond},
+			want:   map[int]time.Duration{1: 100 * time.Millisecond, 10: defaultMaxBackoff, 100: defaultMaxBackoff},
+		},
+	} {
+		d := &retryDoer{policy: tt.policy}
+		for attempt, want := range tt.want {
+			for range 20 {
+				got := d.backoff(attempt)
+				if got < want/2 || got > want {
+					t.Fatalf("%s: attempt %d: expected backoff in [%v, %v], got %v", tt.name, attempt, want/2, want, got)
+				}
+			}
+		}
+	}
+}
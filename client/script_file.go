@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Landscape's limits on script attachments.
+const (
+	// MaxScriptAttachments is the number of files a script can have
+	// attached.
+	MaxScriptAttachments = 5
+
+	// MaxScriptAttachmentsSize is the combined size in bytes of the files
+	// attached to a script.
+	MaxScriptAttachmentsSize = 1 << 20
+)
+
+// ErrScriptAttachmentLimit is returned by CheckScriptAttachments when
+// attachments exceed one of Landscape's limits.
+var ErrScriptAttachmentLimit = errors.New("script attachment limit exceeded")
+
+// ScriptAttachmentFile is a file to attach to a script.
+type ScriptAttachmentFile struct {
+	Filename string
+	Content  []byte
+}
+
+// Param returns the file parameter of LegacyCreateScriptAttachment for f, in
+// the <filename>$$<base64 encoded contents> format Landscape expects.
+func (f ScriptAttachmentFile) Param() string {
+	return f.Filename + "$$" + base64.StdEncoding.EncodeToString(f.Content)
+}
+
+// CheckScriptAttachments returns an error if files can't be attached to a
+// script, because of their names or because they exceed Landscape's limits
+// on the number and size of attachments.
+func CheckScriptAttachments(files []ScriptAttachmentFile) error {
+	if len(files) > MaxScriptAttachments {
+		return fmt.Errorf("%w: %d files given, a script can have at most %d attachments", ErrScriptAttachmentLimit, len(files), MaxScriptAttachments)
+	}
+
+	seen := map[string]bool{}
+	total := 0
+	for _, f := range files {
+		switch {
+		case f.Filename == "":
+			return errors.New("attachment file name must not be empty")
+		case strings.Contains(f.Filename, "$$"):
+			return fmt.Errorf("attachment file name %q must not contain $$", f.Filename)
+		case strings.ContainsAny(f.Filename, `/\`):
+			return fmt.Errorf("attachment file name %q must not contain a path", f.Filename)
+		case seen[f.Filename]:
+			return fmt.Errorf("attachment file name %q is used more than once", f.Filename)
+		}
+		seen[f.Filename] = true
+		total += len(f.Content)
+	}
+
+	if total > MaxScriptAttachmentsSize {
+		return fmt.Errorf("%w: attachments are %d bytes in total, more than the maximum of %d bytes", ErrScriptAttachmentLimit, total, MaxScriptAttachmentsSize)
+	}
+	return nil
+}
+
+// ScriptInterpreter returns the interpreter named by the shebang line that
+// code must start with, ex. "/bin/bash" for "#!/bin/bash -e".
+func ScriptInterpreter(code []byte) (string, error) {
+	line, _, _ := bytes.Cut(code, []byte("\n"))
+
+	rest, ok := bytes.CutPrefix(line, []byte("#!"))
+	if !ok {
+		return "", errors.New("script must start with a shebang line naming its interpreter, ex. #!/bin/bash")
+	}
+	if bytes.HasSuffix(rest, []byte("\r")) {
+		return "", errors.New("script has Windows line endings, which would make the interpreter in its shebang line not be found")
+	}
+
+	fields := strings.Fields(string(rest))
+	if len(fields) == 0 {
+		return "", errors.New("shebang line doesn't name an interpreter")
+	}
+	if !strings.HasPrefix(fields[0], "/") {
+		return "", fmt.Errorf("interpreter %q in shebang line must be an absolute path", fields[0])
+	}
+	return fields[0], nil
+}
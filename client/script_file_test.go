@@ -0,0 +1,84 @@
+package client
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestScriptInterpreter(t *testing.T) {
+	tests := []struct {
+		code, want, err string
+	}{
+		{code: "#!/bin/bash\necho hi\n", want: "/bin/bash"},
+		{code: "#! /usr/bin/env python3\nprint('hi')\n", want: "/usr/bin/env"},
+		{code: "#!/bin/sh -e", want: "/bin/sh"},
+		{code: "echo hi\n", err: "must start with a shebang"},
+		{code: "", err: "must start with a shebang"},
+		{code: "#!/bin/bash\r\necho hi\r\n", err: "Windows line endings"},
+		{code: "#!\necho hi\n", err: "doesn't name an interpreter"},
+		{code: "#!bash\n", err: "absolute path"},
+	}
+
+	for _, tt := range tests {
+		got, err := ScriptInterpreter([]byte(tt.code))
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("expected %q to fail with %q, got %v", tt.code, tt.err, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Fatalf("expected %q to be run by %q, got %q: %v", tt.code, tt.want, got, err)
+		}
+	}
+}
+
+func TestCheckScriptAttachments(t *testing.T) {
+	file := func(name string, size int) ScriptAttachmentFile {
+		return ScriptAttachmentFile{Filename: name, Content: bytes.Repeat([]byte("x"), size)}
+	}
+
+	tests := []struct {
+		name  string
+		files []ScriptAttachmentFile
+		limit bool
+		err   string
+	}{
+		{name: "none"},
+		{name: "at the limits", files: []ScriptAttachmentFile{
+			file("a", MaxScriptAttachmentsSize-4), file("b", 1), file("c", 1), file("d", 1), file("e", 1),
+		}},
+		{name: "too many", files: []ScriptAttachmentFile{
+			file("a", 1), file("b", 1), file("c", 1), file("d", 1), file("e", 1), file("f", 1),
+		}, limit: true},
+		{name: "too large", files: []ScriptAttachmentFile{file("a", MaxScriptAttachmentsSize), file("b", 1)}, limit: true},
+		{name: "duplicate", files: []ScriptAttachmentFile{file("a", 1), file("a", 1)}, err: "more than once"},
+		{name: "path", files: []ScriptAttachmentFile{file("configs/a.conf", 1)}, err: "must not contain a path"},
+		{name: "separator", files: []ScriptAttachmentFile{file("a$$b", 1)}, err: "must not contain $$"},
+	}
+
+	for _, tt := range tests {
+		err := CheckScriptAttachments(tt.files)
+		switch {
+		case tt.limit:
+			if !errors.Is(err, ErrScriptAttachmentLimit) {
+				t.Fatalf("%s: expected ErrScriptAttachmentLimit, got %v", tt.name, err)
+			}
+		case tt.err != "":
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("%s: expected an error containing %q, got %v", tt.name, tt.err, err)
+			}
+		case err != nil:
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+	}
+}
+
+func TestScriptAttachmentFileParam(t *testing.T) {
+	f := ScriptAttachmentFile{Filename: "app.conf", Content: []byte("port=80\n")}
+	if got, want := f.Param(), "app.conf$$cG9ydD04MAo="; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,184 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrScriptProfileLimit is returned by ScriptProfileLimits checks when a
+// script profile would exceed one of the account's limits.
+var ErrScriptProfileLimit = errors.New("script profile limit exceeded")
+
+// CheckInterval returns an error if a recurring trigger with the cron
+// expression would run more often than every MinInterval minutes, or if the
+// expression is invalid.
+func (l ScriptProfileLimits) CheckInterval(cron string) error {
+	interval, err := CronInterval(cron)
+	if err != nil {
+		return err
+	}
+
+	if l.MinInterval > 0 && interval < time.Duration(l.MinInterval)*time.Minute {
+		return fmt.Errorf("%w: %q runs every %s, more often than the minimum interval of %d minutes", ErrScriptProfileLimit, cron, interval, l.MinInterval)
+	}
+	return nil
+}
+
+// CheckComputers returns an error if a script profile targeting n computers
+// would target more than MaxNumComputers.
+func (l ScriptProfileLimits) CheckComputers(n int) error {
+	if l.MaxNumComputers > 0 && n > l.MaxNumComputers {
+		return fmt.Errorf("%w: script profile targets %d computers, more than the maximum of %d", ErrScriptProfileLimit, n, l.MaxNumComputers)
+	}
+	return nil
+}
+
+// cronField describes a field of a cron expression.
+type cronField struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var (
+	cronMinute  = cronField{name: "minute", min: 0, max: 59}
+	cronHour    = cronField{name: "hour", min: 0, max: 23}
+	cronDay     = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth   = cronField{name: "month", min: 1, max: 12, names: []string{"", "jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}}
+	cronWeekday = cronField{name: "day of week", min: 0, max: 7, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}}
+)
+
+// CronInterval returns the shortest time between two runs of a five-field
+// cron expression (minute, hour, day of month, month and day of week), ex.
+// 24 hours for "0 3 * * *" and 15 minutes for "*/15 * * * *".
+func CronInterval(expr string) (time.Duration, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return 0, fmt.Errorf("cron expression %q must have five fields: minute, hour, day of month, month and day of week", expr)
+	}
+
+	var sets [5][]bool
+	for i, f := range []cronField{cronMinute, cronHour, cronDay, cronMonth, cronWeekday} {
+		set, err := f.parse(fields[i])
+		if err != nil {
+			return 0, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+		}
+		sets[i] = set
+	}
+	minutes, hours, days, months, weekdays := sets[0], sets[1], sets[2], sets[3], sets[4]
+	weekdays[0] = weekdays[0] || weekdays[7]
+
+	// Like cron, run on days matching either the day of month or the day of
+	// week if both are restricted, and on days matching both otherwise.
+	either := !strings.HasPrefix(fields[2], "*") && !strings.HasPrefix(fields[4], "*")
+
+	// The fewest days between two days the expression runs on. Days of the
+	// week line up with dates again after 28 years.
+	dayGap := 0
+	start := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	last := -1
+	for i := 0; i < 28*366 && dayGap != 1; i++ {
+		d := start.AddDate(0, 0, i)
+		day, weekday := days[d.Day()], weekdays[d.Weekday()]
+		if !months[d.Month()] || (either && !day && !weekday) || (!either && (!day || !weekday)) {
+			continue
+		}
+		if last >= 0 && (dayGap == 0 || i-last < dayGap) {
+			dayGap = i - last
+		}
+		last = i
+	}
+	if last < 0 {
+		return 0, fmt.Errorf("cron expression %q never runs", expr)
+	}
+
+	var times []int
+	for h, ok := range hours {
+		for m, ok2 := range minutes {
+			if ok && ok2 {
+				times = append(times, h*60+m)
+			}
+		}
+	}
+
+	shortest := 0
+	for i := 1; i < len(times); i++ {
+		if gap := times[i] - times[i-1]; shortest == 0 || gap < shortest {
+			shortest = gap
+		}
+	}
+	if dayGap > 0 {
+		if gap := dayGap*24*60 - times[len(times)-1] + times[0]; shortest == 0 || gap < shortest {
+			shortest = gap
+		}
+	}
+	if shortest == 0 {
+		// It only runs once, ex. on February 29th of a year starting on a
+		// particular day of the week, so there's no interval to speak of.
+		shortest = 28 * 365 * 24 * 60
+	}
+
+	return time.Duration(shortest) * time.Minute, nil
+}
+
+// parse returns the values matching a cron field, a comma separated list of
+// *, values and ranges, each optionally with a /step.
+func (f cronField) parse(field string) ([]bool, error) {
+	set := make([]bool, f.max+1)
+	for _, item := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(item, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepStr); err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step %q in %s field %q", stepStr, f.name, field)
+			}
+		}
+
+		lo, hi := f.min, f.max
+		if rng != "*" {
+			loStr, hiStr, isRange := strings.Cut(rng, "-")
+			var err error
+			if lo, err = f.value(loStr); err != nil {
+				return nil, fmt.Errorf("%w in %s field %q", err, f.name, field)
+			}
+			hi = lo
+			if isRange {
+				if hi, err = f.value(hiStr); err != nil {
+					return nil, fmt.Errorf("%w in %s field %q", err, f.name, field)
+				}
+				if hi < lo {
+					return nil, fmt.Errorf("invalid range %q in %s field %q", rng, f.name, field)
+				}
+			} else if hasStep {
+				hi = f.max
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			set[v] = true
+		}
+	}
+	return set, nil
+}
+
+// value parses a number or name in a cron field.
+func (f cronField) value(s string) (int, error) {
+	for i, name := range f.names {
+		if name != "" && strings.EqualFold(s, name) {
+			return i, nil
+		}
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid value %q, must be %d-%d", s, f.min, f.max)
+	}
+	return v, nil
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCronInterval(t *testing.T) {
+	tests := []struct {
+		expr string
+		want time.Duration
+		err  string
+	}{
+		{expr: "0 3 * * *", want: 24 * time.Hour},
+		{expr: "*/15 * * * *", want: 15 * time.Minute},
+		{expr: "* * * * *", want: time.Minute},
+		{expr: "0 */6 * * *", want: 6 * time.Hour},
+		{expr: "0 9,17 * * *", want: 8 * time.Hour},
+		{expr: "30 2 * * 0", want: 7 * 24 * time.Hour},
+		{expr: "30 2 * * sun", want: 7 * 24 * time.Hour},
+		{expr: "0 0 * * 1,7", want: 24 * time.Hour},
+		{expr: "0 0,23 * * 1-5", want: time.Hour},
+		{expr: "0 0 1 * *", want: 28 * 24 * time.Hour},
+		{expr: "0 0 1 jan *", want: 365 * 24 * time.Hour},
+		{expr: "0 0 1,15 * 3", want: 24 * time.Hour},
+		{expr: "5/20 * * * *", want: 20 * time.Minute},
+		{expr: "0 3 * *", err: "five fields"},
+		{expr: "60 * * * *", err: "must be 0-59"},
+		{expr: "*/0 * * * *", err: "invalid step"},
+		{expr: "0 5-1 * * *", err: "invalid range"},
+		{expr: "0 0 31 2 *", err: "never runs"},
+	}
+
+	for _, tt := range tests {
+		got, err := CronInterval(tt.expr)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("expected %q to fail with %q, got %v", tt.expr, tt.err, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Fatalf("expected %q to run every %s, got %s: %v", tt.expr, tt.want, got, err)
+		}
+	}
+}
+
+func TestScriptProfileLimits(t *testing.T) {
+	limits := ScriptProfileLimits{MaxNumComputers: 10, MinInterval: 60}
+
+	if err := limits.CheckInterval("0 * * * *"); err != nil {
+		t.Fatalf("unexpected error for an hourly schedule: %v", err)
+	}
+	if err := limits.CheckInterval("*/30 * * * *"); !errors.Is(err, ErrScriptProfileLimit) {
+		t.Fatalf("expected ErrScriptProfileLimit, got %v", err)
+	}
+	if err := limits.CheckInterval("every day"); err == nil || errors.Is(err, ErrScriptProfileLimit) {
+		t.Fatalf("expected an invalid expression error, got %v", err)
+	}
+
+	if err := limits.CheckComputers(10); err != nil {
+		t.Fatalf("unexpected error at the limit: %v", err)
+	}
+	if err := limits.CheckComputers(11); !errors.Is(err, ErrScriptProfileLimit) {
+		t.Fatalf("expected ErrScriptProfileLimit, got %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// Session describes who a login was for, as returned by the login endpoints.
+type Session struct {
+	// Token is the JWT for the session.
+	Token string `json:"-"`
+
+	Email      string `json:"email"`
+	Name       string `json:"name,omitempty"`
+	SelfHosted bool   `json:"self_hosted"`
+
+	// CurrentAccount is the name of the account the session is logged into.
+	CurrentAccount string `json:"current_account"`
+
+	// Accounts are the accounts the user can log into.
+	Accounts []LoginAccount `json:"accounts"`
+}
+
+// SessionLoginProvider is implemented by LoginProviders that can return the
+// whole Session from logging in, not just the token.
+type SessionLoginProvider interface {
+	LoginProvider
+	LoginSession(ctx context.Context, client *ClientWithResponses) (*Session, error)
+}
+
+// NewSession creates a Session from a login response.
+func NewSession(res *LoginResponse) *Session {
+	return &Session{
+		Token:          res.Token,
+		Email:          string(res.Email),
+		Name:           derefOrZero(res.Name),
+		SelfHosted:     derefOrZero(res.SelfHosted),
+		CurrentAccount: res.CurrentAccount,
+		Accounts:       res.Accounts,
+	}
+}
+
+// Login logs in with provider and returns the Session. provider must
+// implement SessionLoginProvider.
+func Login(ctx context.Context, c *ClientWithResponses, provider LoginProvider) (*Session, error) {
+	sp, ok := provider.(SessionLoginProvider)
+	if !ok {
+		return nil, fmt.Errorf("%T doesn't report login sessions", provider)
+	}
+	return sp.LoginSession(ctx, c)
+}
+
+// Account returns the account with the given name, if the user has access to
+// it.
+func (s *Session) Account(name string) (LoginAccount, bool) {
+	for _, account := range s.Accounts {
+		if account.Name == name {
+			return account, true
+		}
+	}
+	return LoginAccount{}, false
+}
+
+// DefaultAccount returns the user's default account, if they have one.
+func (s *Session) DefaultAccount() (LoginAccount, bool) {
+	for _, account := range s.Accounts {
+		if account.Default {
+			return account, true
+		}
+	}
+	return LoginAccount{}, false
+}
+
+// AccountNames returns the names of the accounts the user can log into.
+func (s *Session) AccountNames() []string {
+	names := make([]string, len(s.Accounts))
+	for i, account := range s.Accounts {
+		names[i] = account.Name
+	}
+	return names
+}
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestEmailPasswordProviderLoginSession(t *testing.T) {
+	handler := http.NewServeMux()
+	handler.HandleFunc("/api/login", func(w http.ResponseWriter, r *http.Request) {
+		var req LoginRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+
+		current := "personal"
+		if req.Account != nil {
+			current = *req.Account
+		}
+
+		name := "Jan"
+		selfHosted := true
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(LoginResponse{
+			Token:          "token-" + current,
+			Email:          req.Email,
+			Name:           &name,
+			SelfHosted:     &selfHosted,
+			CurrentAccount: current,
+			Accounts: []LoginAccount{
+				{Name: "personal", Title: "Personal", Default: true},
+				{Name: "example-org", Title: "Example Org"},
+			},
+		})
+	})
+
+	server := httptest.NewTLSServer(handler)
+	defer server.Close()
+
+	api, err := NewClientWithResponses(server.URL, WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("failed to init client: %v", err)
+	}
+
+	t.Run("logs into the requested account", func(t *testing.T) {
+		account := "example-org"
+		session, err := Login(context.Background(), api, NewEmailPasswordProvider("jan@example.com", "pw", &account))
+		if err != nil {
+			t.Fatalf("Login failed: %v", err)
+		}
+
+		if session.Token != "token-example-org" || session.CurrentAccount != "example-org" {
+			t.Fatalf("unexpected session: %+v", session)
+		}
+		if session.Email != "jan@example.com" || session.Name != "Jan" || !session.SelfHosted {
+			t.Fatalf("unexpected user details: %+v", session)
+		}
+
+		if got, ok := session.Account("example-org"); !ok || got.Title != "Example Org" {
+			t.Fatalf("expected example-org account, got %+v", got)
+		}
+		if got, ok := session.DefaultAccount(); !ok || got.Name != "personal" {
+			t.Fatalf("expected personal default account, got %+v", got)
+		}
+		if _, ok := session.Account("missing"); ok {
+			t.Fatal("expected no account named missing")
+		}
+	})
+
+	t.Run("uses the default account without one", func(t *testing.T) {
+		token, err := NewEmailPasswordProvider("jan@example.com", "pw", nil).Login(context.Background(), api)
+		if err != nil {
+			t.Fatalf("Login failed: %v", err)
+		}
+		if token != "token-personal" {
+			t.Fatalf("expected token-personal, got %s", token)
+		}
+	})
+
+	t.Run("fails if the account wasn't selected", func(t *testing.T) {
+		handler := http.NewServeMux()
+		handler.HandleFunc("/api/login", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(LoginResponse{
+				Token:          "token",
+				Email:          "jan@example.com",
+				CurrentAccount: "personal",
+				Accounts:       []LoginAccount{{Name: "personal"}},
+			})
+		})
+		server := httptest.NewTLSServer(handler)
+		defer server.Close()
+
+		api, err := NewClientWithResponses(server.URL, WithHTTPClient(server.Client()))
+		if err != nil {
+			t.Fatalf("failed to init client: %v", err)
+		}
+
+		account := "example-org"
+		_, err = NewEmailPasswordProvider("jan@example.com", "pw", &account).Login(context.Background(), api)
+		if err == nil || !strings.Contains(err.Error(), "available accounts: personal") {
+			t.Fatalf("expected account mismatch error, got %v", err)
+		}
+	})
+}
+
+func TestLoginRequiresSessionProvider(t *testing.T) {
+	provider := &countingLoginProvider{token: func(n int) string { return "token" }}
+	if _, err := Login(context.Background(), nil, provider); err == nil {
+		t.Fatal("expected an error for a provider without sessions")
+	}
+}
@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StaticTokenProvider "logs in" by returning a JWT obtained elsewhere, ex.
+// minted by a CI pipeline. It never calls a login endpoint, so once the token
+// expires or is revoked, requests fail with 401.
+type StaticTokenProvider struct {
+	Token string
+}
+
+func NewStaticTokenProvider(token string) *StaticTokenProvider {
+	return &StaticTokenProvider{Token: token}
+}
+
+// Login implements LoginProvider for StaticTokenProvider.
+func (p *StaticTokenProvider) Login(ctx context.Context, c *ClientWithResponses) (string, error) {
+	if p.Token == "" {
+		return "", fmt.Errorf("static token is empty")
+	}
+	return p.Token, nil
+}
+
+// TokenFileProvider reads a JWT from a file that is kept up to date by
+// something else, like a Kubernetes projected secret. The file is read again
+// whenever it changes, so rotated tokens are picked up without restarting.
+type TokenFileProvider struct {
+	Path string
+
+	mu      sync.Mutex
+	token   string
+	modTime time.Time
+	size    int64
+}
+
+func NewTokenFileProvider(path string) *TokenFileProvider {
+	return &TokenFileProvider{Path: path}
+}
+
+// Login implements LoginProvider for TokenFileProvider.
+func (p *TokenFileProvider) Login(ctx context.Context, c *ClientWithResponses) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	info, err := os.Stat(p.Path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+	if p.token != "" && !p.changedLocked(info) {
+		return p.token, nil
+	}
+
+	data, err := os.ReadFile(p.Path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("token file %s is empty", p.Path)
+	}
+
+	p.token = token
+	p.modTime = info.ModTime()
+	p.size = info.Size()
+
+	return token, nil
+}
+
+// TokenChanged implements ChangingLoginProvider for TokenFileProvider. It
+// reports whether the file has changed since token was read from it.
+func (p *TokenFileProvider) TokenChanged(token string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if token != p.token {
+		return true
+	}
+
+	info, err := os.Stat(p.Path)
+	if err != nil {
+		// Keep using the token we have; Login reports the error if the
+		// token is rejected.
+		return false
+	}
+	return p.changedLocked(info)
+}
+
+func (p *TokenFileProvider) changedLocked(info os.FileInfo) bool {
+	return !info.ModTime().Equal(p.modTime) || info.Size() != p.size
+}
@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestStaticTokenProvider(t *testing.T) {
+	handler := http.NewServeMux()
+	handler.HandleFunc("/api/scripts/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer ci-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": 1}`))
+	})
+	handler.HandleFunc("/api/login/access-key", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("static tokens must not call a login endpoint")
+	})
+
+	server := httptest.NewTLSServer(handler)
+	defer server.Close()
+
+	api, err := NewLandscapeAPIClient(server.URL, NewStaticTokenProvider("ci-token"), WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("failed to init client: %v", err)
+	}
+
+	resp, err := api.GetScriptWithResponse(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetScriptWithResponse failed: %v", err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		t.Fatalf("expected HTTP 200 but received %d", resp.StatusCode())
+	}
+
+	if _, err := NewStaticTokenProvider("").Login(context.Background(), nil); err == nil {
+		t.Fatal("expected an error for an empty token")
+	}
+}
+
+func TestStaticTokenProviderRejected(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error": "expired"}`))
+	}))
+	defer server.Close()
+
+	api, err := NewLandscapeAPIClient(server.URL, NewStaticTokenProvider("ci-token"), WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("failed to init client: %v", err)
+	}
+
+	resp, err := api.GetScriptWithResponse(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetScriptWithResponse failed: %v", err)
+	}
+	if resp.StatusCode() != http.StatusUnauthorized || string(resp.Body) != `{"error": "expired"}` {
+		t.Fatalf("expected the 401 response, got %d: %s", resp.StatusCode(), resp.Body)
+	}
+	if requests.Load() != 1 {
+		t.Fatalf("expected 1 request, got %d", requests.Load())
+	}
+}
+
+func TestTokenFileProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	writeToken := func(token string, modTime time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(token+"\n"), 0o600); err != nil {
+			t.Fatalf("failed to write token file: %v", err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("failed to set token file time: %v", err)
+		}
+	}
+
+	exp := time.Now().Add(time.Hour)
+	first := testJWT(t, exp, "first")
+	second := testJWT(t, exp, "second")
+
+	writeToken(first, time.Now().Add(-time.Minute))
+
+	ts := NewTokenSource(NewTokenFileProvider(path), nil)
+
+	token, err := ts.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token failed: %v", err)
+	}
+	if token != first {
+		t.Fatalf("expected the token from the file, got %q", token)
+	}
+
+	token, err = ts.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token failed: %v", err)
+	}
+	if token != first {
+		t.Fatalf("expected the unchanged token, got %q", token)
+	}
+
+	writeToken(second, time.Now())
+
+	token, err = ts.Token(context.Background())
+	if err != nil {
+		t.Fatalf("Token failed: %v", err)
+	}
+	if token != second {
+		t.Fatalf("expected the rotated token, got %q", token)
+	}
+
+	writeToken("", time.Now().Add(time.Minute))
+	if _, err := ts.Token(context.Background()); err == nil {
+		t.Fatal("expected an error for an empty token file")
+	}
+}
@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+)
+
+// WithTLSConfig makes the client use config for TLS connections, ex. to
+// trust a private CA or present a client certificate to an mTLS proxy.
+//
+// If the client already has an *http.Client, its transport is cloned and
+// only the TLS configuration is replaced. Otherwise the client gets a clone
+// of http.DefaultTransport, so proxy settings from the environment, timeouts
+// and HTTP/2 keep working.
+//
+// This replaces the HttpRequestDoer, so it must come after WithHTTPClient and
+// before options that wrap it, like WithRetry and WithRateLimit.
+func WithTLSConfig(config *tls.Config) ClientOption {
+	return func(c *Client) error {
+		httpClient := &http.Client{}
+		switch doer := c.Client.(type) {
+		case nil:
+		case *http.Client:
+			clone := *doer
+			httpClient = &clone
+		default:
+			return fmt.Errorf("WithTLSConfig must come before options that wrap the HTTP client, got %T", doer)
+		}
+
+		var transport *http.Transport
+		switch base := httpClient.Transport.(type) {
+		case nil:
+			transport = http.DefaultTransport.(*http.Transport).Clone()
+		case *http.Transport:
+			transport = base.Clone()
+		default:
+			return fmt.Errorf("WithTLSConfig requires an *http.Transport, got %T", base)
+		}
+
+		transport.TLSClientConfig = config.Clone()
+		httpClient.Transport = transport
+		c.Client = httpClient
+
+		return nil
+	}
+}
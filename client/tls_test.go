@@ -0,0 +1,153 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func testClientCertificate(t *testing.T) (tls.Certificate, *x509.CertPool) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "landscape-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: cert}, pool
+}
+
+func TestWithTLSConfig(t *testing.T) {
+	clientCert, clientCAs := testClientCertificate(t)
+
+	handler := http.NewServeMux()
+	handler.HandleFunc("/api/scripts/1", func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 || r.TLS.PeerCertificates[0].Subject.CommonName != "landscape-client" {
+			t.Errorf("expected the client certificate to be presented")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": 1}`))
+	})
+
+	server := httptest.NewUnstartedServer(handler)
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.StartTLS()
+	defer server.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+
+	t.Run("presents the client certificate", func(t *testing.T) {
+		api, err := NewClientWithResponses(server.URL, WithTLSConfig(&tls.Config{
+			RootCAs:      roots,
+			Certificates: []tls.Certificate{clientCert},
+		}))
+		if err != nil {
+			t.Fatalf("failed to init client: %v", err)
+		}
+
+		resp, err := api.GetScriptWithResponse(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("GetScriptWithResponse failed: %v", err)
+		}
+		if resp.StatusCode() != http.StatusOK {
+			t.Fatalf("expected HTTP 200 but received %d", resp.StatusCode())
+		}
+	})
+
+	t.Run("fails without the client certificate", func(t *testing.T) {
+		api, err := NewClientWithResponses(server.URL, WithTLSConfig(&tls.Config{RootCAs: roots}))
+		if err != nil {
+			t.Fatalf("failed to init client: %v", err)
+		}
+
+		if _, err := api.GetScriptWithResponse(context.Background(), 1); err == nil {
+			t.Fatal("expected the TLS handshake to fail")
+		}
+	})
+
+	t.Run("keeps the transport settings of an existing client", func(t *testing.T) {
+		base := &http.Client{
+			Timeout:   time.Minute,
+			Transport: &http.Transport{MaxIdleConns: 7},
+		}
+
+		c, err := NewClient(server.URL, WithHTTPClient(base), WithTLSConfig(&tls.Config{ServerName: "landscape.internal"}))
+		if err != nil {
+			t.Fatalf("failed to init client: %v", err)
+		}
+
+		httpClient, ok := c.Client.(*http.Client)
+		if !ok {
+			t.Fatalf("expected an *http.Client, got %T", c.Client)
+		}
+		transport := httpClient.Transport.(*http.Transport)
+		if httpClient.Timeout != time.Minute || transport.MaxIdleConns != 7 {
+			t.Fatalf("expected the existing settings to be kept, got timeout %v and %d idle conns", httpClient.Timeout, transport.MaxIdleConns)
+		}
+		if transport.TLSClientConfig.ServerName != "landscape.internal" {
+			t.Fatalf("expected the TLS config to be set")
+		}
+		if original := base.Transport.(*http.Transport).TLSClientConfig; original != nil && original.ServerName != "" {
+			t.Fatalf("expected the original transport to be left alone")
+		}
+	})
+
+	t.Run("defaults to a clone of the default transport", func(t *testing.T) {
+		c, err := NewClient(server.URL, WithTLSConfig(&tls.Config{}))
+		if err != nil {
+			t.Fatalf("failed to init client: %v", err)
+		}
+
+		transport := c.Client.(*http.Client).Transport.(*http.Transport)
+		if transport == http.DefaultTransport || transport.Proxy == nil {
+			t.Fatalf("expected a clone of http.DefaultTransport with its proxy settings")
+		}
+	})
+
+	t.Run("must come before wrapping options", func(t *testing.T) {
+		_, err := NewClient(server.URL, WithRetry(DefaultRetryPolicy()), WithTLSConfig(&tls.Config{}))
+		if err == nil {
+			t.Fatal("expected an error when the HTTP client is already wrapped")
+		}
+	})
+}
@@ -0,0 +1,293 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultTokenRefreshWindow is how long before a JWT's expiry a TokenSource
+// proactively logs in again.
+const DefaultTokenRefreshWindow = time.Minute
+
+// ChangingLoginProvider is implemented by LoginProviders whose token can
+// change outside of logging in, like TokenFileProvider. TokenSource checks
+// TokenChanged before reusing a token, and gets a new one if it returns true.
+type ChangingLoginProvider interface {
+	LoginProvider
+	TokenChanged(token string) bool
+}
+
+// TokenSource hands out JWTs obtained from a LoginProvider. It decodes the
+// exp claim of each token and logs in again shortly before it expires, after
+// the token has been invalidated (ex. because the server returned 401), or
+// when a ChangingLoginProvider reports a new token.
+//
+// A TokenSource is safe for concurrent use. At most one login is in flight at
+// a time; concurrent callers wait for it and then share the new token. A
+// caller stops waiting when its context is done.
+type TokenSource struct {
+	// RefreshWindow is how long before expiry the token is refreshed.
+	// Defaults to DefaultTokenRefreshWindow.
+	RefreshWindow time.Duration
+
+	provider LoginProvider
+	client   *ClientWithResponses
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+	login   *tokenLogin
+}
+
+// tokenLogin is a login in flight. done is closed once token and err are set.
+type tokenLogin struct {
+	done  chan struct{}
+	token string
+	err   error
+
+	// canceled reports that the login failed because the context of the
+	// caller that started it was done.
+	canceled bool
+}
+
+// NewTokenSource creates a TokenSource that logs in with provider using
+// client. client should not itself be authenticated through the returned
+// TokenSource, since it is used to call the login endpoints.
+func NewTokenSource(provider LoginProvider, client *ClientWithResponses) *TokenSource {
+	return &TokenSource{
+		RefreshWindow: DefaultTokenRefreshWindow,
+		provider:      provider,
+		client:        client,
+	}
+}
+
+// Token returns a valid JWT, logging in first if there is no token yet or the
+// current one is about to expire.
+func (ts *TokenSource) Token(ctx context.Context) (string, error) {
+	for {
+		ts.mu.Lock()
+		if ts.token != "" && !ts.expiringLocked() && !ts.changedLocked() {
+			token := ts.token
+			ts.mu.Unlock()
+			return token, nil
+		}
+
+		if login := ts.login; login != nil {
+			ts.mu.Unlock()
+
+			select {
+			case <-ctx.Done():
+				return "", ctx.Err()
+			case <-login.done:
+			}
+			if login.canceled && ctx.Err() == nil {
+				// The caller that logged in gave up, but this one hasn't.
+				continue
+			}
+			return login.token, login.err
+		}
+
+		login := &tokenLogin{done: make(chan struct{})}
+		ts.login = login
+		ts.mu.Unlock()
+
+		return ts.runLogin(ctx, login)
+	}
+}
+
+// runLogin logs in with the provider and hands the result to the callers
+// waiting for login.
+func (ts *TokenSource) runLogin(ctx context.Context, login *tokenLogin) (string, error) {
+	token, err := ts.provider.Login(withLoginRequest(ctx), ts.client)
+
+	ts.mu.Lock()
+	if err == nil {
+		ts.token = token
+		ts.expires, _ = jwtExpiry(token)
+		login.token = token
+	}
+	ts.login = nil
+	ts.mu.Unlock()
+
+	login.err = err
+	login.canceled = err != nil && ctx.Err() != nil
+	close(login.done)
+
+	return login.token, err
+}
+
+// Invalidate discards token if it is still the current token, so the next
+// call to Token logs in again. Tokens that have already been replaced are
+// ignored, which stops many goroutines that saw the same 401 from each
+// triggering their own login.
+//
+// If the LoginProvider implements TokenInvalidator, it is told about the
+// invalidated token too.
+func (ts *TokenSource) Invalidate(token string) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.token != token {
+		return
+	}
+
+	ts.token = ""
+	ts.expires = time.Time{}
+
+	if inv, ok := ts.provider.(TokenInvalidator); ok {
+		inv.InvalidateToken(token)
+	}
+}
+
+// changedLocked reports whether the provider has a newer token than the
+// current one.
+func (ts *TokenSource) changedLocked() bool {
+	changing, ok := ts.provider.(ChangingLoginProvider)
+	return ok && changing.TokenChanged(ts.token)
+}
+
+func (ts *TokenSource) expiringLocked() bool {
+	if ts.expires.IsZero() {
+		return false
+	}
+	return time.Until(ts.expires) < ts.RefreshWindow
+}
+
+// jwtExpiry returns the time in the exp claim of a JWT. The signature is not
+// verified; the server is the authority on whether the token is valid, this is
+// only used to refresh ahead of time.
+func jwtExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	var claims struct {
+		Exp *float64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == nil {
+		return time.Time{}, false
+	}
+
+	sec := int64(*claims.Exp)
+	nsec := int64((*claims.Exp - float64(sec)) * float64(time.Second))
+	return time.Unix(sec, nsec), true
+}
+
+// WithTokenSource authenticates every request with a Bearer token from ts.
+// If the server responds with 401, the token is invalidated and the request
+// is retried once with a freshly obtained token, unless logging in again
+// returns the rejected token.
+//
+// This wraps the HttpRequestDoer configured by earlier options, so it must
+// come after WithHTTPClient.
+func WithTokenSource(ts *TokenSource) ClientOption {
+	return func(c *Client) error {
+		if c.Client == nil {
+			c.Client = &http.Client{}
+		}
+		c.Client = &authDoer{next: c.Client, tokens: ts}
+		return nil
+	}
+}
+
+// loginRequestKey marks the context of requests made to log in.
+type loginRequestKey struct{}
+
+// withLoginRequest marks ctx as belonging to a login, which WithRateLimit
+// doesn't count against WithMaxConcurrency: a login can be needed to retry a
+// request that is still holding a slot.
+func withLoginRequest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loginRequestKey{}, true)
+}
+
+func isLoginRequest(ctx context.Context) bool {
+	login, _ := ctx.Value(loginRequestKey{}).(bool)
+	return login
+}
+
+type authDoer struct {
+	next   HttpRequestDoer
+	tokens *TokenSource
+}
+
+// Do implements HttpRequestDoer for authDoer.
+func (d *authDoer) Do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	token, err := d.tokens.Token(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get token: %w", err)
+	}
+
+	retry, canRetry := rewindRequest(req)
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	res, err := d.next.Do(req)
+	if err != nil || res.StatusCode != http.StatusUnauthorized || !canRetry {
+		return res, err
+	}
+
+	// Close the rejected response before logging in again, so it doesn't
+	// hold on to its connection or a WithMaxConcurrency slot meanwhile. Its
+	// body is kept in case it has to be returned after all.
+	body, _ := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+
+	d.tokens.Invalidate(token)
+	fresh, err := d.tokens.Token(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to log in again after 401: %w", err)
+	}
+	if fresh == token {
+		// The provider has no other token, ex. a StaticTokenProvider, so
+		// the retry would be rejected too.
+		res.Body = io.NopCloser(bytes.NewReader(body))
+		return res, nil
+	}
+
+	retry.Header.Set("Authorization", "Bearer "+fresh)
+	return d.next.Do(retry)
+}
+
+// rewindRequest returns a copy of req that can be sent again after req has
+// been sent. It reports false if the body cannot be replayed.
+func rewindRequest(req *http.Request) (*http.Request, bool) {
+	clone := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return clone, true
+	}
+	if req.GetBody == nil {
+		return nil, false
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, false
+	}
+	clone.Body = body
+
+	return clone, true
+}
+
+// drainBody reads and closes a response body that is being discarded so the
+// underlying connection can be reused.
+func drainBody(res *http.Response) {
+	_, _ = io.Copy(io.Discard, res.Body)
+	_ = res.Body.Close()
+}
@@ -0,0 +1,260 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenInvalidator is implemented by LoginProviders that keep tokens around,
+// like CachedLoginProvider. TokenSource.Invalidate calls InvalidateToken so
+// a token the server rejected isn't handed out again.
+type TokenInvalidator interface {
+	InvalidateToken(token string)
+}
+
+// CachedLoginProvider wraps a LoginProvider and stores the tokens it returns
+// in files, so separate processes (ex. repeated CLI invocations) can reuse a
+// token instead of each logging in.
+//
+// Tokens are keyed by the base URL of the client and Identity, and are
+// reused until they are within RefreshWindow of expiry. For the providers in
+// this package that log in with a secret, a hash of the secret is stored with
+// the token, and the token isn't reused once the secret changes. Tokens without an exp
+// claim are not cached. Failing to write the cache is not an error; the
+// token is still returned.
+type CachedLoginProvider struct {
+	// Provider logs in when there is no usable cached token.
+	Provider LoginProvider
+
+	// Identity distinguishes the credentials of Provider, ex. the access key
+	// or the email and account. It must not contain secrets.
+	Identity string
+
+	// Dir is the directory holding the cached tokens. Defaults to
+	// DefaultTokenCacheDir.
+	Dir string
+
+	// RefreshWindow is how long before expiry a cached token is no longer
+	// reused. Defaults to DefaultTokenRefreshWindow.
+	RefreshWindow time.Duration
+
+	mu    sync.Mutex
+	paths map[string]string
+}
+
+// NewCachedLoginProvider creates a CachedLoginProvider for provider, caching
+// tokens in DefaultTokenCacheDir.
+func NewCachedLoginProvider(provider LoginProvider, identity string) *CachedLoginProvider {
+	return &CachedLoginProvider{
+		Provider:      provider,
+		Identity:      identity,
+		RefreshWindow: DefaultTokenRefreshWindow,
+	}
+}
+
+// DefaultTokenCacheDir returns the landscape-api/tokens directory in the
+// user's cache directory, ex. ~/.cache/landscape-api/tokens.
+func DefaultTokenCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "landscape-api", "tokens"), nil
+}
+
+type cachedToken struct {
+	BaseURL     string    `json:"base_url"`
+	Token       string    `json:"token"`
+	Expires     time.Time `json:"expires"`
+	Credentials string    `json:"credentials,omitempty"`
+}
+
+// secretLoginProvider is implemented by the LoginProviders in this package
+// that log in with a secret, ex. a secret key or password.
+type secretLoginProvider interface {
+	loginSecret() string
+}
+
+// Login implements LoginProvider for CachedLoginProvider.
+func (p *CachedLoginProvider) Login(ctx context.Context, c *ClientWithResponses) (string, error) {
+	baseURL := clientBaseURL(c)
+
+	path, err := p.path(baseURL)
+	if err != nil {
+		return p.Provider.Login(ctx, c)
+	}
+
+	credentials := p.credentials(baseURL)
+	if token, ok := p.read(path, credentials); ok {
+		p.remember(token, path)
+		return token, nil
+	}
+
+	token, err := p.Provider.Login(ctx, c)
+	if err != nil {
+		return "", err
+	}
+
+	if expires, ok := jwtExpiry(token); ok {
+		_ = writeCachedToken(path, cachedToken{BaseURL: baseURL, Token: token, Expires: expires, Credentials: credentials})
+		p.remember(token, path)
+	}
+
+	return token, nil
+}
+
+// InvalidateToken implements TokenInvalidator for CachedLoginProvider by
+// removing token from the cache.
+func (p *CachedLoginProvider) InvalidateToken(token string) {
+	p.mu.Lock()
+	path, ok := p.paths[token]
+	delete(p.paths, token)
+	p.mu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	// Another process may have cached a new token in the meantime.
+	if cached, err := readCachedToken(path); err == nil && cached.Token != token {
+		return
+	}
+	_ = os.Remove(path)
+}
+
+// Clear removes the cached token for baseURL and Identity, if there is one.
+func (p *CachedLoginProvider) Clear(baseURL string) error {
+	path, err := p.path(normalizeBaseURL(baseURL))
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (p *CachedLoginProvider) path(baseURL string) (string, error) {
+	dir := p.Dir
+	if dir == "" {
+		var err error
+		if dir, err = DefaultTokenCacheDir(); err != nil {
+			return "", err
+		}
+	}
+
+	sum := sha256.Sum256([]byte(baseURL + "\x00" + p.Identity))
+	return filepath.Join(dir, hex.EncodeToString(sum[:])+".json"), nil
+}
+
+// credentials returns a hash identifying the secret Provider logs in with,
+// or "" if it doesn't have one. The base URL and Identity are hashed with it
+// so that the same secret doesn't hash the same way in every cached token.
+func (p *CachedLoginProvider) credentials(baseURL string) string {
+	sp, ok := p.Provider.(secretLoginProvider)
+	if !ok {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(baseURL + "\x00" + p.Identity + "\x00" + sp.loginSecret()))
+	return hex.EncodeToString(sum[:])
+}
+
+func (p *CachedLoginProvider) read(path, credentials string) (string, bool) {
+	cached, err := readCachedToken(path)
+	if err != nil || cached.Token == "" || cached.Credentials != credentials {
+		return "", false
+	}
+
+	window := p.RefreshWindow
+	if window == 0 {
+		window = DefaultTokenRefreshWindow
+	}
+	if time.Until(cached.Expires) < window {
+		return "", false
+	}
+
+	return cached.Token, true
+}
+
+func (p *CachedLoginProvider) remember(token, path string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.paths == nil {
+		p.paths = map[string]string{}
+	}
+	p.paths[token] = path
+}
+
+func readCachedToken(path string) (*cachedToken, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cached cachedToken
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, err
+	}
+	return &cached, nil
+}
+
+func writeCachedToken(path string, cached cachedToken) error {
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data)
+}
+
+// writeFileAtomic writes a file that only the current user can read,
+// replacing it in one step so concurrent readers never see a partial file.
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// clientBaseURL returns the server URL c sends requests to.
+func clientBaseURL(c *ClientWithResponses) string {
+	if c == nil {
+		return ""
+	}
+	if inner, ok := c.ClientInterface.(*Client); ok {
+		return normalizeBaseURL(inner.Server)
+	}
+	return ""
+}
+
+func normalizeBaseURL(baseURL string) string {
+	return strings.TrimRight(baseURL, "/")
+}
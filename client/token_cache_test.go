@@ -0,0 +1,246 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCachedLoginProvider(t *testing.T) {
+	newProvider := func(t *testing.T, dir, identity string, exp time.Duration) (*CachedLoginProvider, *countingLoginProvider) {
+		t.Helper()
+		inner := &countingLoginProvider{token: func(n int) string {
+			return testJWT(t, time.Now().Add(exp), fmt.Sprint(n))
+		}}
+		p := NewCachedLoginProvider(inner, identity)
+		p.Dir = dir
+		return p, inner
+	}
+
+	newClient := func(t *testing.T, baseURL string) *ClientWithResponses {
+		t.Helper()
+		c, err := NewClientWithResponses(baseURL)
+		if err != nil {
+			t.Fatalf("failed to init client: %v", err)
+		}
+		return c
+	}
+
+	t.Run("reuses token across providers", func(t *testing.T) {
+		dir := t.TempDir()
+		c := newClient(t, "https://landscape.example.com")
+
+		first, firstInner := newProvider(t, dir, "ak", time.Hour)
+		want, err := first.Login(context.Background(), c)
+		if err != nil {
+			t.Fatalf("Login failed: %v", err)
+		}
+
+		second, secondInner := newProvider(t, dir, "ak", time.Hour)
+		got, err := second.Login(context.Background(), c)
+		if err != nil {
+			t.Fatalf("Login failed: %v", err)
+		}
+
+		if got != want {
+			t.Fatalf("expected cached token %q, got %q", want, got)
+		}
+		if firstInner.count() != 1 || secondInner.count() != 0 {
+			t.Fatalf("expected 1 login in total, got %d and %d", firstInner.count(), secondInner.count())
+		}
+
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatalf("failed to read cache dir: %v", err)
+		}
+		if len(entries) != 1 {
+			t.Fatalf("expected 1 cache file, got %d", len(entries))
+		}
+		info, err := os.Stat(filepath.Join(dir, entries[0].Name()))
+		if err != nil {
+			t.Fatalf("failed to stat cache file: %v", err)
+		}
+		if info.Mode().Perm() != 0o600 {
+			t.Fatalf("expected cache file mode 0600, got %v", info.Mode().Perm())
+		}
+	})
+
+	t.Run("keys tokens by base URL and identity", func(t *testing.T) {
+		dir := t.TempDir()
+
+		p, _ := newProvider(t, dir, "ak", time.Hour)
+		if _, err := p.Login(context.Background(), newClient(t, "https://a.example.com")); err != nil {
+			t.Fatalf("Login failed: %v", err)
+		}
+
+		other, otherInner := newProvider(t, dir, "other", time.Hour)
+		if _, err := other.Login(context.Background(), newClient(t, "https://a.example.com")); err != nil {
+			t.Fatalf("Login failed: %v", err)
+		}
+		elsewhere, elsewhereInner := newProvider(t, dir, "ak", time.Hour)
+		if _, err := elsewhere.Login(context.Background(), newClient(t, "https://b.example.com")); err != nil {
+			t.Fatalf("Login failed: %v", err)
+		}
+
+		if otherInner.count() != 1 || elsewhereInner.count() != 1 {
+			t.Fatalf("expected a login per identity and base URL, got %d and %d", otherInner.count(), elsewhereInner.count())
+		}
+	})
+
+	t.Run("logs in again when the secret changes", func(t *testing.T) {
+		dir := t.TempDir()
+		c := newClient(t, "https://landscape.example.com")
+
+		withSecret := func(secret string) (*CachedLoginProvider, *countingLoginProvider) {
+			p, inner := newProvider(t, dir, "ak", time.Hour)
+			p.Provider = &secretCountingLoginProvider{countingLoginProvider: inner, secret: secret}
+			return p, inner
+		}
+
+		first, _ := withSecret("old-secret")
+		if _, err := first.Login(context.Background(), c); err != nil {
+			t.Fatalf("Login failed: %v", err)
+		}
+
+		same, sameInner := withSecret("old-secret")
+		if _, err := same.Login(context.Background(), c); err != nil {
+			t.Fatalf("Login failed: %v", err)
+		}
+		rotated, rotatedInner := withSecret("new-secret")
+		if _, err := rotated.Login(context.Background(), c); err != nil {
+			t.Fatalf("Login failed: %v", err)
+		}
+
+		if sameInner.count() != 0 || rotatedInner.count() != 1 {
+			t.Fatalf("expected a login only for the new secret, got %d and %d", sameInner.count(), rotatedInner.count())
+		}
+
+		entries, err := os.ReadDir(dir)
+		if err != nil || len(entries) != 1 {
+			t.Fatalf("expected 1 cache file, got %d: %v", len(entries), err)
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entries[0].Name()))
+		if err != nil {
+			t.Fatalf("failed to read cache file: %v", err)
+		}
+		if strings.Contains(string(data), "new-secret") {
+			t.Fatalf("cache file contains the secret: %s", data)
+		}
+	})
+
+	t.Run("logs in again when the cached token nears expiry", func(t *testing.T) {
+		dir := t.TempDir()
+		c := newClient(t, "https://landscape.example.com")
+
+		first, _ := newProvider(t, dir, "ak", 30*time.Second)
+		if _, err := first.Login(context.Background(), c); err != nil {
+			t.Fatalf("Login failed: %v", err)
+		}
+
+		second, secondInner := newProvider(t, dir, "ak", time.Hour)
+		if _, err := second.Login(context.Background(), c); err != nil {
+			t.Fatalf("Login failed: %v", err)
+		}
+
+		if secondInner.count() != 1 {
+			t.Fatalf("expected the expiring token to be replaced, got %d logins", secondInner.count())
+		}
+	})
+
+	t.Run("clear removes the cached token", func(t *testing.T) {
+		dir := t.TempDir()
+		c := newClient(t, "https://landscape.example.com")
+
+		p, inner := newProvider(t, dir, "ak", time.Hour)
+		if _, err := p.Login(context.Background(), c); err != nil {
+			t.Fatalf("Login failed: %v", err)
+		}
+		if err := p.Clear("https://landscape.example.com/"); err != nil {
+			t.Fatalf("Clear failed: %v", err)
+		}
+		if _, err := p.Login(context.Background(), c); err != nil {
+			t.Fatalf("Login failed: %v", err)
+		}
+
+		if inner.count() != 2 {
+			t.Fatalf("expected 2 logins, got %d", inner.count())
+		}
+	})
+}
+
+// secretCountingLoginProvider is a countingLoginProvider that logs in with a
+// secret, like AccessKeyProvider.
+type secretCountingLoginProvider struct {
+	*countingLoginProvider
+	secret string
+}
+
+func (p *secretCountingLoginProvider) loginSecret() string {
+	return p.secret
+}
+
+func TestCachedLoginProviderInvalidatesOn401(t *testing.T) {
+	dir := t.TempDir()
+
+	var valid string
+	handler := http.NewServeMux()
+	handler.HandleFunc("/api/scripts/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+valid {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": 1}`))
+	})
+
+	server := httptest.NewTLSServer(handler)
+	defer server.Close()
+
+	exp := time.Now().Add(time.Hour)
+	inner := &countingLoginProvider{token: func(n int) string {
+		return testJWT(t, exp, fmt.Sprint(n))
+	}}
+	stale := testJWT(t, exp, "stale")
+	valid = inner.token(1)
+
+	p := NewCachedLoginProvider(inner, "ak")
+	p.Dir = dir
+	path, err := p.path(normalizeBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("failed to get cache path: %v", err)
+	}
+	if err := writeCachedToken(path, cachedToken{BaseURL: server.URL, Token: stale, Expires: exp}); err != nil {
+		t.Fatalf("failed to seed cache: %v", err)
+	}
+
+	api, err := NewLandscapeAPIClient(server.URL, p, WithHTTPClient(server.Client()))
+	if err != nil {
+		t.Fatalf("failed to init client: %v", err)
+	}
+
+	resp, err := api.GetScriptWithResponse(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetScriptWithResponse failed: %v", err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		t.Fatalf("expected HTTP 200 but received %d", resp.StatusCode())
+	}
+
+	if inner.count() != 1 {
+		t.Fatalf("expected 1 login after the cached token was rejected, got %d", inner.count())
+	}
+
+	cached, err := readCachedToken(path)
+	if err != nil {
+		t.Fatalf("failed to read cache: %v", err)
+	}
+	if cached.Token != valid {
+		t.Fatalf("expected the new token to be cached")
+	}
+}
@@ -0,0 +1,344 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type countingLoginProvider struct {
+	mu     sync.Mutex
+	logins int
+	token  func(n int) string
+	err    func(n int) error
+}
+
+func (p *countingLoginProvider) Login(ctx context.Context, c *ClientWithResponses) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.logins++
+	if p.err != nil {
+		if err := p.err(p.logins); err != nil {
+			return "", err
+		}
+	}
+	return p.token(p.logins), nil
+}
+
+func (p *countingLoginProvider) count() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.logins
+}
+
+func testJWT(t *testing.T, exp time.Time, subject string) string {
+	t.Helper()
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+	claims, err := json.Marshal(map[string]any{"exp": exp.Unix(), "sub": subject})
+	if err != nil {
+		t.Fatalf("failed to marshal claims: %v", err)
+	}
+	return header + "." + base64.RawURLEncoding.EncodeToString(claims) + ".sig"
+}
+
+func TestJWTExpiry(t *testing.T) {
+	exp := time.Unix(1893456000, 0)
+
+	got, ok := jwtExpiry(testJWT(t, exp, "a"))
+	if !ok {
+		t.Fatal("expected exp claim to be decoded")
+	}
+	if !got.Equal(exp) {
+		t.Fatalf("expected %v, got %v", exp, got)
+	}
+
+	for _, token := range []string{"", "opaque-token", "a.!!!.c", "a." + base64.RawURLEncoding.EncodeToString([]byte(`{}`)) + ".c"} {
+		if _, ok := jwtExpiry(token); ok {
+			t.Fatalf("expected no expiry for %q", token)
+		}
+	}
+}
+
+func TestTokenSourceRefresh(t *testing.T) {
+	t.Run("reuses token until it nears expiry", func(t *testing.T) {
+		provider := &countingLoginProvider{token: func(n int) string {
+			return testJWT(t, time.Now().Add(time.Hour), fmt.Sprint(n))
+		}}
+		ts := NewTokenSource(provider, nil)
+
+		for range 3 {
+			if _, err := ts.Token(context.Background()); err != nil {
+				t.Fatalf("Token failed: %v", err)
+			}
+		}
+
+		if provider.count() != 1 {
+			t.Fatalf("expected 1 login, got %d", provider.count())
+		}
+	})
+
+	t.Run("refreshes inside the refresh window", func(t *testing.T) {
+		provider := &countingLoginProvider{token: func(n int) string {
+			return testJWT(t, time.Now().Add(10*time.Second), fmt.Sprint(n))
+		}}
+		ts := NewTokenSource(provider, nil)
+
+		first, err := ts.Token(context.Background())
+		if err != nil {
+			t.Fatalf("Token failed: %v", err)
+		}
+		second, err := ts.Token(context.Background())
+		if err != nil {
+			t.Fatalf("Token failed: %v", err)
+		}
+
+		if first == second || provider.count() != 2 {
+			t.Fatalf("expected token to be refreshed, got %d logins", provider.count())
+		}
+	})
+
+	t.Run("invalidate ignores stale tokens", func(t *testing.T) {
+		provider := &countingLoginProvider{token: func(n int) string { return fmt.Sprintf("token-%d", n) }}
+		ts := NewTokenSource(provider, nil)
+
+		if _, err := ts.Token(context.Background()); err != nil {
+			t.Fatalf("Token failed: %v", err)
+		}
+		ts.Invalidate("token-0")
+		if _, err := ts.Token(context.Background()); err != nil {
+			t.Fatalf("Token failed: %v", err)
+		}
+		if provider.count() != 1 {
+			t.Fatalf("expected stale invalidation to be ignored, got %d logins", provider.count())
+		}
+
+		ts.Invalidate("token-1")
+		token, err := ts.Token(context.Background())
+		if err != nil {
+			t.Fatalf("Token failed: %v", err)
+		}
+		if token != "token-2" {
+			t.Fatalf("expected token-2 after invalidation, got %s", token)
+		}
+	})
+}
+
+// blockingLoginProvider logs in once release is closed, or fails when the
+// context is done. started receives a value as each login begins.
+type blockingLoginProvider struct {
+	started chan struct{}
+	release chan struct{}
+	logins  atomic.Int32
+}
+
+func (p *blockingLoginProvider) Login(ctx context.Context, c *ClientWithResponses) (string, error) {
+	n := p.logins.Add(1)
+	p.started <- struct{}{}
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case <-p.release:
+		return fmt.Sprintf("token-%d", n), nil
+	}
+}
+
+func TestTokenSourceConcurrentLogin(t *testing.T) {
+	type result struct {
+		token string
+		err   error
+	}
+	token := func(ts *TokenSource, ctx context.Context) <-chan result {
+		ch := make(chan result, 1)
+		go func() {
+			token, err := ts.Token(ctx)
+			ch <- result{token, err}
+		}()
+		return ch
+	}
+
+	t.Run("waiting stops when the context is done", func(t *testing.T) {
+		provider := &blockingLoginProvider{started: make(chan struct{}, 10), release: make(chan struct{})}
+		ts := NewTokenSource(provider, nil)
+
+		first := token(ts, context.Background())
+		<-provider.started
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		if _, err := ts.Token(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected context.DeadlineExceeded while the login is in flight, got %v", err)
+		}
+
+		close(provider.release)
+		if res := <-first; res.err != nil || res.token != "token-1" {
+			t.Fatalf("expected token-1, got %q: %v", res.token, res.err)
+		}
+		if n := provider.logins.Load(); n != 1 {
+			t.Fatalf("expected 1 login, got %d", n)
+		}
+	})
+
+	t.Run("waiters log in when the first caller gives up", func(t *testing.T) {
+		provider := &blockingLoginProvider{started: make(chan struct{}, 10), release: make(chan struct{})}
+		ts := NewTokenSource(provider, nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		first := token(ts, ctx)
+		<-provider.started
+
+		second := token(ts, context.Background())
+		time.Sleep(20 * time.Millisecond)
+
+		cancel()
+		if res := <-first; !errors.Is(res.err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %q: %v", res.token, res.err)
+		}
+
+		<-provider.started
+		close(provider.release)
+		if res := <-second; res.err != nil || res.token != "token-2" {
+			t.Fatalf("expected token-2, got %q: %v", res.token, res.err)
+		}
+	})
+}
+
+func TestNewLandscapeAPIClientReloginOn401(t *testing.T) {
+	handler := http.NewServeMux()
+	handler.HandleFunc("/api/script-profiles", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("failed to read body: %v", err)
+		}
+
+		var req ScriptProfileCreateBody
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Errorf("request body was not replayed: %q", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(ScriptProfileDetail{Id: 7, Title: req.Title})
+	})
+	handler.HandleFunc("/api/scripts/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(V1Script{Id: 1, Title: "diagnostic script"})
+	})
+
+	server := httptest.NewTLSServer(handler)
+	defer server.Close()
+
+	t.Run("retries request with a new token", func(t *testing.T) {
+		provider := &countingLoginProvider{token: func(n int) string { return fmt.Sprintf("token-%d", n) }}
+		api, err := NewLandscapeAPIClient(server.URL, provider, WithHTTPClient(server.Client()))
+		if err != nil {
+			t.Fatalf("failed to init client: %v", err)
+		}
+
+		resp, err := api.CreateScriptProfileWithResponse(context.Background(), ScriptProfileCreateBody{
+			ScriptId: 1,
+			Title:    "nightly",
+		})
+		if err != nil {
+			t.Fatalf("CreateScriptProfileWithResponse failed: %v", err)
+		}
+
+		if resp.StatusCode() != http.StatusCreated {
+			t.Fatalf("expected HTTP 201 but received %d", resp.StatusCode())
+		}
+
+		if resp.JSON201 == nil || resp.JSON201.Title != "nightly" {
+			t.Fatalf("unexpected payload: %s", resp.Body)
+		}
+
+		if provider.count() != 2 {
+			t.Fatalf("expected 2 logins, got %d", provider.count())
+		}
+	})
+
+	t.Run("concurrent requests share one re-login", func(t *testing.T) {
+		provider := &countingLoginProvider{token: func(n int) string { return fmt.Sprintf("token-%d", n) }}
+		api, err := NewLandscapeAPIClient(server.URL, provider, WithHTTPClient(server.Client()))
+		if err != nil {
+			t.Fatalf("failed to init client: %v", err)
+		}
+
+		var wg sync.WaitGroup
+		for range 20 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				resp, err := api.GetScriptWithResponse(context.Background(), 1)
+				if err != nil {
+					t.Errorf("GetScriptWithResponse failed: %v", err)
+					return
+				}
+				if resp.StatusCode() != http.StatusOK {
+					t.Errorf("expected HTTP 200 but received %d", resp.StatusCode())
+				}
+			}()
+		}
+		wg.Wait()
+
+		if provider.count() != 2 {
+			t.Fatalf("expected 2 logins, got %d", provider.count())
+		}
+	})
+
+	t.Run("returns the error from logging in again", func(t *testing.T) {
+		loginErr := errors.New("account disabled")
+		provider := &countingLoginProvider{
+			token: func(n int) string { return "token-1" },
+			err: func(n int) error {
+				if n > 1 {
+					return loginErr
+				}
+				return nil
+			},
+		}
+		api, err := NewLandscapeAPIClient(server.URL, provider, WithHTTPClient(server.Client()))
+		if err != nil {
+			t.Fatalf("failed to init client: %v", err)
+		}
+
+		if _, err := api.GetScriptWithResponse(context.Background(), 1); !errors.Is(err, loginErr) {
+			t.Fatalf("expected the login error, got %v", err)
+		}
+	})
+
+	t.Run("gives up after one retry", func(t *testing.T) {
+		provider := &countingLoginProvider{token: func(n int) string { return "token-1" }}
+		api, err := NewLandscapeAPIClient(server.URL, provider, WithHTTPClient(server.Client()))
+		if err != nil {
+			t.Fatalf("failed to init client: %v", err)
+		}
+
+		resp, err := api.GetScriptWithResponse(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("GetScriptWithResponse failed: %v", err)
+		}
+		if resp.StatusCode() != http.StatusUnauthorized {
+			t.Fatalf("expected HTTP 401 but received %d", resp.StatusCode())
+		}
+		if provider.count() != 2 {
+			t.Fatalf("expected 2 logins, got %d", provider.count())
+		}
+	})
+}
@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// RecurringTrigger returns a trigger that runs a script profile on the
+// schedule given by a five-field cron expression, ex. "0 3 * * *", once
+// startAfter has passed.
+func RecurringTrigger(cron string, startAfter time.Time) ScriptProfileTriggerCreateRequest {
+	return newTrigger(ScriptProfileScheduleDraftTrigger{
+		Interval:    cron,
+		StartAfter:  startAfter,
+		TriggerType: ScriptProfileScheduleDraftTriggerTriggerTypeRecurring,
+	})
+}
+
+// OneTimeTrigger returns a trigger that runs a script profile once, at t.
+func OneTimeTrigger(t time.Time) ScriptProfileTriggerCreateRequest {
+	return newTrigger(ScriptProfileOneTimeDraftTrigger{
+		Timestamp:   t,
+		TriggerType: ScriptProfileOneTimeDraftTriggerTriggerTypeOneTime,
+	})
+}
+
+// EventTrigger returns a trigger that runs a script profile on computers when
+// an event happens to them, ex. PostEnrollment.
+func EventTrigger(eventType ScriptProfileEventType) ScriptProfileTriggerCreateRequest {
+	return newTrigger(ScriptProfileEventTrigger{
+		EventType:   eventType,
+		TriggerType: Event,
+	})
+}
+
+// newTrigger marshals a trigger variant into the union. Marshaling can only
+// fail for times outside the years 0 to 9999, which leaves the trigger null
+// and gets the request rejected by Landscape.
+func newTrigger(v any) ScriptProfileTriggerCreateRequest {
+	b, _ := json.Marshal(v)
+	return ScriptProfileTriggerCreateRequest{union: b}
+}
+
+// Patch returns t as the trigger of a ScriptProfilePatchBody, replacing the
+// whole trigger of the script profile.
+func (t ScriptProfileTriggerCreateRequest) Patch() *ScriptProfileTriggerPatchRequest {
+	// Every create variant has the same JSON form as its patch variant.
+	return &ScriptProfileTriggerPatchRequest{union: t.union}
+}
+
+// ScriptProfileTrigger is the trigger of a script profile returned by
+// ScriptProfileTriggerResponse.Trigger: a ScriptProfileScheduleTrigger,
+// ScriptProfileOneTimeTrigger or ScriptProfileEventTrigger.
+type ScriptProfileTrigger interface {
+	isScriptProfileTrigger()
+}
+
+func (ScriptProfileScheduleTrigger) isScriptProfileTrigger() {}
+func (ScriptProfileOneTimeTrigger) isScriptProfileTrigger()  {}
+func (ScriptProfileEventTrigger) isScriptProfileTrigger()    {}
+
+// Trigger decodes the trigger into its concrete type, for use in a type
+// switch:
+//
+//	switch trigger := trigger.(type) {
+//	case client.ScriptProfileScheduleTrigger:
+//		fmt.Println("runs at", trigger.Interval)
+//	case client.ScriptProfileOneTimeTrigger:
+//		fmt.Println("runs once at", trigger.Timestamp)
+//	case client.ScriptProfileEventTrigger:
+//		fmt.Println("runs on", trigger.EventType)
+//	}
+func (t ScriptProfileTriggerResponse) Trigger() (ScriptProfileTrigger, error) {
+	value, err := t.ValueByDiscriminator()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode script profile trigger: %w", err)
+	}
+	return value.(ScriptProfileTrigger), nil
+}
+
+// AnyScript is a script returned by ScriptResult.Script: a V1Script or a
+// V2Script.
+type AnyScript interface {
+	isScript()
+}
+
+func (V1Script) isScript() {}
+func (V2Script) isScript() {}
+
+// Script decodes the script into a V1Script or V2Script, depending on its
+// status, for use in a type switch. V2 scripts have the status ACTIVE,
+// ARCHIVED or REDACTED, and V1 scripts the status V1.
+func (t ScriptResult) Script() (AnyScript, error) {
+	var s struct {
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(t.union, &s); err != nil {
+		return nil, fmt.Errorf("failed to decode script: %w", err)
+	}
+
+	switch s.Status {
+	case string(V1):
+		return t.AsV1Script()
+	case string(ACTIVE), string(ARCHIVED), string(REDACTED):
+		return t.AsV2Script()
+	default:
+		return nil, fmt.Errorf("failed to decode script: unknown status %q", s.Status)
+	}
+}
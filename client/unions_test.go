@@ -0,0 +1,140 @@
+package client
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// roundTrip marshals a create body with trigger and decodes its trigger as a
+// response would return it.
+func roundTrip(t *testing.T, trigger ScriptProfileTriggerCreateRequest) ScriptProfileTrigger {
+	t.Helper()
+
+	b, err := json.Marshal(ScriptProfileCreateBody{Title: "nightly", Trigger: trigger})
+	if err != nil {
+		t.Fatalf("failed to marshal create body: %v", err)
+	}
+
+	var detail ScriptProfileDetail
+	if err := json.Unmarshal(b, &detail); err != nil {
+		t.Fatalf("failed to unmarshal %s: %v", b, err)
+	}
+
+	got, err := detail.Trigger.Trigger()
+	if err != nil {
+		t.Fatalf("Trigger failed for %s: %v", b, err)
+	}
+	return got
+}
+
+func TestRecurringTrigger(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	got, ok := roundTrip(t, RecurringTrigger("0 3 * * *", start)).(ScriptProfileScheduleTrigger)
+	if !ok {
+		t.Fatalf("expected a ScriptProfileScheduleTrigger")
+	}
+	if got.Interval != "0 3 * * *" || !got.StartAfter.Equal(start) || got.TriggerType != Recurring {
+		t.Fatalf("unexpected trigger: %+v", got)
+	}
+}
+
+func TestOneTimeTrigger(t *testing.T) {
+	at := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	got, ok := roundTrip(t, OneTimeTrigger(at)).(ScriptProfileOneTimeTrigger)
+	if !ok {
+		t.Fatalf("expected a ScriptProfileOneTimeTrigger")
+	}
+	if !got.Timestamp.Equal(at) || got.TriggerType != ScriptProfileOneTimeTriggerTriggerTypeOneTime {
+		t.Fatalf("unexpected trigger: %+v", got)
+	}
+}
+
+func TestEventTrigger(t *testing.T) {
+	got, ok := roundTrip(t, EventTrigger(PostEnrollment)).(ScriptProfileEventTrigger)
+	if !ok {
+		t.Fatalf("expected a ScriptProfileEventTrigger")
+	}
+	if got.EventType != PostEnrollment || got.TriggerType != Event {
+		t.Fatalf("unexpected trigger: %+v", got)
+	}
+}
+
+func TestTriggerPatch(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	b, err := json.Marshal(ScriptProfilePatchBody{Trigger: RecurringTrigger("*/30 * * * *", start).Patch()})
+	if err != nil {
+		t.Fatalf("failed to marshal patch body: %v", err)
+	}
+
+	var body ScriptProfilePatchBody
+	if err := json.Unmarshal(b, &body); err != nil {
+		t.Fatalf("failed to unmarshal %s: %v", b, err)
+	}
+	value, err := body.Trigger.ValueByDiscriminator()
+	if err != nil {
+		t.Fatalf("ValueByDiscriminator failed for %s: %v", b, err)
+	}
+
+	got, ok := value.(ScriptProfileScheduleDraftEditTrigger)
+	if !ok {
+		t.Fatalf("expected a ScriptProfileScheduleDraftEditTrigger, got %T", value)
+	}
+	if got.Interval == nil || *got.Interval != "*/30 * * * *" || got.StartAfter == nil || !got.StartAfter.Equal(start) {
+		t.Fatalf("unexpected trigger: %+v", got)
+	}
+}
+
+func TestTriggerUnknownType(t *testing.T) {
+	var trigger ScriptProfileTriggerResponse
+	if err := json.Unmarshal([]byte(`{"trigger_type": "webhook"}`), &trigger); err != nil {
+		t.Fatalf("failed to unmarshal trigger: %v", err)
+	}
+	if _, err := trigger.Trigger(); err == nil {
+		t.Fatalf("expected an error for an unknown trigger type")
+	}
+}
+
+func TestScriptResultScript(t *testing.T) {
+	tests := []struct {
+		body string
+		v2   bool
+	}{
+		{body: `{"id": 1, "title": "legacy", "status": "V1"}`},
+		{body: `{"id": 2, "title": "deploy", "status": "ACTIVE", "version_number": 3}`, v2: true},
+		{body: `{"id": 3, "title": "old", "status": "ARCHIVED"}`, v2: true},
+	}
+
+	for _, tt := range tests {
+		var result ScriptResult
+		if err := json.Unmarshal([]byte(tt.body), &result); err != nil {
+			t.Fatalf("failed to unmarshal %s: %v", tt.body, err)
+		}
+
+		script, err := result.Script()
+		if err != nil {
+			t.Fatalf("Script failed for %s: %v", tt.body, err)
+		}
+		switch script := script.(type) {
+		case V1Script:
+			if tt.v2 || script.Title != "legacy" {
+				t.Fatalf("unexpected V1 script for %s: %+v", tt.body, script)
+			}
+		case V2Script:
+			if !tt.v2 || script.Id == 0 {
+				t.Fatalf("unexpected V2 script for %s: %+v", tt.body, script)
+			}
+		}
+	}
+
+	var result ScriptResult
+	if err := json.Unmarshal([]byte(`{"id": 4, "status": "DRAFT"}`), &result); err != nil {
+		t.Fatalf("failed to unmarshal script: %v", err)
+	}
+	if _, err := result.Script(); err == nil {
+		t.Fatalf("expected an error for an unknown status")
+	}
+}
@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/jansdhillon/landscape-go-api-client/client"
+	"github.com/urfave/cli/v3"
+)
+
+const waitFlag = "wait"
+
+var waitCliFlag = &cli.BoolFlag{
+	Name:    waitFlag,
+	Aliases: []string{"w"},
+	Usage:   "Wait for the activity to complete and print its result. Exits with an error if it failed or was canceled.",
+}
+
+// WriteActivityResponseToRoot writes an activity response like
+// WriteResponseToRoot, or if --wait was given, waits for the activity to
+// complete and writes its result instead.
+func WriteActivityResponseToRoot(ctx context.Context, cmd *cli.Command, api *client.ClientWithResponses, res *http.Response) error {
+	if !cmd.Bool(waitFlag) {
+		return WriteResponseToRoot(ctx, cmd, res)
+	}
+
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return client.NewAPIError(res, body)
+	}
+
+	activity, err := client.ParseLegacyResponse[client.Activity](body)
+	if err != nil {
+		return fmt.Errorf("failed to decode activity: %w", err)
+	}
+
+	var last client.ActivityStatus
+	result, err := client.WaitForActivity(ctx, api, activity.Id, &client.WaitOptions{
+		OnPoll: func(r *client.ActivityResult) {
+			if r.Status != last {
+				fmt.Fprintf(cmd.Root().ErrWriter, "activity %d: %s\n", r.Activity.Id, r.Status)
+				last = r.Status
+			}
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed waiting for activity %d: %w", activity.Id, err)
+	}
+
+	out, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	if err := writeOutput(cmd, out); err != nil {
+		return err
+	}
+
+	return result.Err()
+}
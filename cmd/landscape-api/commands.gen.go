@@ -0,0 +1,5770 @@
+// Code generated by commandgen. DO NOT EDIT.
+
+package main
+
+import (
+	"context"
+
+	"github.com/jansdhillon/landscape-go-api-client/client"
+	"github.com/urfave/cli/v3"
+)
+
+// generatedCommands has a command for every operation of the API client.
+var generatedCommands = []*cli.Command{
+	{
+		Name:     "accept-pending-computers",
+		Usage:    "Call the AcceptPendingComputers legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.IntSliceFlag{
+				Name:     "computer-ids",
+				Usage:    "A list of computer IDs to accept. Can be specified multiple times.",
+				Required: true,
+			},
+			&cli.StringMapFlag{
+				Name:  "existing-ids",
+				Usage: "A mapping from pending computer IDs to existing ones.",
+			},
+			&cli.StringFlag{
+				Name:  "access-group",
+				Usage: "The access group to put the computers into",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyAcceptPendingComputersParams{}
+			params.ComputerIds = cmd.IntSlice("computer-ids")
+			if cmd.IsSet("existing-ids") {
+				v, err := intMap(cmd.StringMap("existing-ids"))
+				if err != nil {
+					return err
+				}
+				params.ExistingIds = &v
+			}
+			if cmd.IsSet("access-group") {
+				v := cmd.String("access-group")
+				params.AccessGroup = &v
+			}
+			res, err := api.LegacyAcceptPendingComputers(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "add-access-groups-to-role",
+		Usage:    "Call the AddAccessGroupsToRole legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the role to modify.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:     "access-groups",
+				Usage:    "A list of names of access groups to add to the role. Can be specified multiple times.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyAddAccessGroupsToRoleParams{}
+			params.Name = cmd.String("name")
+			params.AccessGroups = cmd.StringSlice("access-groups")
+			res, err := api.LegacyAddAccessGroupsToRole(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "add-annotation-to-computers",
+		Usage:    "Call the AddAnnotationToComputers legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "query",
+				Usage:    "A query string used to select the computers to which to add the annotation.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "key",
+				Usage:    "Annotation key to add to the selected computers.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "value",
+				Usage: "Annotation value associated with the provided key to add to the selected computers.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyAddAnnotationToComputersParams{}
+			params.Query = cmd.String("query")
+			params.Key = cmd.String("key")
+			if cmd.IsSet("value") {
+				v := cmd.String("value")
+				params.Value = &v
+			}
+			res, err := api.LegacyAddAnnotationToComputers(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "add-apt-sources-to-repository-profile",
+		Usage:    "Call the AddAPTSourcesToRepositoryProfile legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "Name of the repository profile.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:     "apt-sources",
+				Usage:    "The names of the APT sources to add. Can be specified multiple times.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyAddAPTSourcesToRepositoryProfileParams{}
+			params.Name = cmd.String("name")
+			params.AptSources = cmd.StringSlice("apt-sources")
+			res, err := api.LegacyAddAPTSourcesToRepositoryProfile(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "add-package-filters-to-pocket",
+		Usage:    "Call the AddPackageFiltersToPocket legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the pocket to operate on.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "series",
+				Usage:    "The name of the series containing the pocket.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "distribution",
+				Usage:    "The name of the distribution containing the series.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:     "packages",
+				Usage:    "A list of names of packages to be added or removed from the pocket filter. Can be specified multiple times.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyAddPackageFiltersToPocketParams{}
+			params.Name = cmd.String("name")
+			params.Series = cmd.String("series")
+			params.Distribution = cmd.String("distribution")
+			params.Packages = cmd.StringSlice("packages")
+			res, err := api.LegacyAddPackageFiltersToPocket(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "add-permissions-to-role",
+		Usage:    "Call the AddPermissionsToRole legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the role to modify.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:     "permissions",
+				Usage:    "A list of permissions to add. Can be specified multiple times.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyAddPermissionsToRoleParams{}
+			params.Name = cmd.String("name")
+			params.Permissions = cmd.StringSlice("permissions")
+			res, err := api.LegacyAddPermissionsToRole(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "add-persons-to-role",
+		Usage:    "Call the AddPersonsToRole legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the role to modify.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:     "persons",
+				Usage:    "A list of emails of persons to add. Can be specified multiple times.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyAddPersonsToRoleParams{}
+			params.Name = cmd.String("name")
+			params.Persons = cmd.StringSlice("persons")
+			res, err := api.LegacyAddPersonsToRole(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "add-pockets-to-repository-profile",
+		Usage:    "Call the AddPocketsToRepositoryProfile legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "Name of the repository profile.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:     "pockets",
+				Usage:    "The names of the pockets to add. Can be specified multiple times.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "series",
+				Usage:    "The name of the series the pockets belongs to.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "distribution",
+				Usage:    "The name of the distribution the series belongs to.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyAddPocketsToRepositoryProfileParams{}
+			params.Name = cmd.String("name")
+			params.Pockets = cmd.StringSlice("pockets")
+			params.Series = cmd.String("series")
+			params.Distribution = cmd.String("distribution")
+			res, err := api.LegacyAddPocketsToRepositoryProfile(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "add-tags-to-computers",
+		Usage:    "Call the AddTagsToComputers legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "query",
+				Usage:    "A query string used to select the computers to add tags to.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:     "tags",
+				Usage:    "Tag names to be applied. Can be specified multiple times.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyAddTagsToComputersParams{}
+			params.Query = cmd.String("query")
+			params.Tags = cmd.StringSlice("tags")
+			res, err := api.LegacyAddTagsToComputers(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "add-uploader-gpg-keys-to-pocket",
+		Usage:    "Call the AddUploaderGPGKeysToPocket legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the pocket on which to associate keys.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "series",
+				Usage:    "The name of the series containing the pocket.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "distribution",
+				Usage:    "The name of the distribution containing the series.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:     "gpg-keys",
+				Usage:    "A list of GPG keys on which to operate. Can be specified multiple times.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyAddUploaderGPGKeysToPocketParams{}
+			params.Name = cmd.String("name")
+			params.Series = cmd.String("series")
+			params.Distribution = cmd.String("distribution")
+			params.GpgKeys = cmd.StringSlice("gpg-keys")
+			res, err := api.LegacyAddUploaderGPGKeysToPocket(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "approve-activities",
+		Usage:    "Call the ApproveActivities legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "query",
+				Usage:    "A query string used to select activities on which to operate.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyApproveActivitiesParams{}
+			params.Query = cmd.String("query")
+			res, err := api.LegacyApproveActivities(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:      "archive-script",
+		Usage:     "Call POST /api/scripts/{script_id}:archive.",
+		Category:  "REST API",
+		ArgsUsage: "<script-id>",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			scriptId, err := intArg(cmd, 0, "script-id")
+			if err != nil {
+				return err
+			}
+			res, err := api.ArchiveScript(ctx, scriptId)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:      "archive-script-profile",
+		Usage:     "Call POST /api/script-profiles/{script_profile_id}:archive.",
+		Category:  "REST API",
+		ArgsUsage: "<script-profile-id>",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			scriptProfileId, err := intArg(cmd, 0, "script-profile-id")
+			if err != nil {
+				return err
+			}
+			res, err := api.ArchiveScriptProfile(ctx, scriptProfileId)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "associate-alert",
+		Usage:    "Call the AssociateAlert legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "Name of the entity.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:  "tags",
+				Usage: "Tags to change entity association for Can be specified multiple times.",
+			},
+			&cli.BoolFlag{
+				Name:  "all-computers",
+				Usage: "If true, change the 'all_computers' flag state for the entity. If the flag is enabled, associated tags will be kept, but they will not be effective until the flag is disabled.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyAssociateAlertParams{}
+			params.Name = cmd.String("name")
+			if cmd.IsSet("tags") {
+				v := cmd.StringSlice("tags")
+				params.Tags = &v
+			}
+			if cmd.IsSet("all-computers") {
+				v := cmd.Bool("all-computers")
+				params.AllComputers = &v
+			}
+			res, err := api.LegacyAssociateAlert(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "associate-package-profile",
+		Usage:    "Call the AssociatePackageProfile legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "Name of the entity.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:  "tags",
+				Usage: "Tags to change entity association for Can be specified multiple times.",
+			},
+			&cli.BoolFlag{
+				Name:  "all-computers",
+				Usage: "If true, change the 'all_computers' flag state for the entity. If the flag is enabled, associated tags will be kept, but they will not be effective until the flag is disabled.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyAssociatePackageProfileParams{}
+			params.Name = cmd.String("name")
+			if cmd.IsSet("tags") {
+				v := cmd.StringSlice("tags")
+				params.Tags = &v
+			}
+			if cmd.IsSet("all-computers") {
+				v := cmd.Bool("all-computers")
+				params.AllComputers = &v
+			}
+			res, err := api.LegacyAssociatePackageProfile(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "associate-removal-profile",
+		Usage:    "Call the AssociateRemovalProfile legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "Name of the entity.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:  "tags",
+				Usage: "Tags to change entity association for Can be specified multiple times.",
+			},
+			&cli.BoolFlag{
+				Name:  "all-computers",
+				Usage: "If true, change the 'all_computers' flag state for the entity. If the flag is enabled, associated tags will be kept, but they will not be effective until the flag is disabled.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyAssociateRemovalProfileParams{}
+			params.Name = cmd.String("name")
+			if cmd.IsSet("tags") {
+				v := cmd.StringSlice("tags")
+				params.Tags = &v
+			}
+			if cmd.IsSet("all-computers") {
+				v := cmd.Bool("all-computers")
+				params.AllComputers = &v
+			}
+			res, err := api.LegacyAssociateRemovalProfile(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "associate-repository-profile",
+		Usage:    "Call the AssociateRepositoryProfile legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "Name of the entity.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:  "tags",
+				Usage: "Tags to change entity association for Can be specified multiple times.",
+			},
+			&cli.BoolFlag{
+				Name:  "all-computers",
+				Usage: "If true, change the 'all_computers' flag state for the entity. If the flag is enabled, associated tags will be kept, but they will not be effective until the flag is disabled.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyAssociateRepositoryProfileParams{}
+			params.Name = cmd.String("name")
+			if cmd.IsSet("tags") {
+				v := cmd.StringSlice("tags")
+				params.Tags = &v
+			}
+			if cmd.IsSet("all-computers") {
+				v := cmd.Bool("all-computers")
+				params.AllComputers = &v
+			}
+			res, err := api.LegacyAssociateRepositoryProfile(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "associate-upgrade-profile",
+		Usage:    "Call the AssociateUpgradeProfile legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "Name of the entity.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:  "tags",
+				Usage: "Tags to change entity association for Can be specified multiple times.",
+			},
+			&cli.BoolFlag{
+				Name:  "all-computers",
+				Usage: "If true, change the 'all_computers' flag state for the entity. If the flag is enabled, associated tags will be kept, but they will not be effective until the flag is disabled.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyAssociateUpgradeProfileParams{}
+			params.Name = cmd.String("name")
+			if cmd.IsSet("tags") {
+				v := cmd.StringSlice("tags")
+				params.Tags = &v
+			}
+			if cmd.IsSet("all-computers") {
+				v := cmd.Bool("all-computers")
+				params.AllComputers = &v
+			}
+			res, err := api.LegacyAssociateUpgradeProfile(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "cancel-activities",
+		Usage:    "Call the CancelActivities legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "query",
+				Usage:    "A query string used to select activities on which to operate.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyCancelActivitiesParams{}
+			params.Query = cmd.String("query")
+			res, err := api.LegacyCancelActivities(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "change-computers-access-group",
+		Usage:    "Call the ChangeComputersAccessGroup legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "query",
+				Usage:    "A query string used to select the computers to change access group for.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "access-group",
+				Usage:    "The name of the access group to assign selected computers to.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyChangeComputersAccessGroupParams{}
+			params.Query = cmd.String("query")
+			params.AccessGroup = cmd.String("access-group")
+			res, err := api.LegacyChangeComputersAccessGroup(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "copy-package-profile",
+		Usage:    "Call the CopyPackageProfile legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "A name of the existing package profile to copy.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "destination-name",
+				Usage: "The profile name of the copied package profile.",
+			},
+			&cli.StringFlag{
+				Name:  "title",
+				Usage: "A title for the new profile. If not specified, the title of the source profile is used.",
+			},
+			&cli.StringFlag{
+				Name:  "description",
+				Usage: "A description for the new profile. If not specified, the title of the source profile is used.",
+			},
+			&cli.StringFlag{
+				Name:  "access-group",
+				Usage: "Name of the access group to copy the profile to. Defaults to the origin's access group.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyCopyPackageProfileParams{}
+			params.Name = cmd.String("name")
+			if cmd.IsSet("destination-name") {
+				v := cmd.String("destination-name")
+				params.DestinationName = &v
+			}
+			if cmd.IsSet("title") {
+				v := cmd.String("title")
+				params.Title = &v
+			}
+			if cmd.IsSet("description") {
+				v := cmd.String("description")
+				params.Description = &v
+			}
+			if cmd.IsSet("access-group") {
+				v := cmd.String("access-group")
+				params.AccessGroup = &v
+			}
+			res, err := api.LegacyCopyPackageProfile(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "copy-role",
+		Usage:    "Call the CopyRole legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the existing role.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "destination-name",
+				Usage:    "The name of the new role.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "description",
+				Usage: "The description of the new role.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyCopyRoleParams{}
+			params.Name = cmd.String("name")
+			params.DestinationName = cmd.String("destination-name")
+			if cmd.IsSet("description") {
+				v := cmd.String("description")
+				params.Description = &v
+			}
+			res, err := api.LegacyCopyRole(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "copy-script",
+		Usage:    "Call the CopyScript legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:     "script-id",
+				Usage:    "The identity of the existing script.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "destination-title",
+				Usage:    "The title of the new script.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "access-group",
+				Usage: "The access group for the new script. It defaults to the same access group as the existing script.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyCopyScriptParams{}
+			params.ScriptId = cmd.Int("script-id")
+			params.DestinationTitle = cmd.String("destination-title")
+			if cmd.IsSet("access-group") {
+				v := cmd.String("access-group")
+				params.AccessGroup = &v
+			}
+			res, err := api.LegacyCopyScript(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "create-access-group",
+		Usage:    "Call the CreateAccessGroup legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "title",
+				Usage:    "The title of the access group.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "parent",
+				Usage: "The title of the parent access group.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyCreateAccessGroupParams{}
+			params.Title = cmd.String("title")
+			if cmd.IsSet("parent") {
+				v := cmd.String("parent")
+				params.Parent = &v
+			}
+			res, err := api.LegacyCreateAccessGroup(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "create-apt-source",
+		Usage:    "Call the CreateAPTSource legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "Name of the APT source. It must be unique within the account, start with an alphanumeric character and only contain lowercase letters, numbers and - or + signs.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "apt-line",
+				Usage:    "The APT line of the source.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "gpg-key",
+				Usage: "Name of the GPG key used to sign the repository",
+			},
+			&cli.StringFlag{
+				Name:  "access-group",
+				Usage: "An optional name of the access group to create the APT source into.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyCreateAPTSourceParams{}
+			params.Name = cmd.String("name")
+			params.AptLine = cmd.String("apt-line")
+			if cmd.IsSet("gpg-key") {
+				v := cmd.String("gpg-key")
+				params.GpgKey = &v
+			}
+			if cmd.IsSet("access-group") {
+				v := cmd.String("access-group")
+				params.AccessGroup = &v
+			}
+			res, err := api.LegacyCreateAPTSource(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "create-child-computer",
+		Usage:    "Call the CreateChildComputer legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "computer-name",
+				Usage:    "The name of child computer to create.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "cloud-init",
+				Usage: "b64 encoded cloud init file contents.",
+			},
+			&cli.StringFlag{
+				Name:  "rootfs-url",
+				Usage: "URL to a WSL rootfs image to download and import from.",
+			},
+			&cli.IntFlag{
+				Name:     "parent-id",
+				Usage:    "The id of the parent computer.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyCreateChildComputerParams{}
+			params.ComputerName = cmd.String("computer-name")
+			if cmd.IsSet("cloud-init") {
+				v := cmd.String("cloud-init")
+				params.CloudInit = &v
+			}
+			if cmd.IsSet("rootfs-url") {
+				v := cmd.String("rootfs-url")
+				params.RootfsUrl = &v
+			}
+			params.ParentId = cmd.Int("parent-id")
+			res, err := api.LegacyCreateChildComputer(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "create-distribution",
+		Usage:    "Call the CreateDistribution legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the distribution. It must be unique within the account, start with an alphanumeric character and only contain lowercase letters, numbers and - or + signs.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "access-group",
+				Usage: "An optional name of the access group to create the distribution into.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyCreateDistributionParams{}
+			params.Name = cmd.String("name")
+			if cmd.IsSet("access-group") {
+				v := cmd.String("access-group")
+				params.AccessGroup = &v
+			}
+			res, err := api.LegacyCreateDistribution(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "create-package-profile",
+		Usage:    "Call the CreatePackageProfile legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "title",
+				Usage:    "The title of the package profile to create.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "description",
+				Usage:    "The description of the new profile.",
+				Required: true,
+			},
+			&cli.IntFlag{
+				Name:  "source-computer-id",
+				Usage: "A computer ID to find a computer which will be used as the basis of the package profile.",
+			},
+			&cli.StringFlag{
+				Name:  "material",
+				Usage: "Package data in the format of 'dpkg --get-selections' or CSV (as exported by Landscape).",
+			},
+			&cli.StringSliceFlag{
+				Name:  "constraints",
+				Usage: "Alternative to material, constraint specifications in the form of \"depends packagename\" or \"conflicts packagename < 1.0\". Can be specified multiple times.",
+			},
+			&cli.StringFlag{
+				Name:  "access-group",
+				Usage: "Optional name of the access group to create the profile into",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyCreatePackageProfileParams{}
+			params.Title = cmd.String("title")
+			params.Description = cmd.String("description")
+			if cmd.IsSet("source-computer-id") {
+				v := cmd.Int("source-computer-id")
+				params.SourceComputerId = &v
+			}
+			if cmd.IsSet("material") {
+				v := cmd.String("material")
+				params.Material = &v
+			}
+			if cmd.IsSet("constraints") {
+				v := cmd.StringSlice("constraints")
+				params.Constraints = &v
+			}
+			if cmd.IsSet("access-group") {
+				v := cmd.String("access-group")
+				params.AccessGroup = &v
+			}
+			res, err := api.LegacyCreatePackageProfile(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "create-pocket",
+		Usage:    "Call the CreatePocket legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the pocket. It must be unique within series, start with an alphanumeric character and only contain lowercase letters, numbers and - or + signs.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "series",
+				Usage:    "The name of the series to create the pocket in.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "distribution",
+				Usage:    "The name of the distribution the series belongs to.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:     "components",
+				Usage:    "A list of components the pocket will handle. Can be specified multiple times.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:     "architectures",
+				Usage:    "A list of architectures the pocket will handle. Can be specified multiple times.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "mode",
+				Usage:    "The pocket mode. Can be 'pull', 'mirror' and 'upload'.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "gpg-key",
+				Usage:    "The name of the GPG key to use to sign packages lists for this pocket. The GPG key provided must have a private key associated with it.",
+				Required: true,
+			},
+			&cli.BoolFlag{
+				Name:  "include-udeb",
+				Usage: "Whether the pocket should include selected components also for .udeb packages (debian-installer). It's 'false' by default.",
+			},
+			&cli.StringFlag{
+				Name:  "mirror-uri",
+				Usage: "The URI to mirror for pockets in 'mirror' mode.",
+			},
+			&cli.StringFlag{
+				Name:  "mirror-suite",
+				Usage: "The repository entry under dists/ to mirror for pockets in 'mirror' mode. This parameter is optional and defaults to the same name as local series and pocket. If the suite name ends with a '/', the remote repository is flat (packages are not grouped in components); in this case a single value can be passed for the 'components' parameter. Packages from the remote repository will be mirrored in the specified component.",
+			},
+			&cli.StringFlag{
+				Name:  "mirror-gpg-key",
+				Usage: "The name of the GPG key to use to verify the mirrored archive signature. If none is given, the stock Ubuntu archive one will be used.",
+			},
+			&cli.StringFlag{
+				Name:  "pull-series",
+				Usage: "The name of the series pull_pocket belongs to. Must be a series in the same distribution series belongs to. If not specified, it defaults to series.",
+			},
+			&cli.StringFlag{
+				Name:  "pull-pocket",
+				Usage: "The name of a pocket in current distribution to sync packages from for pockets in 'pull' mode.",
+			},
+			&cli.StringFlag{
+				Name:  "filter-type",
+				Usage: "If specified, the type of the filter of the pocket. Can be either 'allowlist' or 'blocklist' (Deprecated blacklist and whitelist).",
+			},
+			&cli.StringSliceFlag{
+				Name:  "filter-packages",
+				Usage: "If specified, the package filters to a repository pocket. The pocket must be in pull mode and support blocklist / allowlist filtering (Deprecated blacklist and whitelist). Can be specified multiple times.",
+			},
+			&cli.BoolFlag{
+				Name:  "upload-allow-unsigned",
+				Usage: "For pockets in upload mode, a boolean indicating whether uploaded packages are required to be signed or not. It's 'false' by default.",
+			},
+			&cli.StringFlag{
+				Name:  "origin",
+				Usage: "The origin of this pocket",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyCreatePocketParams{}
+			params.Name = cmd.String("name")
+			params.Series = cmd.String("series")
+			params.Distribution = cmd.String("distribution")
+			params.Components = cmd.StringSlice("components")
+			params.Architectures = cmd.StringSlice("architectures")
+			params.Mode = cmd.String("mode")
+			params.GpgKey = cmd.String("gpg-key")
+			if cmd.IsSet("include-udeb") {
+				v := cmd.Bool("include-udeb")
+				params.IncludeUdeb = &v
+			}
+			if cmd.IsSet("mirror-uri") {
+				v := cmd.String("mirror-uri")
+				params.MirrorUri = &v
+			}
+			if cmd.IsSet("mirror-suite") {
+				v := cmd.String("mirror-suite")
+				params.MirrorSuite = &v
+			}
+			if cmd.IsSet("mirror-gpg-key") {
+				v := cmd.String("mirror-gpg-key")
+				params.MirrorGpgKey = &v
+			}
+			if cmd.IsSet("pull-series") {
+				v := cmd.String("pull-series")
+				params.PullSeries = &v
+			}
+			if cmd.IsSet("pull-pocket") {
+				v := cmd.String("pull-pocket")
+				params.PullPocket = &v
+			}
+			if cmd.IsSet("filter-type") {
+				v := cmd.String("filter-type")
+				params.FilterType = &v
+			}
+			if cmd.IsSet("filter-packages") {
+				v := cmd.StringSlice("filter-packages")
+				params.FilterPackages = &v
+			}
+			if cmd.IsSet("upload-allow-unsigned") {
+				v := cmd.Bool("upload-allow-unsigned")
+				params.UploadAllowUnsigned = &v
+			}
+			if cmd.IsSet("origin") {
+				v := cmd.String("origin")
+				params.Origin = &v
+			}
+			res, err := api.LegacyCreatePocket(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "create-removal-profile",
+		Usage:    "Call the CreateRemovalProfile legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "title",
+				Usage:    "The title of the profile to create.",
+				Required: true,
+			},
+			&cli.IntFlag{
+				Name:     "days-without-exchange",
+				Usage:    "The length of time after which a computer may be removed.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "access-group",
+				Usage: "An optional name of an access group the profile will apply to.",
+			},
+			&cli.BoolFlag{
+				Name:  "cascade-to-children",
+				Usage: "If true, removed computers will also include child computers (e.g. virtual machines, WSL instances).",
+			},
+			&cli.StringSliceFlag{
+				Name:  "tags",
+				Usage: "Computer tags to associate with the removal profile. Can be specified multiple times.",
+			},
+			&cli.BoolFlag{
+				Name:  "all-computers",
+				Usage: "Whether to associate the removal profile with all computers.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyCreateRemovalProfileParams{}
+			params.Title = cmd.String("title")
+			params.DaysWithoutExchange = cmd.Int("days-without-exchange")
+			if cmd.IsSet("access-group") {
+				v := cmd.String("access-group")
+				params.AccessGroup = &v
+			}
+			if cmd.IsSet("cascade-to-children") {
+				v := cmd.Bool("cascade-to-children")
+				params.CascadeToChildren = &v
+			}
+			if cmd.IsSet("tags") {
+				v := cmd.StringSlice("tags")
+				params.Tags = &v
+			}
+			if cmd.IsSet("all-computers") {
+				v := cmd.Bool("all-computers")
+				params.AllComputers = &v
+			}
+			res, err := api.LegacyCreateRemovalProfile(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "create-repository-profile",
+		Usage:    "Call the CreateRepositoryProfile legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "title",
+				Usage:    "Title of the repository profile. It must start with an alphanumeric character and only contain lowercase letters, numbers and - or + signs.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "description",
+				Usage: "Description of the repository profile.",
+			},
+			&cli.StringFlag{
+				Name:  "access-group",
+				Usage: "Optional name of the access group to create the profile in.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyCreateRepositoryProfileParams{}
+			params.Title = cmd.String("title")
+			if cmd.IsSet("description") {
+				v := cmd.String("description")
+				params.Description = &v
+			}
+			if cmd.IsSet("access-group") {
+				v := cmd.String("access-group")
+				params.AccessGroup = &v
+			}
+			res, err := api.LegacyCreateRepositoryProfile(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "create-role",
+		Usage:    "Call the CreateRole legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the role.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "description",
+				Usage: "The description of the role.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyCreateRoleParams{}
+			params.Name = cmd.String("name")
+			if cmd.IsSet("description") {
+				v := cmd.String("description")
+				params.Description = &v
+			}
+			res, err := api.LegacyCreateRole(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "create-saved-search",
+		Usage:    "Call the CreateSavedSearch legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "name",
+				Usage: "The \"slug\" name for this saved search. It must consist of only lowercase ASCII letters, numbers and hyphens. This is the text which must be used when using the \"search:name\" syntax. If this parameter is not included a name will be generated automatically based on the title.",
+			},
+			&cli.StringFlag{
+				Name:     "title",
+				Usage:    "The display name for the SavedSearch.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "search",
+				Usage:    "The search string to save.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyCreateSavedSearchParams{}
+			if cmd.IsSet("name") {
+				v := cmd.String("name")
+				params.Name = &v
+			}
+			params.Title = cmd.String("title")
+			params.Search = cmd.String("search")
+			res, err := api.LegacyCreateSavedSearch(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "create-script",
+		Usage:    "Call the CreateScript legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "title",
+				Usage:    "The title of the new script.",
+				Required: true,
+			},
+			&cli.IntFlag{
+				Name:  "time-limit",
+				Usage: "Amount of time to wait for the process to end.",
+			},
+			&cli.StringFlag{
+				Name:     "code",
+				Usage:    "The filename holding the script contents.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "username",
+				Usage: "The user to execute the script as.",
+			},
+			&cli.StringFlag{
+				Name:  "access-group",
+				Usage: "The access group for the new script.",
+			},
+			&cli.StringFlag{
+				Name:  "script-type",
+				Usage: "The type of script to create (V1 or V2).",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyCreateScriptParams{}
+			params.Title = cmd.String("title")
+			if cmd.IsSet("time-limit") {
+				v := cmd.Int("time-limit")
+				params.TimeLimit = &v
+			}
+			params.Code = cmd.String("code")
+			if cmd.IsSet("username") {
+				v := cmd.String("username")
+				params.Username = &v
+			}
+			if cmd.IsSet("access-group") {
+				v := cmd.String("access-group")
+				params.AccessGroup = &v
+			}
+			if cmd.IsSet("script-type") {
+				v := cmd.String("script-type")
+				params.ScriptType = &v
+			}
+			res, err := api.LegacyCreateScript(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "create-script-attachment",
+		Usage:    "Call the CreateScriptAttachment legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:     "script-id",
+				Usage:    "The identity of the script to add the attachment to.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "file",
+				Usage:    "The file to attach",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyCreateScriptAttachmentParams{}
+			params.ScriptId = cmd.Int("script-id")
+			params.File = cmd.String("file")
+			res, err := api.LegacyCreateScriptAttachment(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "create-script-profile",
+		Usage:    "Call POST /api/script-profiles.",
+		Category: "REST API",
+		Flags: []cli.Flag{
+			bodyCliFlag,
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			body, err := requestBody(cmd)
+			if err != nil {
+				return err
+			}
+			res, err := api.CreateScriptProfileWithBody(ctx, "application/json", body)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "create-series",
+		Usage:    "Call the CreateSeries legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the series. It must be unique within series within the distribution, start with an alphanumeric character and only contain lowercase letters, numbers and - or + signs.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "distribution",
+				Usage:    "The name of the distribution to create the series in.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:  "pockets",
+				Usage: "Pockets that will be created in the series, they will be in mirror mode by default. Can be specified multiple times.",
+			},
+			&cli.StringSliceFlag{
+				Name:  "components",
+				Usage: "List of components for the created pockets. This parameter is **optional** if no pocket is specified. Can be specified multiple times.",
+			},
+			&cli.StringSliceFlag{
+				Name:  "architectures",
+				Usage: "List of architectures for the created pockets. This parameter is **optional** if no pocket is specified Can be specified multiple times.",
+			},
+			&cli.StringFlag{
+				Name:  "gpg-key",
+				Usage: "The name of the GPG key to use to sign packages lists of the created pockets. This parameter is **optional** if no pocket is specified.",
+			},
+			&cli.StringFlag{
+				Name:  "mirror-uri",
+				Usage: "The URI to mirror for the created pockets. This parameter is **optional** if no pocket is specified.",
+			},
+			&cli.StringFlag{
+				Name:  "mirror-series",
+				Usage: "The remote series to mirror. If not specified, it defaults to the name of the series being created. If a pockets parameter also passed, each of the created pockets will mirror the relevant dists/<mirror_series>-<pocket> repository of the remote archive.",
+			},
+			&cli.StringFlag{
+				Name:  "mirror-gpg-key",
+				Usage: "The name of the GPG key to use to verify the mirrored repositories for created pockets. If none is given, the stock Ubuntu archive one will be used.",
+			},
+			&cli.BoolFlag{
+				Name:  "include-udeb",
+				Usage: "Whether the pocket should include selected components also for .udeb packages (debian-installer). It's 'false' by default.",
+			},
+			&cli.StringFlag{
+				Name:  "origin",
+				Usage: "The origin of the created pocket",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyCreateSeriesParams{}
+			params.Name = cmd.String("name")
+			params.Distribution = cmd.String("distribution")
+			if cmd.IsSet("pockets") {
+				v := cmd.StringSlice("pockets")
+				params.Pockets = &v
+			}
+			if cmd.IsSet("components") {
+				v := cmd.StringSlice("components")
+				params.Components = &v
+			}
+			if cmd.IsSet("architectures") {
+				v := cmd.StringSlice("architectures")
+				params.Architectures = &v
+			}
+			if cmd.IsSet("gpg-key") {
+				v := cmd.String("gpg-key")
+				params.GpgKey = &v
+			}
+			if cmd.IsSet("mirror-uri") {
+				v := cmd.String("mirror-uri")
+				params.MirrorUri = &v
+			}
+			if cmd.IsSet("mirror-series") {
+				v := cmd.String("mirror-series")
+				params.MirrorSeries = &v
+			}
+			if cmd.IsSet("mirror-gpg-key") {
+				v := cmd.String("mirror-gpg-key")
+				params.MirrorGpgKey = &v
+			}
+			if cmd.IsSet("include-udeb") {
+				v := cmd.Bool("include-udeb")
+				params.IncludeUdeb = &v
+			}
+			if cmd.IsSet("origin") {
+				v := cmd.String("origin")
+				params.Origin = &v
+			}
+			res, err := api.LegacyCreateSeries(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "create-upgrade-profile",
+		Usage:    "Call the CreateUpgradeProfile legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "title",
+				Usage:    "A human readable title for this upgrade profile.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "every",
+				Usage:    "The frequency at which you wish this upgrade profile to be executed. Valid choices are \"hour\" and \"week\".",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:  "on-days",
+				Usage: "A list of days of the week on which the upgrade profile will be run. The day names must be abbreviated to their first two letters, as: \"mo\", \"tu\", \"we\", \"th\", \"fr\", \"sa\", \"su\". Required when the every parameter is \"week\" but optional when the every parameter is \"hour\". Can be specified multiple times.",
+			},
+			&cli.IntFlag{
+				Name:  "at-hour",
+				Usage: "The hour, in 24h format, at which the upgrade profile will be run.",
+			},
+			&cli.IntFlag{
+				Name:     "at-minute",
+				Usage:    "The minute of the hour (0-59) at which the upgrade profile will be run.",
+				Required: true,
+			},
+			&cli.IntFlag{
+				Name:  "deliver-within",
+				Usage: "An optional number of hours within which the upgrade task should be delivered to computers. The window will be from the time specified by this API call (on_days, at_hour, at_minute) until the provided number of hours later. Defaults to 1 hour.",
+			},
+			&cli.IntFlag{
+				Name:  "deliver-delay-window",
+				Usage: "Randomise delivery within the given timeframe specified in minutes.",
+			},
+			&cli.BoolFlag{
+				Name:  "security-upgrade",
+				Usage: "(Deprecated) Whether this upgrade is a security upgrade or not.",
+			},
+			&cli.StringFlag{
+				Name:  "upgrade-type",
+				Usage: "The type of upgrade profile, either \"security\" or \"all\".",
+			},
+			&cli.BoolFlag{
+				Name:  "autoremove",
+				Usage: "Whether this upgrade should also autoremove old packages.",
+			},
+			&cli.StringFlag{
+				Name:  "access-group",
+				Usage: "An optional name of the access group to create the profile into.",
+			},
+			&cli.StringSliceFlag{
+				Name:  "tags",
+				Usage: "Computer tags to associate with the upgrade profile. Can be specified multiple times.",
+			},
+			&cli.BoolFlag{
+				Name:  "all-computers",
+				Usage: "Whether to associate the upgrade profile with all computers.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyCreateUpgradeProfileParams{}
+			params.Title = cmd.String("title")
+			params.Every = cmd.String("every")
+			if cmd.IsSet("on-days") {
+				v := cmd.StringSlice("on-days")
+				params.OnDays = &v
+			}
+			if cmd.IsSet("at-hour") {
+				v := cmd.Int("at-hour")
+				params.AtHour = &v
+			}
+			params.AtMinute = cmd.Int("at-minute")
+			if cmd.IsSet("deliver-within") {
+				v := cmd.Int("deliver-within")
+				params.DeliverWithin = &v
+			}
+			if cmd.IsSet("deliver-delay-window") {
+				v := cmd.Int("deliver-delay-window")
+				params.DeliverDelayWindow = &v
+			}
+			if cmd.IsSet("security-upgrade") {
+				v := cmd.Bool("security-upgrade")
+				params.SecurityUpgrade = &v
+			}
+			if cmd.IsSet("upgrade-type") {
+				v := cmd.String("upgrade-type")
+				params.UpgradeType = &v
+			}
+			if cmd.IsSet("autoremove") {
+				v := cmd.Bool("autoremove")
+				params.Autoremove = &v
+			}
+			if cmd.IsSet("access-group") {
+				v := cmd.String("access-group")
+				params.AccessGroup = &v
+			}
+			if cmd.IsSet("tags") {
+				v := cmd.StringSlice("tags")
+				params.Tags = &v
+			}
+			if cmd.IsSet("all-computers") {
+				v := cmd.Bool("all-computers")
+				params.AllComputers = &v
+			}
+			res, err := api.LegacyCreateUpgradeProfile(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "create-user",
+		Usage:    "Call the CreateUser legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.IntSliceFlag{
+				Name:     "computer-ids",
+				Usage:    "The numerical IDs of the computers. Can be specified multiple times.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "username",
+				Usage:    "The username of the new user.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The title name of the new user.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "password",
+				Usage:    "The password of the new user.",
+				Required: true,
+			},
+			&cli.BoolFlag{
+				Name:  "require-password-reset",
+				Usage: "Requires the user to reset their password on first login",
+			},
+			&cli.StringFlag{
+				Name:  "primary-groupname",
+				Usage: "The group the new user will be assigned to.",
+			},
+			&cli.StringFlag{
+				Name:  "location",
+				Usage: "The location of the new user.",
+			},
+			&cli.StringFlag{
+				Name:  "home-phone",
+				Usage: "The home phone number of the new user.",
+			},
+			&cli.StringFlag{
+				Name:  "work-phone",
+				Usage: "The work phone number of the new user.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyCreateUserParams{}
+			params.ComputerIds = cmd.IntSlice("computer-ids")
+			params.Username = cmd.String("username")
+			params.Name = cmd.String("name")
+			params.Password = cmd.String("password")
+			if cmd.IsSet("require-password-reset") {
+				v := cmd.Bool("require-password-reset")
+				params.RequirePasswordReset = &v
+			}
+			if cmd.IsSet("primary-groupname") {
+				v := cmd.String("primary-groupname")
+				params.PrimaryGroupname = &v
+			}
+			if cmd.IsSet("location") {
+				v := cmd.String("location")
+				params.Location = &v
+			}
+			if cmd.IsSet("home-phone") {
+				v := cmd.String("home-phone")
+				params.HomePhone = &v
+			}
+			if cmd.IsSet("work-phone") {
+				v := cmd.String("work-phone")
+				params.WorkPhone = &v
+			}
+			res, err := api.LegacyCreateUser(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "delete-child-computers",
+		Usage:    "Call the DeleteChildComputers legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.IntSliceFlag{
+				Name:     "computer-ids",
+				Usage:    "A list of child computer ids to delete. Can be specified multiple times.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyDeleteChildComputersParams{}
+			params.ComputerIds = cmd.IntSlice("computer-ids")
+			res, err := api.LegacyDeleteChildComputers(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "derive-series",
+		Usage:    "Call the DeriveSeries legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the derived series. It must be unique within the distribution, start with an alphanumeric character and only contain lowercase letters, numbers and - or + signs.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "origin",
+				Usage:    "The name of the origin series.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "distribution",
+				Usage:    "The name of the distribution to derive the series in.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyDeriveSeriesParams{}
+			params.Name = cmd.String("name")
+			params.Origin = cmd.String("origin")
+			params.Distribution = cmd.String("distribution")
+			res, err := api.LegacyDeriveSeries(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "diff-pull-pocket",
+		Usage:    "Call the DiffPullPocket legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the pocket.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "series",
+				Usage:    "The name of the series.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "distribution",
+				Usage:    "The name of the distribution.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyDiffPullPocketParams{}
+			params.Name = cmd.String("name")
+			params.Series = cmd.String("series")
+			params.Distribution = cmd.String("distribution")
+			res, err := api.LegacyDiffPullPocket(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "disable-administrator",
+		Usage:    "Call the DisableAdministrator legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "email",
+				Usage:    "The name of the person to disable.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyDisableAdministratorParams{}
+			params.Email = cmd.String("email")
+			res, err := api.LegacyDisableAdministrator(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "disassociate-alert",
+		Usage:    "Call the DisassociateAlert legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "Name of the entity.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:  "tags",
+				Usage: "Tags to change entity association for Can be specified multiple times.",
+			},
+			&cli.BoolFlag{
+				Name:  "all-computers",
+				Usage: "If true, change the 'all_computers' flag state for the entity. If the flag is enabled, associated tags will be kept, but they will not be effective until the flag is disabled.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyDisassociateAlertParams{}
+			params.Name = cmd.String("name")
+			if cmd.IsSet("tags") {
+				v := cmd.StringSlice("tags")
+				params.Tags = &v
+			}
+			if cmd.IsSet("all-computers") {
+				v := cmd.Bool("all-computers")
+				params.AllComputers = &v
+			}
+			res, err := api.LegacyDisassociateAlert(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "disassociate-package-profile",
+		Usage:    "Call the DisassociatePackageProfile legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "Name of the entity.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:  "tags",
+				Usage: "Tags to change entity association for Can be specified multiple times.",
+			},
+			&cli.BoolFlag{
+				Name:  "all-computers",
+				Usage: "If true, change the 'all_computers' flag state for the entity. If the flag is enabled, associated tags will be kept, but they will not be effective until the flag is disabled.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyDisassociatePackageProfileParams{}
+			params.Name = cmd.String("name")
+			if cmd.IsSet("tags") {
+				v := cmd.StringSlice("tags")
+				params.Tags = &v
+			}
+			if cmd.IsSet("all-computers") {
+				v := cmd.Bool("all-computers")
+				params.AllComputers = &v
+			}
+			res, err := api.LegacyDisassociatePackageProfile(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "disassociate-removal-profile",
+		Usage:    "Call the DisassociateRemovalProfile legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "Name of the entity.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:  "tags",
+				Usage: "Tags to change entity association for Can be specified multiple times.",
+			},
+			&cli.BoolFlag{
+				Name:  "all-computers",
+				Usage: "If true, change the 'all_computers' flag state for the entity. If the flag is enabled, associated tags will be kept, but they will not be effective until the flag is disabled.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyDisassociateRemovalProfileParams{}
+			params.Name = cmd.String("name")
+			if cmd.IsSet("tags") {
+				v := cmd.StringSlice("tags")
+				params.Tags = &v
+			}
+			if cmd.IsSet("all-computers") {
+				v := cmd.Bool("all-computers")
+				params.AllComputers = &v
+			}
+			res, err := api.LegacyDisassociateRemovalProfile(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "disassociate-repository-profile",
+		Usage:    "Call the DisassociateRepositoryProfile legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "Name of the entity.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:  "tags",
+				Usage: "Tags to change entity association for Can be specified multiple times.",
+			},
+			&cli.BoolFlag{
+				Name:  "all-computers",
+				Usage: "If true, change the 'all_computers' flag state for the entity. If the flag is enabled, associated tags will be kept, but they will not be effective until the flag is disabled.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyDisassociateRepositoryProfileParams{}
+			params.Name = cmd.String("name")
+			if cmd.IsSet("tags") {
+				v := cmd.StringSlice("tags")
+				params.Tags = &v
+			}
+			if cmd.IsSet("all-computers") {
+				v := cmd.Bool("all-computers")
+				params.AllComputers = &v
+			}
+			res, err := api.LegacyDisassociateRepositoryProfile(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "disassociate-upgrade-profile",
+		Usage:    "Call the DisassociateUpgradeProfile legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "Name of the entity.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:  "tags",
+				Usage: "Tags to change entity association for Can be specified multiple times.",
+			},
+			&cli.BoolFlag{
+				Name:  "all-computers",
+				Usage: "If true, change the 'all_computers' flag state for the entity. If the flag is enabled, associated tags will be kept, but they will not be effective until the flag is disabled.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyDisassociateUpgradeProfileParams{}
+			params.Name = cmd.String("name")
+			if cmd.IsSet("tags") {
+				v := cmd.StringSlice("tags")
+				params.Tags = &v
+			}
+			if cmd.IsSet("all-computers") {
+				v := cmd.Bool("all-computers")
+				params.AllComputers = &v
+			}
+			res, err := api.LegacyDisassociateUpgradeProfile(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "edit-package-profile",
+		Usage:    "Call the EditPackageProfile legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the package profile.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "title",
+				Usage: "The new title of the package profile.",
+			},
+			&cli.StringSliceFlag{
+				Name:  "add-constraints",
+				Usage: "List of constraints specifications to add in the form of \"depends packagename\" or \"conflicts packagename < 1.0\". Can be specified multiple times.",
+			},
+			&cli.StringSliceFlag{
+				Name:  "remove-constraints",
+				Usage: "List of constraints specifications to remove in the form of \"depends packagename\" or \"conflicts packagename < 1.0\". Can be specified multiple times.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyEditPackageProfileParams{}
+			params.Name = cmd.String("name")
+			if cmd.IsSet("title") {
+				v := cmd.String("title")
+				params.Title = &v
+			}
+			if cmd.IsSet("add-constraints") {
+				v := cmd.StringSlice("add-constraints")
+				params.AddConstraints = &v
+			}
+			if cmd.IsSet("remove-constraints") {
+				v := cmd.StringSlice("remove-constraints")
+				params.RemoveConstraints = &v
+			}
+			res, err := api.LegacyEditPackageProfile(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "edit-pocket",
+		Usage:    "Call the EditPocket legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the pocket to edit.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "series",
+				Usage:    "The name of the series containing the pocket.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "distribution",
+				Usage:    "The name of the distribution containing the series.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:  "components",
+				Usage: "A list of components the pocket will handle. Can be specified multiple times.",
+			},
+			&cli.StringSliceFlag{
+				Name:  "architectures",
+				Usage: "A list of architectures the pocket will handle. Can be specified multiple times.",
+			},
+			&cli.StringFlag{
+				Name:  "gpg-key",
+				Usage: "The name of the GPG key to use to sign packages lists for this pocket. The GPG key provided must have a private key associated with it.",
+			},
+			&cli.StringFlag{
+				Name:  "mirror-uri",
+				Usage: "The URI to mirror for pockets in 'mirror' mode.",
+			},
+			&cli.StringFlag{
+				Name:  "mirror-suite",
+				Usage: "The repository entry under dists/ to mirror for pockets in 'mirror' mode.",
+			},
+			&cli.StringFlag{
+				Name:  "mirror-gpg-key",
+				Usage: "The name of the GPG key to use to verify the mirrored archive signature. If '-' is given, the stock Ubuntu archive one will be used.",
+			},
+			&cli.BoolFlag{
+				Name:  "upload-allow-unsigned",
+				Usage: "For pockets in upload mode, a boolean indicating whether uploaded packages are required to be signed or not.",
+			},
+			&cli.BoolFlag{
+				Name:  "include-udeb",
+				Usage: "Whether the pocket should include selected components also for .udeb packages (debian-installer).",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyEditPocketParams{}
+			params.Name = cmd.String("name")
+			params.Series = cmd.String("series")
+			params.Distribution = cmd.String("distribution")
+			if cmd.IsSet("components") {
+				v := cmd.StringSlice("components")
+				params.Components = &v
+			}
+			if cmd.IsSet("architectures") {
+				v := cmd.StringSlice("architectures")
+				params.Architectures = &v
+			}
+			if cmd.IsSet("gpg-key") {
+				v := cmd.String("gpg-key")
+				params.GpgKey = &v
+			}
+			if cmd.IsSet("mirror-uri") {
+				v := cmd.String("mirror-uri")
+				params.MirrorUri = &v
+			}
+			if cmd.IsSet("mirror-suite") {
+				v := cmd.String("mirror-suite")
+				params.MirrorSuite = &v
+			}
+			if cmd.IsSet("mirror-gpg-key") {
+				v := cmd.String("mirror-gpg-key")
+				params.MirrorGpgKey = &v
+			}
+			if cmd.IsSet("upload-allow-unsigned") {
+				v := cmd.Bool("upload-allow-unsigned")
+				params.UploadAllowUnsigned = &v
+			}
+			if cmd.IsSet("include-udeb") {
+				v := cmd.Bool("include-udeb")
+				params.IncludeUdeb = &v
+			}
+			res, err := api.LegacyEditPocket(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "edit-removal-profile",
+		Usage:    "Call the EditRemovalProfile legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the profile to edit.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "title",
+				Usage: "The new title of the profile.",
+			},
+			&cli.IntFlag{
+				Name:  "days-without-exchange",
+				Usage: "The length of time after which a computer may be removed.",
+			},
+			&cli.StringSliceFlag{
+				Name:  "tags",
+				Usage: "Computer tags to associate with the removal profile Can be specified multiple times.",
+			},
+			&cli.BoolFlag{
+				Name:  "all-computers",
+				Usage: "Whether to associate the removal profile with all computers.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyEditRemovalProfileParams{}
+			params.Name = cmd.String("name")
+			if cmd.IsSet("title") {
+				v := cmd.String("title")
+				params.Title = &v
+			}
+			if cmd.IsSet("days-without-exchange") {
+				v := cmd.Int("days-without-exchange")
+				params.DaysWithoutExchange = &v
+			}
+			if cmd.IsSet("tags") {
+				v := cmd.StringSlice("tags")
+				params.Tags = &v
+			}
+			if cmd.IsSet("all-computers") {
+				v := cmd.Bool("all-computers")
+				params.AllComputers = &v
+			}
+			res, err := api.LegacyEditRemovalProfile(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "edit-repository-profile",
+		Usage:    "Call the EditRepositoryProfile legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "Name of the repository profile to edit.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "title",
+				Usage: "Title of the repository profile.",
+			},
+			&cli.StringFlag{
+				Name:  "description",
+				Usage: "Description of the repository profile.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyEditRepositoryProfileParams{}
+			params.Name = cmd.String("name")
+			if cmd.IsSet("title") {
+				v := cmd.String("title")
+				params.Title = &v
+			}
+			if cmd.IsSet("description") {
+				v := cmd.String("description")
+				params.Description = &v
+			}
+			res, err := api.LegacyEditRepositoryProfile(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "edit-saved-search",
+		Usage:    "Call the EditSavedSearch legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The \"slug\" name for this saved search, this is the text which must be used when using the \"search:name\" syntax. A saved search with this name must already exist in the account.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "title",
+				Usage: "The new display name for the saved search. If this parameter is not included then the title will not be modified.",
+			},
+			&cli.StringFlag{
+				Name:  "search",
+				Usage: "The search string to save. If this parameter is not included then the search string will not be modified.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyEditSavedSearchParams{}
+			params.Name = cmd.String("name")
+			if cmd.IsSet("title") {
+				v := cmd.String("title")
+				params.Title = &v
+			}
+			if cmd.IsSet("search") {
+				v := cmd.String("search")
+				params.Search = &v
+			}
+			res, err := api.LegacyEditSavedSearch(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "edit-script",
+		Usage:    "Call the EditScript legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:     "script-id",
+				Usage:    "The identifier of the script you wish to edit.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "title",
+				Usage: "The new script title.",
+			},
+			&cli.IntFlag{
+				Name:  "time-limit",
+				Usage: "Amount of time to wait for the process to end.",
+			},
+			&cli.StringFlag{
+				Name:  "code",
+				Usage: "The filename holding the script contents.",
+			},
+			&cli.StringFlag{
+				Name:  "username",
+				Usage: "The user to execute the script as.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyEditScriptParams{}
+			params.ScriptId = cmd.Int("script-id")
+			if cmd.IsSet("title") {
+				v := cmd.String("title")
+				params.Title = &v
+			}
+			if cmd.IsSet("time-limit") {
+				v := cmd.Int("time-limit")
+				params.TimeLimit = &v
+			}
+			if cmd.IsSet("code") {
+				v := cmd.String("code")
+				params.Code = &v
+			}
+			if cmd.IsSet("username") {
+				v := cmd.String("username")
+				params.Username = &v
+			}
+			res, err := api.LegacyEditScript(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "edit-upgrade-profile",
+		Usage:    "Call the EditUpgradeProfile legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name for this upgrade profile.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "title",
+				Usage: "The new title of the upgrade profile.",
+			},
+			&cli.StringFlag{
+				Name:  "every",
+				Usage: "The frequency at which you wish this upgrade profile to be executed. Valid choices are \"hour\" and \"week\".",
+			},
+			&cli.StringSliceFlag{
+				Name:  "on-days",
+				Usage: "A list of days of the week on which the upgrade profile will be run. The day names must be abbreviated to their first two letters, as: \"mo\", \"tu\", \"we\", \"th\", \"fr\", \"sa\", \"su\". Required when the every parameter is \"week\" but optional when the every parameter is \"hour\". Can be specified multiple times.",
+			},
+			&cli.IntFlag{
+				Name:  "at-hour",
+				Usage: "The hour, in 24h format, at which the upgrade profile will be run.",
+			},
+			&cli.IntFlag{
+				Name:  "at-minute",
+				Usage: "The minute of the hour (0-59) at which the upgrade profile will be run.",
+			},
+			&cli.IntFlag{
+				Name:  "deliver-within",
+				Usage: "An optional number of hours within which the upgrade task should be delivered to computers. The window will be from the time specified by this API call (on_days, at_hour, at_minute) until the provided number of hours later. Defaults to 1 hour.",
+			},
+			&cli.IntFlag{
+				Name:  "deliver-delay-window",
+				Usage: "Randomise delivery within the given timeframe specified in minutes.",
+			},
+			&cli.BoolFlag{
+				Name:  "security-upgrade",
+				Usage: "(Deprecated) Whether this upgrade is a security upgrade or not.",
+			},
+			&cli.StringFlag{
+				Name:  "upgrade-type",
+				Usage: "The type of upgrade profile, either \"security\" or \"all\".",
+			},
+			&cli.BoolFlag{
+				Name:  "autoremove",
+				Usage: "Whether this upgrade should also autoremove old packages.",
+			},
+			&cli.StringSliceFlag{
+				Name:  "tags",
+				Usage: "Computer tags to associate with the upgrade profile. Can be specified multiple times.",
+			},
+			&cli.BoolFlag{
+				Name:  "all-computers",
+				Usage: "Whether to associate the upgrade profile with all computers.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyEditUpgradeProfileParams{}
+			params.Name = cmd.String("name")
+			if cmd.IsSet("title") {
+				v := cmd.String("title")
+				params.Title = &v
+			}
+			if cmd.IsSet("every") {
+				v := cmd.String("every")
+				params.Every = &v
+			}
+			if cmd.IsSet("on-days") {
+				v := cmd.StringSlice("on-days")
+				params.OnDays = &v
+			}
+			if cmd.IsSet("at-hour") {
+				v := cmd.Int("at-hour")
+				params.AtHour = &v
+			}
+			if cmd.IsSet("at-minute") {
+				v := cmd.Int("at-minute")
+				params.AtMinute = &v
+			}
+			if cmd.IsSet("deliver-within") {
+				v := cmd.Int("deliver-within")
+				params.DeliverWithin = &v
+			}
+			if cmd.IsSet("deliver-delay-window") {
+				v := cmd.Int("deliver-delay-window")
+				params.DeliverDelayWindow = &v
+			}
+			if cmd.IsSet("security-upgrade") {
+				v := cmd.Bool("security-upgrade")
+				params.SecurityUpgrade = &v
+			}
+			if cmd.IsSet("upgrade-type") {
+				v := cmd.String("upgrade-type")
+				params.UpgradeType = &v
+			}
+			if cmd.IsSet("autoremove") {
+				v := cmd.Bool("autoremove")
+				params.Autoremove = &v
+			}
+			if cmd.IsSet("tags") {
+				v := cmd.StringSlice("tags")
+				params.Tags = &v
+			}
+			if cmd.IsSet("all-computers") {
+				v := cmd.Bool("all-computers")
+				params.AllComputers = &v
+			}
+			res, err := api.LegacyEditUpgradeProfile(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "edit-user",
+		Usage:    "Call the EditUser legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.IntSliceFlag{
+				Name:     "computer-ids",
+				Usage:    "The numerical IDs of the computers. Can be specified multiple times.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "username",
+				Usage:    "The username of an existing user.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "name",
+				Usage: "The new title name of the existing user.",
+			},
+			&cli.StringFlag{
+				Name:  "password",
+				Usage: "The new password for the existing user.",
+			},
+			&cli.StringFlag{
+				Name:  "primary-groupname",
+				Usage: "The new group the existing user will be assigned to.",
+			},
+			&cli.StringFlag{
+				Name:  "location",
+				Usage: "The new location of the existing user.",
+			},
+			&cli.StringFlag{
+				Name:  "home-phone",
+				Usage: "The new home phone number of the existing user.",
+			},
+			&cli.StringFlag{
+				Name:  "work-phone",
+				Usage: "The new work phone number of the existing user.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyEditUserParams{}
+			params.ComputerIds = cmd.IntSlice("computer-ids")
+			params.Username = cmd.String("username")
+			if cmd.IsSet("name") {
+				v := cmd.String("name")
+				params.Name = &v
+			}
+			if cmd.IsSet("password") {
+				v := cmd.String("password")
+				params.Password = &v
+			}
+			if cmd.IsSet("primary-groupname") {
+				v := cmd.String("primary-groupname")
+				params.PrimaryGroupname = &v
+			}
+			if cmd.IsSet("location") {
+				v := cmd.String("location")
+				params.Location = &v
+			}
+			if cmd.IsSet("home-phone") {
+				v := cmd.String("home-phone")
+				params.HomePhone = &v
+			}
+			if cmd.IsSet("work-phone") {
+				v := cmd.String("work-phone")
+				params.WorkPhone = &v
+			}
+			res, err := api.LegacyEditUser(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "execute-script",
+		Usage:    "Call the ExecuteScript legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "query",
+				Usage:    "A query string used to select the computers to execute the script on. Multiple occurrences will be joined with a logical AND.",
+				Required: true,
+			},
+			&cli.IntFlag{
+				Name:     "script-id",
+				Usage:    "The identity of the script stored in the server.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "username",
+				Usage: "The username to execute the script as on the client. Required if the script has no default username.",
+			},
+			&cli.StringFlag{
+				Name:  "deliver-after",
+				Usage: "A time in the future to deliver the script.",
+			},
+			&cli.IntFlag{
+				Name:  "time-limit",
+				Usage: "The amount of time to wait for the process to complete before it is killed.",
+			},
+			&cli.StringFlag{
+				Name:  "in-access-group",
+				Usage: "Only execute the script in the given access group.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyExecuteScriptParams{}
+			params.Query = cmd.String("query")
+			params.ScriptId = cmd.Int("script-id")
+			if cmd.IsSet("username") {
+				v := cmd.String("username")
+				params.Username = &v
+			}
+			if cmd.IsSet("deliver-after") {
+				v := cmd.String("deliver-after")
+				params.DeliverAfter = &v
+			}
+			if cmd.IsSet("time-limit") {
+				v := cmd.Int("time-limit")
+				params.TimeLimit = &v
+			}
+			if cmd.IsSet("in-access-group") {
+				v := cmd.String("in-access-group")
+				params.InAccessGroup = &v
+			}
+			res, err := api.LegacyExecuteScript(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "get-access-groups",
+		Usage:    "Call the GetAccessGroups legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:  "names",
+				Usage: "The name of the access group. Can be specified multiple times.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyGetAccessGroupsParams{}
+			if cmd.IsSet("names") {
+				v := cmd.StringSlice("names")
+				params.Names = &v
+			}
+			res, err := api.LegacyGetAccessGroups(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "get-activities",
+		Usage:    "Call the GetActivities legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "query",
+				Usage: "A query string with space separated tokens used to filter the returned result objects.",
+			},
+			&cli.IntFlag{
+				Name:  "limit",
+				Usage: "The maximum number of results returned by the method. It defaults to 1000.",
+			},
+			&cli.IntFlag{
+				Name:  "offset",
+				Usage: "The offset inside the list of results.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyGetActivitiesParams{}
+			if cmd.IsSet("query") {
+				v := cmd.String("query")
+				params.Query = &v
+			}
+			if cmd.IsSet("limit") {
+				v := cmd.Int("limit")
+				params.Limit = &v
+			}
+			if cmd.IsSet("offset") {
+				v := cmd.Int("offset")
+				params.Offset = &v
+			}
+			res, err := api.LegacyGetActivities(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "get-activity-types",
+		Usage:    "Call the GetActivityTypes legacy action.",
+		Category: "legacy actions",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			res, err := api.LegacyGetActivityTypes(ctx)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "get-administrators",
+		Usage:    "Call the GetAdministrators legacy action.",
+		Category: "legacy actions",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			res, err := api.LegacyGetAdministrators(ctx)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "get-alert-subscribers",
+		Usage:    "Call the GetAlertSubscribers legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "alert-type",
+				Usage:    "The alert type to check the subscription on.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyGetAlertSubscribersParams{}
+			params.AlertType = cmd.String("alert-type")
+			res, err := api.LegacyGetAlertSubscribers(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "get-alerts",
+		Usage:    "Call the GetAlerts legacy action.",
+		Category: "legacy actions",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			res, err := api.LegacyGetAlerts(ctx)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "get-apt-sources",
+		Usage:    "Call the GetAPTSources legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:  "names",
+				Usage: "List of names of the APT source to be returned. Multiple names can be supplied. Can be specified multiple times.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyGetAPTSourcesParams{}
+			if cmd.IsSet("names") {
+				v := cmd.StringSlice("names")
+				params.Names = &v
+			}
+			res, err := api.LegacyGetAPTSources(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "get-computer-processes",
+		Usage:    "Call the GetComputerProcesses legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:     "computer-id",
+				Usage:    "The numerical ID of the computer.",
+				Required: true,
+			},
+			&cli.IntFlag{
+				Name:  "offset",
+				Usage: "The number of items to skip.",
+			},
+			&cli.IntFlag{
+				Name:  "limit",
+				Usage: "The number of items per page.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyGetComputerProcessesParams{}
+			params.ComputerId = cmd.Int("computer-id")
+			if cmd.IsSet("offset") {
+				v := cmd.Int("offset")
+				params.Offset = &v
+			}
+			if cmd.IsSet("limit") {
+				v := cmd.Int("limit")
+				params.Limit = &v
+			}
+			res, err := api.LegacyGetComputerProcesses(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "get-computers",
+		Usage:    "Call the GetComputers legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "query",
+				Usage: "A query string with space separated tokens used to filter the returned result objects.",
+			},
+			&cli.IntFlag{
+				Name:  "limit",
+				Usage: "The maximum number of results returned by the method. It defaults to 1000.",
+			},
+			&cli.IntFlag{
+				Name:  "offset",
+				Usage: "The offset inside the list of results.",
+			},
+			&cli.BoolFlag{
+				Name:  "with-network",
+				Usage: "If true, include the details of all active network devices attached to the computer.",
+			},
+			&cli.BoolFlag{
+				Name:  "with-all-network",
+				Usage: "If true, include the details of all active and inactive network devices attached to the computer.",
+			},
+			&cli.BoolFlag{
+				Name:  "with-hardware",
+				Usage: "If true, include the details of all known hardware information.",
+			},
+			&cli.BoolFlag{
+				Name:  "with-annotations",
+				Usage: "If true, include the details of all custom annotation information known.",
+			},
+			&cli.BoolFlag{
+				Name:  "with-grouped-hardware",
+				Usage: "If true, include the details of all known hardware information grouped by device category.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyGetComputersParams{}
+			if cmd.IsSet("query") {
+				v := cmd.String("query")
+				params.Query = &v
+			}
+			if cmd.IsSet("limit") {
+				v := cmd.Int("limit")
+				params.Limit = &v
+			}
+			if cmd.IsSet("offset") {
+				v := cmd.Int("offset")
+				params.Offset = &v
+			}
+			if cmd.IsSet("with-network") {
+				v := cmd.Bool("with-network")
+				params.WithNetwork = &v
+			}
+			if cmd.IsSet("with-all-network") {
+				v := cmd.Bool("with-all-network")
+				params.WithAllNetwork = &v
+			}
+			if cmd.IsSet("with-hardware") {
+				v := cmd.Bool("with-hardware")
+				params.WithHardware = &v
+			}
+			if cmd.IsSet("with-annotations") {
+				v := cmd.Bool("with-annotations")
+				params.WithAnnotations = &v
+			}
+			if cmd.IsSet("with-grouped-hardware") {
+				v := cmd.Bool("with-grouped-hardware")
+				params.WithGroupedHardware = &v
+			}
+			res, err := api.LegacyGetComputers(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "get-computers-not-upgraded",
+		Usage:    "Call the GetComputersNotUpgraded legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "query",
+				Usage: "A query string with space separated tokens used to filter the returned result objects.",
+			},
+			&cli.IntFlag{
+				Name:  "limit",
+				Usage: "The maximum number of results returned by the method. It defaults to 1000.",
+			},
+			&cli.IntFlag{
+				Name:  "offset",
+				Usage: "The offset inside the list of results.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyGetComputersNotUpgradedParams{}
+			if cmd.IsSet("query") {
+				v := cmd.String("query")
+				params.Query = &v
+			}
+			if cmd.IsSet("limit") {
+				v := cmd.Int("limit")
+				params.Limit = &v
+			}
+			if cmd.IsSet("offset") {
+				v := cmd.Int("offset")
+				params.Offset = &v
+			}
+			res, err := api.LegacyGetComputersNotUpgraded(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "get-csv-compliance-data",
+		Usage:    "Call the GetCSVComplianceData legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "query",
+				Usage: "A query string with space separated tokens used to filter the returned result objects.",
+			},
+			&cli.IntFlag{
+				Name:  "limit",
+				Usage: "The maximum number of results returned by the method. It defaults to 1000.",
+			},
+			&cli.IntFlag{
+				Name:  "offset",
+				Usage: "The offset inside the list of results.",
+			},
+			&cli.IntFlag{
+				Name:  "max-days",
+				Usage: "Return issues newer than max_days.",
+			},
+			&cli.BoolFlag{
+				Name:  "by-cve",
+				Usage: "If by_cve is false (the default), a key will be added for each USN released in the last max_days. The key name will be the identifier of the USN with timestamp, and the value will indicate whether the issue is present and if it is, whether it has been resolved, and when. If by_cve is true, CVEs will be used as the column key instead.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyGetCSVComplianceDataParams{}
+			if cmd.IsSet("query") {
+				v := cmd.String("query")
+				params.Query = &v
+			}
+			if cmd.IsSet("limit") {
+				v := cmd.Int("limit")
+				params.Limit = &v
+			}
+			if cmd.IsSet("offset") {
+				v := cmd.Int("offset")
+				params.Offset = &v
+			}
+			if cmd.IsSet("max-days") {
+				v := cmd.Int("max-days")
+				params.MaxDays = &v
+			}
+			if cmd.IsSet("by-cve") {
+				v := cmd.Bool("by-cve")
+				params.ByCve = &v
+			}
+			res, err := api.LegacyGetCSVComplianceData(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "get-distributions",
+		Usage:    "Call the GetDistributions legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:  "names",
+				Usage: "A list of distribution names to get info for. If this is not provided, the call will return all distributions for the account. Can be specified multiple times.",
+			},
+			&cli.BoolFlag{
+				Name:  "include-latest-sync",
+				Usage: "Include the status of the latest sync for pull and mirror pockets.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyGetDistributionsParams{}
+			if cmd.IsSet("names") {
+				v := cmd.StringSlice("names")
+				params.Names = &v
+			}
+			if cmd.IsSet("include-latest-sync") {
+				v := cmd.Bool("include-latest-sync")
+				params.IncludeLatestSync = &v
+			}
+			res, err := api.LegacyGetDistributions(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "get-event-log",
+		Usage:    "Call the GetEventLog legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:  "days",
+				Usage: "The number of days prior to today from which to fetch log entries. It defaults to 30 days.",
+			},
+			&cli.IntFlag{
+				Name:  "limit",
+				Usage: "The maximum number of results returned by the method. It defaults to 1000.",
+			},
+			&cli.IntFlag{
+				Name:  "offset",
+				Usage: "The offset inside the list of results.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyGetEventLogParams{}
+			if cmd.IsSet("days") {
+				v := cmd.Int("days")
+				params.Days = &v
+			}
+			if cmd.IsSet("limit") {
+				v := cmd.Int("limit")
+				params.Limit = &v
+			}
+			if cmd.IsSet("offset") {
+				v := cmd.Int("offset")
+				params.Offset = &v
+			}
+			res, err := api.LegacyGetEventLog(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "get-gpg-keys",
+		Usage:    "Call the GetGPGKeys legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:  "names",
+				Usage: "A list of GPG keys to get info for. If this is not provided, the call will return all keys for the account. Can be specified multiple times.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyGetGPGKeysParams{}
+			if cmd.IsSet("names") {
+				v := cmd.StringSlice("names")
+				params.Names = &v
+			}
+			res, err := api.LegacyGetGPGKeys(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "get-not-pinging-computers",
+		Usage:    "Call the GetNotPingingComputers legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "query",
+				Usage: "A query string with space separated tokens used to filter the returned result objects.",
+			},
+			&cli.IntFlag{
+				Name:  "limit",
+				Usage: "The maximum number of results returned by the method. It defaults to 1000.",
+			},
+			&cli.IntFlag{
+				Name:  "offset",
+				Usage: "The offset inside the list of results.",
+			},
+			&cli.IntFlag{
+				Name:     "since-minutes",
+				Usage:    "The number of minutes elapsed in which no ping from included computers has been seen.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyGetNotPingingComputersParams{}
+			if cmd.IsSet("query") {
+				v := cmd.String("query")
+				params.Query = &v
+			}
+			if cmd.IsSet("limit") {
+				v := cmd.Int("limit")
+				params.Limit = &v
+			}
+			if cmd.IsSet("offset") {
+				v := cmd.Int("offset")
+				params.Offset = &v
+			}
+			params.SinceMinutes = cmd.Int("since-minutes")
+			res, err := api.LegacyGetNotPingingComputers(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "get-package-profiles",
+		Usage:    "Call the GetPackageProfiles legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:  "names",
+				Usage: "A list of package profile names to limit the result. Can be specified multiple times.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyGetPackageProfilesParams{}
+			if cmd.IsSet("names") {
+				v := cmd.StringSlice("names")
+				params.Names = &v
+			}
+			res, err := api.LegacyGetPackageProfiles(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "get-packages",
+		Usage:    "Call the GetPackages legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "query",
+				Usage:    "A query string used to select computers to query packages on.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "search",
+				Usage: "A string to restrict the search to. All fields are searched, not just those returned. (e.g., description)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "names",
+				Usage: "Restrict the search to these package names. Can be specified multiple times.",
+			},
+			&cli.BoolFlag{
+				Name:  "installed",
+				Usage: "If true only packages in the installed state will be returned, if false only packages not installed will be returned. If not given both installed and not installed packages will be returned.",
+			},
+			&cli.BoolFlag{
+				Name:  "available",
+				Usage: "If true only packages in the available state will be returned, if false only packages not available will be returned. If not given both available and not available packages will be returned.",
+			},
+			&cli.BoolFlag{
+				Name:  "upgrade",
+				Usage: "If true, only installable packages that are upgrades for an for an installed one are returned. If false, only installable packages that are not upgrades are returned. If not given, packages will be returned regardless of wether they are upgrades or not.",
+			},
+			&cli.BoolFlag{
+				Name:  "held",
+				Usage: "If true, only installed packages that are held on computers are returned. If false, only packages that are not held on computers are returned. If not given, packages will be returned regardless of the held state.",
+			},
+			&cli.IntFlag{
+				Name:  "offset",
+				Usage: "The offset inside the list of results.",
+			},
+			&cli.IntFlag{
+				Name:  "limit",
+				Usage: "The maximum number of results returned by the method. It defaults to 1000.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyGetPackagesParams{}
+			params.Query = cmd.String("query")
+			if cmd.IsSet("search") {
+				v := cmd.String("search")
+				params.Search = &v
+			}
+			if cmd.IsSet("names") {
+				v := cmd.StringSlice("names")
+				params.Names = &v
+			}
+			if cmd.IsSet("installed") {
+				v := cmd.Bool("installed")
+				params.Installed = &v
+			}
+			if cmd.IsSet("available") {
+				v := cmd.Bool("available")
+				params.Available = &v
+			}
+			if cmd.IsSet("upgrade") {
+				v := cmd.Bool("upgrade")
+				params.Upgrade = &v
+			}
+			if cmd.IsSet("held") {
+				v := cmd.Bool("held")
+				params.Held = &v
+			}
+			if cmd.IsSet("offset") {
+				v := cmd.Int("offset")
+				params.Offset = &v
+			}
+			if cmd.IsSet("limit") {
+				v := cmd.Int("limit")
+				params.Limit = &v
+			}
+			res, err := api.LegacyGetPackages(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "get-pending-computers",
+		Usage:    "Call the GetPendingComputers legacy action.",
+		Category: "legacy actions",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			res, err := api.LegacyGetPendingComputers(ctx)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "get-permissions",
+		Usage:    "Call the GetPermissions legacy action.",
+		Category: "legacy actions",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			res, err := api.LegacyGetPermissions(ctx)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "get-removal-profiles",
+		Usage:    "Call the GetRemovalProfiles legacy action.",
+		Category: "legacy actions",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			res, err := api.LegacyGetRemovalProfiles(ctx)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "get-repo-info",
+		Usage:    "Call the GetRepoInfo legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "mirror-uri",
+				Usage:    "The name of mirror uri",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyGetRepoInfoParams{}
+			params.MirrorUri = cmd.String("mirror-uri")
+			res, err := api.LegacyGetRepoInfo(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "get-repository-profiles",
+		Usage:    "Call the GetRepositoryProfiles legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:  "names",
+				Usage: "A list of repository profile names to get info for. If this is not provided, the call will return all repository profiles for the account. Can be specified multiple times.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyGetRepositoryProfilesParams{}
+			if cmd.IsSet("names") {
+				v := cmd.StringSlice("names")
+				params.Names = &v
+			}
+			res, err := api.LegacyGetRepositoryProfiles(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "get-roles",
+		Usage:    "Call the GetRoles legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:  "names",
+				Usage: "A list of role names to limit the result. Can be specified multiple times.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyGetRolesParams{}
+			if cmd.IsSet("names") {
+				v := cmd.StringSlice("names")
+				params.Names = &v
+			}
+			res, err := api.LegacyGetRoles(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "get-saved-searches",
+		Usage:    "Call the GetSavedSearches legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:  "offset",
+				Usage: "The offset inside the list of results.",
+			},
+			&cli.IntFlag{
+				Name:  "limit",
+				Usage: "The maximum number of results returned by the method. It defaults to 1000.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyGetSavedSearchesParams{}
+			if cmd.IsSet("offset") {
+				v := cmd.Int("offset")
+				params.Offset = &v
+			}
+			if cmd.IsSet("limit") {
+				v := cmd.Int("limit")
+				params.Limit = &v
+			}
+			res, err := api.LegacyGetSavedSearches(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:      "get-script",
+		Usage:     "Call GET /api/scripts/{script_id}.",
+		Category:  "REST API",
+		ArgsUsage: "<script-id>",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			scriptId, err := intArg(cmd, 0, "script-id")
+			if err != nil {
+				return err
+			}
+			res, err := api.GetScript(ctx, scriptId)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:      "get-script-attachment",
+		Usage:     "Call GET /api/scripts/{script_id}/attachments/{attachment_id}.",
+		Category:  "REST API",
+		ArgsUsage: "<script-id> <attachment-id>",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			scriptId, err := intArg(cmd, 0, "script-id")
+			if err != nil {
+				return err
+			}
+			attachmentId, err := intArg(cmd, 1, "attachment-id")
+			if err != nil {
+				return err
+			}
+			res, err := api.GetScriptAttachment(ctx, scriptId, attachmentId)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "get-script-code",
+		Usage:    "Call the GetScriptCode legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:     "script-id",
+				Usage:    "The identity of the script you wish to get the code for.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyGetScriptCodeParams{}
+			params.ScriptId = cmd.Int("script-id")
+			res, err := api.LegacyGetScriptCode(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:      "get-script-profile",
+		Usage:     "Call GET /api/script-profiles/{script_profile_id}.",
+		Category:  "REST API",
+		ArgsUsage: "<script-profile-id>",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			scriptProfileId, err := intArg(cmd, 0, "script-profile-id")
+			if err != nil {
+				return err
+			}
+			res, err := api.GetScriptProfile(ctx, scriptProfileId)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "get-script-profile-limits",
+		Usage:    "Call GET /api/script-profile-limits.",
+		Category: "REST API",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			res, err := api.GetScriptProfileLimits(ctx)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "get-scripts",
+		Usage:    "Call the GetScripts legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:  "limit",
+				Usage: "The maximum number of results returned by the method. It defaults to 1000.",
+			},
+			&cli.IntFlag{
+				Name:  "offset",
+				Usage: "The offset inside the list of results.",
+			},
+			&cli.StringFlag{
+				Name:  "script-type",
+				Usage: "The type of script to get (V1, V2, active, archived, redacted, or all).",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyGetScriptsParams{}
+			if cmd.IsSet("limit") {
+				v := cmd.Int("limit")
+				params.Limit = &v
+			}
+			if cmd.IsSet("offset") {
+				v := cmd.Int("offset")
+				params.Offset = &v
+			}
+			if cmd.IsSet("script-type") {
+				v := cmd.String("script-type")
+				params.ScriptType = &v
+			}
+			res, err := api.LegacyGetScripts(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "get-settings",
+		Usage:    "Call the GetSettings legacy action.",
+		Category: "legacy actions",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			res, err := api.LegacyGetSettings(ctx)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "get-upgrade-profiles",
+		Usage:    "Call the GetUpgradeProfiles legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "upgrade-type",
+				Usage: " The type of upgrade you wish to list. This can be either \"all\" or \"security\", in which case the result will be a list of upgrade profiles with an upgrade type of \"all\" or \"security\" respectively. If omitted, the resulting list will contain all upgrade profiles, regardless of their upgrade type.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyGetUpgradeProfilesParams{}
+			if cmd.IsSet("upgrade-type") {
+				v := cmd.String("upgrade-type")
+				params.UpgradeType = &v
+			}
+			res, err := api.LegacyGetUpgradeProfiles(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "get-upgraded-computers-by-frequency",
+		Usage:    "Call the GetUpgradedComputersByFrequency legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "query",
+				Usage: "A query string with space separated tokens used to filter the returned result objects.",
+			},
+			&cli.IntFlag{
+				Name:  "limit",
+				Usage: "The maximum number of results returned by the method. It defaults to 1000.",
+			},
+			&cli.IntFlag{
+				Name:  "offset",
+				Usage: "The offset inside the list of results.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyGetUpgradedComputersByFrequencyParams{}
+			if cmd.IsSet("query") {
+				v := cmd.String("query")
+				params.Query = &v
+			}
+			if cmd.IsSet("limit") {
+				v := cmd.Int("limit")
+				params.Limit = &v
+			}
+			if cmd.IsSet("offset") {
+				v := cmd.Int("offset")
+				params.Offset = &v
+			}
+			res, err := api.LegacyGetUpgradedComputersByFrequency(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "get-users",
+		Usage:    "Call the GetUsers legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:     "computer-id",
+				Usage:    "The numerical ID of the computer.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyGetUsersParams{}
+			params.ComputerId = cmd.Int("computer-id")
+			res, err := api.LegacyGetUsers(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "get-usn-time-to-fix",
+		Usage:    "Call the GetUSNTimeToFix legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "query",
+				Usage: "A query string with space separated tokens used to filter the returned result objects.",
+			},
+			&cli.IntFlag{
+				Name:  "limit",
+				Usage: "The maximum number of results returned by the method. It defaults to 1000.",
+			},
+			&cli.IntFlag{
+				Name:  "offset",
+				Usage: "The offset inside the list of results.",
+			},
+			&cli.IntSliceFlag{
+				Name:  "fixed-in-days",
+				Usage: "A list of periods of days to report on USN fixes being applied in Can be specified multiple times.",
+			},
+			&cli.IntFlag{
+				Name:  "pending-in-days",
+				Usage: "The period of days in the past to search for USNs that are pending on a computer. This is independent of the in_last argument.",
+			},
+			&cli.IntFlag{
+				Name:  "in-last",
+				Usage: "The period of days to look into the past to find USN releases to be considered in these statistics.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyGetUSNTimeToFixParams{}
+			if cmd.IsSet("query") {
+				v := cmd.String("query")
+				params.Query = &v
+			}
+			if cmd.IsSet("limit") {
+				v := cmd.Int("limit")
+				params.Limit = &v
+			}
+			if cmd.IsSet("offset") {
+				v := cmd.Int("offset")
+				params.Offset = &v
+			}
+			if cmd.IsSet("fixed-in-days") {
+				v := cmd.IntSlice("fixed-in-days")
+				params.FixedInDays = &v
+			}
+			if cmd.IsSet("pending-in-days") {
+				v := cmd.Int("pending-in-days")
+				params.PendingInDays = &v
+			}
+			if cmd.IsSet("in-last") {
+				v := cmd.Int("in-last")
+				params.InLast = &v
+			}
+			res, err := api.LegacyGetUSNTimeToFix(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "get-wsl-hosts",
+		Usage:    "Call the GetWSLHosts legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "query",
+				Usage: "A query string with space separated tokens used to filter the returned result objects.",
+			},
+			&cli.IntFlag{
+				Name:  "limit",
+				Usage: "The maximum number of results returned by the method. It defaults to 1000.",
+			},
+			&cli.IntFlag{
+				Name:  "offset",
+				Usage: "The offset inside the list of results.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyGetWSLHostsParams{}
+			if cmd.IsSet("query") {
+				v := cmd.String("query")
+				params.Query = &v
+			}
+			if cmd.IsSet("limit") {
+				v := cmd.Int("limit")
+				params.Limit = &v
+			}
+			if cmd.IsSet("offset") {
+				v := cmd.Int("offset")
+				params.Offset = &v
+			}
+			res, err := api.LegacyGetWSLHosts(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "import-gpg-key",
+		Usage:    "Call the ImportGPGKey legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "Name of the GPG key. It must be unique within the account, start with an alphanumeric character and only contain lowercase letters, numbers and - or + signs.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "material",
+				Usage:    "The text representation of the key.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyImportGPGKeyParams{}
+			params.Name = cmd.String("name")
+			params.Material = cmd.String("material")
+			res, err := api.LegacyImportGPGKey(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "install-packages",
+		Usage:    "Call the InstallPackages legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "query",
+				Usage:    "A qualified criteria to be used in the search.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:     "packages",
+				Usage:    "A list of package names on which to operate. Multiple package names can be supplied. Can be specified multiple times.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "deliver-after",
+				Usage: "A time in the future to perform the package operation.",
+			},
+			&cli.IntFlag{
+				Name:  "deliver-delay-window",
+				Usage: "Randomise delivery within the given time frame specified in minutes",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyInstallPackagesParams{}
+			params.Query = cmd.String("query")
+			params.Packages = cmd.StringSlice("packages")
+			if cmd.IsSet("deliver-after") {
+				v := cmd.String("deliver-after")
+				params.DeliverAfter = &v
+			}
+			if cmd.IsSet("deliver-delay-window") {
+				v := cmd.Int("deliver-delay-window")
+				params.DeliverDelayWindow = &v
+			}
+			res, err := api.LegacyInstallPackages(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "invite-administrator",
+		Usage:    "Call the InviteAdministrator legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the person to invite.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "email",
+				Usage:    "The email address of the administrator, to which the invitation will be send.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:  "roles",
+				Usage: "If specified, the roles that the administrator is going to have in your account. Default to GlobalAdmin Can be specified multiple times.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyInviteAdministratorParams{}
+			params.Name = cmd.String("name")
+			params.Email = cmd.String("email")
+			if cmd.IsSet("roles") {
+				v := cmd.StringSlice("roles")
+				params.Roles = &v
+			}
+			res, err := api.LegacyInviteAdministrator(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "kill-computer-processes",
+		Usage:    "Call the KillComputerProcesses legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:     "computer-id",
+				Usage:    "The numerical ID of the computer",
+				Required: true,
+			},
+			&cli.IntSliceFlag{
+				Name:     "pids",
+				Usage:    "A comma separated list of PIDs of the processes to send a KILL signal Can be specified multiple times.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyKillComputerProcessesParams{}
+			params.ComputerId = cmd.Int("computer-id")
+			params.Pids = cmd.IntSlice("pids")
+			res, err := api.LegacyKillComputerProcesses(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "list-pocket",
+		Usage:    "Call the ListPocket legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the pocket.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "series",
+				Usage:    "The name of the series.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "distribution",
+				Usage:    "The name of the distribution.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "search",
+				Usage: "Package name to search for.",
+			},
+			&cli.IntFlag{
+				Name:  "offset",
+				Usage: "The number of packages to skip before starting the list.",
+			},
+			&cli.IntFlag{
+				Name:  "limit",
+				Usage: "The number of packages to list.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyListPocketParams{}
+			params.Name = cmd.String("name")
+			params.Series = cmd.String("series")
+			params.Distribution = cmd.String("distribution")
+			if cmd.IsSet("search") {
+				v := cmd.String("search")
+				params.Search = &v
+			}
+			if cmd.IsSet("offset") {
+				v := cmd.Int("offset")
+				params.Offset = &v
+			}
+			if cmd.IsSet("limit") {
+				v := cmd.Int("limit")
+				params.Limit = &v
+			}
+			res, err := api.LegacyListPocket(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:      "list-script-profile-activities",
+		Usage:     "Call GET /api/script-profiles/{script_profile_id}/activities.",
+		Category:  "REST API",
+		ArgsUsage: "<script-profile-id>",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			scriptProfileId, err := intArg(cmd, 0, "script-profile-id")
+			if err != nil {
+				return err
+			}
+			res, err := api.ListScriptProfileActivities(ctx, scriptProfileId)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:      "list-script-profile-computers",
+		Usage:     "Call GET /api/script-profiles/{script_profile_id}/computers.",
+		Category:  "REST API",
+		ArgsUsage: "<script-profile-id>",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			scriptProfileId, err := intArg(cmd, 0, "script-profile-id")
+			if err != nil {
+				return err
+			}
+			res, err := api.ListScriptProfileComputers(ctx, scriptProfileId)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "list-script-profiles",
+		Usage:    "Call GET /api/script-profiles.",
+		Category: "REST API",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "archived",
+				Usage: "Filter script profiles by archived status.",
+			},
+			&cli.StringFlag{
+				Name:  "names",
+				Usage: "Comma-separated list of script profile titles to filter by.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.ListScriptProfilesParams{}
+			if cmd.IsSet("archived") {
+				v := client.ListScriptProfilesParamsArchived(cmd.String("archived"))
+				params.Archived = &v
+			}
+			if cmd.IsSet("names") {
+				v := client.ScriptProfileNamesQueryParam(cmd.String("names"))
+				params.Names = &v
+			}
+			res, err := api.ListScriptProfiles(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:      "list-script-profiles-by-script",
+		Usage:     "Call GET /api/scripts/{script_id}/script-profiles.",
+		Category:  "REST API",
+		ArgsUsage: "<script-id>",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			scriptId, err := intArg(cmd, 0, "script-id")
+			if err != nil {
+				return err
+			}
+			res, err := api.ListScriptProfilesByScript(ctx, scriptId)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "login-with-access-key",
+		Usage:    "Call POST /api/login/access-key.",
+		Category: "REST API",
+		Flags: []cli.Flag{
+			bodyCliFlag,
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			body, err := requestBody(cmd)
+			if err != nil {
+				return err
+			}
+			res, err := api.LoginWithAccessKeyWithBody(ctx, "application/json", body)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "login-with-password",
+		Usage:    "Call POST /api/login.",
+		Category: "REST API",
+		Flags: []cli.Flag{
+			bodyCliFlag,
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			body, err := requestBody(cmd)
+			if err != nil {
+				return err
+			}
+			res, err := api.LoginWithPasswordWithBody(ctx, "application/json", body)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "modify-package-profile",
+		Usage:    "Call the ModifyPackageProfile legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the package profile.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "title",
+				Usage: "The new title of the package profile.",
+			},
+			&cli.StringSliceFlag{
+				Name:  "add-constraints",
+				Usage: "List of constraints specifications to add in the form of \"depends packagename\" or \"conflicts packagename < 1.0\". Can be specified multiple times.",
+			},
+			&cli.StringSliceFlag{
+				Name:  "remove-constraints",
+				Usage: "List of constraints specifications to remove in the form of \"depends packagename\" or \"conflicts packagename < 1.0\". Can be specified multiple times.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyModifyPackageProfileParams{}
+			params.Name = cmd.String("name")
+			if cmd.IsSet("title") {
+				v := cmd.String("title")
+				params.Title = &v
+			}
+			if cmd.IsSet("add-constraints") {
+				v := cmd.StringSlice("add-constraints")
+				params.AddConstraints = &v
+			}
+			if cmd.IsSet("remove-constraints") {
+				v := cmd.StringSlice("remove-constraints")
+				params.RemoveConstraints = &v
+			}
+			res, err := api.LegacyModifyPackageProfile(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "pull-packages-to-pocket",
+		Usage:    "Call the PullPackagesToPocket legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the pocket to pull packages to.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "series",
+				Usage:    "The name of the series.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "distribution",
+				Usage:    "The name of the distribution.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyPullPackagesToPocketParams{}
+			params.Name = cmd.String("name")
+			params.Series = cmd.String("series")
+			params.Distribution = cmd.String("distribution")
+			res, err := api.LegacyPullPackagesToPocket(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "reboot-computers",
+		Usage:    "Call the RebootComputers legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.IntSliceFlag{
+				Name:     "computer-ids",
+				Usage:    "A list of computer ids to reboot. Can be specified multiple times.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "deliver-after",
+				Usage: "A time in the future to deliver the script.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyRebootComputersParams{}
+			params.ComputerIds = cmd.IntSlice("computer-ids")
+			if cmd.IsSet("deliver-after") {
+				v := cmd.String("deliver-after")
+				params.DeliverAfter = &v
+			}
+			res, err := api.LegacyRebootComputers(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:      "redact-script",
+		Usage:     "Call POST /api/scripts/{script_id}:redact.",
+		Category:  "REST API",
+		ArgsUsage: "<script-id>",
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			scriptId, err := intArg(cmd, 0, "script-id")
+			if err != nil {
+				return err
+			}
+			res, err := api.RedactScript(ctx, scriptId)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "reject-pending-computers",
+		Usage:    "Call the RejectPendingComputers legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.IntSliceFlag{
+				Name:     "computer-ids",
+				Usage:    "A list of computer IDs to reject. Can be specified multiple times.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyRejectPendingComputersParams{}
+			params.ComputerIds = cmd.IntSlice("computer-ids")
+			res, err := api.LegacyRejectPendingComputers(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "remove-access-group",
+		Usage:    "Call the RemoveAccessGroup legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the access group to remove.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyRemoveAccessGroupParams{}
+			params.Name = cmd.String("name")
+			res, err := api.LegacyRemoveAccessGroup(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "remove-access-groups-from-role",
+		Usage:    "Call the RemoveAccessGroupsFromRole legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the role to modify.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:     "access-groups",
+				Usage:    "A list of names of access groups to remove from the role. Can be specified multiple times.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyRemoveAccessGroupsFromRoleParams{}
+			params.Name = cmd.String("name")
+			params.AccessGroups = cmd.StringSlice("access-groups")
+			res, err := api.LegacyRemoveAccessGroupsFromRole(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "remove-annotation-from-computers",
+		Usage:    "Call the RemoveAnnotationFromComputers legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "query",
+				Usage:    "A query string used to select the computers from which to remove annotation.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "key",
+				Usage:    "Annotation key to disassociate.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyRemoveAnnotationFromComputersParams{}
+			params.Query = cmd.String("query")
+			params.Key = cmd.String("key")
+			res, err := api.LegacyRemoveAnnotationFromComputers(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "remove-apt-source",
+		Usage:    "Call the RemoveAPTSource legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the apt source to be removed.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyRemoveAPTSourceParams{}
+			params.Name = cmd.String("name")
+			res, err := api.LegacyRemoveAPTSource(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "remove-apt-source-from-repository-profile",
+		Usage:    "Call the RemoveAPTSourceFromRepositoryProfile legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "Name of the repository profile.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "apt-source",
+				Usage:    "The name of the APT source to remove.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyRemoveAPTSourceFromRepositoryProfileParams{}
+			params.Name = cmd.String("name")
+			params.AptSource = cmd.String("apt-source")
+			res, err := api.LegacyRemoveAPTSourceFromRepositoryProfile(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "remove-apt-sources",
+		Usage:    "Call the RemoveAPTSources legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:     "names",
+				Usage:    "List of names of the APT sources be removed. Multiple names can be supplied. Can be specified multiple times.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyRemoveAPTSourcesParams{}
+			params.Names = cmd.StringSlice("names")
+			res, err := api.LegacyRemoveAPTSources(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "remove-apt-sources-from-repository-profile",
+		Usage:    "Call the RemoveAPTSourcesFromRepositoryProfile legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "Name of the repository profile.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:     "apt-sources",
+				Usage:    "The names of the APT sources to remove. Can be specified multiple times.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyRemoveAPTSourcesFromRepositoryProfileParams{}
+			params.Name = cmd.String("name")
+			params.AptSources = cmd.StringSlice("apt-sources")
+			res, err := api.LegacyRemoveAPTSourcesFromRepositoryProfile(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "remove-computers",
+		Usage:    "Call the RemoveComputers legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.IntSliceFlag{
+				Name:     "computer-ids",
+				Usage:    "A list of computer ids to remove. Can be specified multiple times.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyRemoveComputersParams{}
+			params.ComputerIds = cmd.IntSlice("computer-ids")
+			res, err := api.LegacyRemoveComputers(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "remove-distribution",
+		Usage:    "Call the RemoveDistribution legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the distribution to remove.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyRemoveDistributionParams{}
+			params.Name = cmd.String("name")
+			res, err := api.LegacyRemoveDistribution(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "remove-gpg-key",
+		Usage:    "Call the RemoveGPGKey legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "Name of the GPG key to remove.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyRemoveGPGKeyParams{}
+			params.Name = cmd.String("name")
+			res, err := api.LegacyRemoveGPGKey(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "remove-package-filters-from-pocket",
+		Usage:    "Call the RemovePackageFiltersFromPocket legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the pocket to operate on.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "series",
+				Usage:    "The name of the series containing the pocket.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "distribution",
+				Usage:    "The name of the distribution containing the series.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:     "packages",
+				Usage:    "A list of names of packages to be added or removed from the pocket filter. Can be specified multiple times.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyRemovePackageFiltersFromPocketParams{}
+			params.Name = cmd.String("name")
+			params.Series = cmd.String("series")
+			params.Distribution = cmd.String("distribution")
+			params.Packages = cmd.StringSlice("packages")
+			res, err := api.LegacyRemovePackageFiltersFromPocket(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "remove-package-profile",
+		Usage:    "Call the RemovePackageProfile legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the package profile to remove.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyRemovePackageProfileParams{}
+			params.Name = cmd.String("name")
+			res, err := api.LegacyRemovePackageProfile(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "remove-packages",
+		Usage:    "Call the RemovePackages legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "query",
+				Usage:    "A qualified criteria to be used in the search.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:     "packages",
+				Usage:    "A list of package names on which to operate. Multiple package names can be supplied. Can be specified multiple times.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "deliver-after",
+				Usage: "A time in the future to perform the package operation.",
+			},
+			&cli.IntFlag{
+				Name:  "deliver-delay-window",
+				Usage: "Randomise delivery within the given time frame specified in minutes",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyRemovePackagesParams{}
+			params.Query = cmd.String("query")
+			params.Packages = cmd.StringSlice("packages")
+			if cmd.IsSet("deliver-after") {
+				v := cmd.String("deliver-after")
+				params.DeliverAfter = &v
+			}
+			if cmd.IsSet("deliver-delay-window") {
+				v := cmd.Int("deliver-delay-window")
+				params.DeliverDelayWindow = &v
+			}
+			res, err := api.LegacyRemovePackages(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "remove-packages-from-pocket",
+		Usage:    "Call the RemovePackagesFromPocket legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the pocket to remove packages from.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "series",
+				Usage:    "The name of the series containing the pocket.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "distribution",
+				Usage:    "The name of the distribution containing the series.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:     "packages",
+				Usage:    "A list of names of packages to be removed from the pockets. Can be specified multiple times.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyRemovePackagesFromPocketParams{}
+			params.Name = cmd.String("name")
+			params.Series = cmd.String("series")
+			params.Distribution = cmd.String("distribution")
+			params.Packages = cmd.StringSlice("packages")
+			res, err := api.LegacyRemovePackagesFromPocket(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "remove-permissions-from-role",
+		Usage:    "Call the RemovePermissionsFromRole legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the role to modify.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:     "permissions",
+				Usage:    "A list of permissions to remove. Can be specified multiple times.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyRemovePermissionsFromRoleParams{}
+			params.Name = cmd.String("name")
+			params.Permissions = cmd.StringSlice("permissions")
+			res, err := api.LegacyRemovePermissionsFromRole(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "remove-persons-from-role",
+		Usage:    "Call the RemovePersonsFromRole legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the role to modify.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:     "persons",
+				Usage:    "A list of email addresses of people to remove. Can be specified multiple times.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyRemovePersonsFromRoleParams{}
+			params.Name = cmd.String("name")
+			params.Persons = cmd.StringSlice("persons")
+			res, err := api.LegacyRemovePersonsFromRole(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "remove-pocket",
+		Usage:    "Call the RemovePocket legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the pocket to remove.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "series",
+				Usage:    "The name of the series containing the pocket.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "distribution",
+				Usage:    "The name of the distribution containing the series.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyRemovePocketParams{}
+			params.Name = cmd.String("name")
+			params.Series = cmd.String("series")
+			params.Distribution = cmd.String("distribution")
+			res, err := api.LegacyRemovePocket(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "remove-pockets-from-repository-profile",
+		Usage:    "Call the RemovePocketsFromRepositoryProfile legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "Name of the repository profile.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:     "pockets",
+				Usage:    "The names of the pockets to remove. Can be specified multiple times.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "series",
+				Usage:    "The name of the series the pocket belongs to.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "distribution",
+				Usage:    "The name of the distribution the series belongs to.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyRemovePocketsFromRepositoryProfileParams{}
+			params.Name = cmd.String("name")
+			params.Pockets = cmd.StringSlice("pockets")
+			params.Series = cmd.String("series")
+			params.Distribution = cmd.String("distribution")
+			res, err := api.LegacyRemovePocketsFromRepositoryProfile(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "remove-removal-profile",
+		Usage:    "Call the RemoveRemovalProfile legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the removal profile you wish to remove.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyRemoveRemovalProfileParams{}
+			params.Name = cmd.String("name")
+			res, err := api.LegacyRemoveRemovalProfile(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "remove-repository-profile",
+		Usage:    "Call the RemoveRepositoryProfile legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the repository profile to be removed.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyRemoveRepositoryProfileParams{}
+			params.Name = cmd.String("name")
+			res, err := api.LegacyRemoveRepositoryProfile(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "remove-repository-profiles",
+		Usage:    "Call the RemoveRepositoryProfiles legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:     "names",
+				Usage:    "Names of the repository profiles to be removed. Can be specified multiple times.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyRemoveRepositoryProfilesParams{}
+			params.Names = cmd.StringSlice("names")
+			res, err := api.LegacyRemoveRepositoryProfiles(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "remove-role",
+		Usage:    "Call the RemoveRole legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the role.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyRemoveRoleParams{}
+			params.Name = cmd.String("name")
+			res, err := api.LegacyRemoveRole(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "remove-saved-search",
+		Usage:    "Call the RemoveSavedSearch legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The \"slug\" name for this saved search.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyRemoveSavedSearchParams{}
+			params.Name = cmd.String("name")
+			res, err := api.LegacyRemoveSavedSearch(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "remove-script",
+		Usage:    "Call the RemoveScript legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:     "script-id",
+				Usage:    "The identity of the script to remove.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyRemoveScriptParams{}
+			params.ScriptId = cmd.Int("script-id")
+			res, err := api.LegacyRemoveScript(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "remove-script-attachment",
+		Usage:    "Call the RemoveScriptAttachment legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:     "script-id",
+				Usage:    "The identity of the script to remove.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "filename",
+				Usage:    "The filename of the attachment to remove.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyRemoveScriptAttachmentParams{}
+			params.ScriptId = cmd.Int("script-id")
+			params.Filename = cmd.String("filename")
+			res, err := api.LegacyRemoveScriptAttachment(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "remove-series",
+		Usage:    "Call the RemoveSeries legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the series to remove.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "distribution",
+				Usage:    "The name of the distribution.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyRemoveSeriesParams{}
+			params.Name = cmd.String("name")
+			params.Distribution = cmd.String("distribution")
+			res, err := api.LegacyRemoveSeries(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "remove-tags-from-computers",
+		Usage:    "Call the RemoveTagsFromComputers legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "query",
+				Usage:    "A query string used to select the computers to remove tags from.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:     "tags",
+				Usage:    "Tag names to be removed. Can be specified multiple times.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyRemoveTagsFromComputersParams{}
+			params.Query = cmd.String("query")
+			params.Tags = cmd.StringSlice("tags")
+			res, err := api.LegacyRemoveTagsFromComputers(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "remove-upgrade-profile",
+		Usage:    "Call the RemoveUpgradeProfile legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the upgrade profile you wish to cancel.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyRemoveUpgradeProfileParams{}
+			params.Name = cmd.String("name")
+			res, err := api.LegacyRemoveUpgradeProfile(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "remove-uploader-gpg-keys-from-pocket",
+		Usage:    "Call the RemoveUploaderGPGKeysFromPocket legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the pocket on which to associate keys.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "series",
+				Usage:    "The name of the series containing the pocket.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "distribution",
+				Usage:    "The name of the distribution containing the series.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:     "gpg-keys",
+				Usage:    "A list of GPG keys on which to operate. Can be specified multiple times.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyRemoveUploaderGPGKeysFromPocketParams{}
+			params.Name = cmd.String("name")
+			params.Series = cmd.String("series")
+			params.Distribution = cmd.String("distribution")
+			params.GpgKeys = cmd.StringSlice("gpg-keys")
+			res, err := api.LegacyRemoveUploaderGPGKeysFromPocket(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "remove-wsl-hosts",
+		Usage:    "Call the RemoveWSLHosts legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.IntSliceFlag{
+				Name:     "computer-ids",
+				Usage:    "A list of computer ids to remove. Can be specified multiple times.",
+				Required: true,
+			},
+			&cli.BoolFlag{
+				Name:  "cascade-to-children",
+				Usage: "If true, removed computers will also include child computers.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyRemoveWSLHostsParams{}
+			params.ComputerIds = cmd.IntSlice("computer-ids")
+			if cmd.IsSet("cascade-to-children") {
+				v := cmd.Bool("cascade-to-children")
+				params.CascadeToChildren = &v
+			}
+			res, err := api.LegacyRemoveWSLHosts(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "rename-computers",
+		Usage:    "Call the RenameComputers legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringMapFlag{
+				Name:     "computer-titles",
+				Usage:    "mapping of computer_ids to computer titles",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyRenameComputersParams{}
+			params.ComputerTitles = cmd.StringMap("computer-titles")
+			res, err := api.LegacyRenameComputers(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "set-default-child-computer",
+		Usage:    "Call the SetDefaultChildComputer legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:     "parent-id",
+				Usage:    "The id of the parent host computer.",
+				Required: true,
+			},
+			&cli.IntFlag{
+				Name:     "child-id",
+				Usage:    "The id of the child computer to set as default.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacySetDefaultChildComputerParams{}
+			params.ParentId = cmd.Int("parent-id")
+			params.ChildId = cmd.Int("child-id")
+			res, err := api.LegacySetDefaultChildComputer(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "set-settings",
+		Usage:    "Call the SetSettings legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:     "key-values",
+				Usage:    "Key/value pairs to set, separated by '='. 'true' and 'false' strings will be interpreted as booleans. Can be specified multiple times.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacySetSettingsParams{}
+			params.KeyValues = cmd.StringSlice("key-values")
+			res, err := api.LegacySetSettings(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "shutdown-computers",
+		Usage:    "Call the ShutdownComputers legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.IntSliceFlag{
+				Name:     "computer-ids",
+				Usage:    "A list of computer ids to shutdown. Can be specified multiple times.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "deliver-after",
+				Usage: "A time in the future to deliver the script.",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyShutdownComputersParams{}
+			params.ComputerIds = cmd.IntSlice("computer-ids")
+			if cmd.IsSet("deliver-after") {
+				v := cmd.String("deliver-after")
+				params.DeliverAfter = &v
+			}
+			res, err := api.LegacyShutdownComputers(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "shutdown-host-computer",
+		Usage:    "Call the ShutdownHostComputer legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:     "parent-id",
+				Usage:    "The id of the parent host computer.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyShutdownHostComputerParams{}
+			params.ParentId = cmd.Int("parent-id")
+			res, err := api.LegacyShutdownHostComputer(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "start-child-computers",
+		Usage:    "Call the StartChildComputers legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.IntSliceFlag{
+				Name:     "computer-ids",
+				Usage:    "A list of child computer ids to start. Can be specified multiple times.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyStartChildComputersParams{}
+			params.ComputerIds = cmd.IntSlice("computer-ids")
+			res, err := api.LegacyStartChildComputers(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "stop-child-computers",
+		Usage:    "Call the StopChildComputers legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.IntSliceFlag{
+				Name:     "computer-ids",
+				Usage:    "A list of child computer ids to stop. Can be specified multiple times.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyStopChildComputersParams{}
+			params.ComputerIds = cmd.IntSlice("computer-ids")
+			res, err := api.LegacyStopChildComputers(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "subscribe-to-alert",
+		Usage:    "Call the SubscribeToAlert legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "alert-type",
+				Usage:    "The alert type to add a subscription to.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacySubscribeToAlertParams{}
+			params.AlertType = cmd.String("alert-type")
+			res, err := api.LegacySubscribeToAlert(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "sync-mirror-pocket",
+		Usage:    "Call the SyncMirrorPocket legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "name",
+				Usage:    "The name of the pocket to synchronize.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "series",
+				Usage:    "The name of the series.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "distribution",
+				Usage:    "The name of the distribution.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacySyncMirrorPocketParams{}
+			params.Name = cmd.String("name")
+			params.Series = cmd.String("series")
+			params.Distribution = cmd.String("distribution")
+			res, err := api.LegacySyncMirrorPocket(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "terminate-computer-processes",
+		Usage:    "Call the TerminateComputerProcesses legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:     "computer-id",
+				Usage:    "The numerical ID of the computer.",
+				Required: true,
+			},
+			&cli.IntSliceFlag{
+				Name:     "pids",
+				Usage:    "A comma separated list of PIDs of the processes to send an TERM signal Can be specified multiple times.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyTerminateComputerProcessesParams{}
+			params.ComputerId = cmd.Int("computer-id")
+			params.Pids = cmd.IntSlice("pids")
+			res, err := api.LegacyTerminateComputerProcesses(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "unsubscribe-from-alert",
+		Usage:    "Call the UnsubscribeFromAlert legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "alert-type",
+				Usage:    "The alert type to remove a subscription from.",
+				Required: true,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyUnsubscribeFromAlertParams{}
+			params.AlertType = cmd.String("alert-type")
+			res, err := api.LegacyUnsubscribeFromAlert(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:      "update-script-profile",
+		Usage:     "Call PATCH /api/script-profiles/{script_profile_id}.",
+		Category:  "REST API",
+		ArgsUsage: "<script-profile-id>",
+		Flags: []cli.Flag{
+			bodyCliFlag,
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			scriptProfileId, err := intArg(cmd, 0, "script-profile-id")
+			if err != nil {
+				return err
+			}
+			body, err := requestBody(cmd)
+			if err != nil {
+				return err
+			}
+			res, err := api.UpdateScriptProfileWithBody(ctx, scriptProfileId, "application/json", body)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+	{
+		Name:     "upgrade-packages",
+		Usage:    "Call the UpgradePackages legacy action.",
+		Category: "legacy actions",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "query",
+				Usage:    "A qualified criteria to be used in the search.",
+				Required: true,
+			},
+			&cli.StringSliceFlag{
+				Name:  "packages",
+				Usage: "List of package names on which to perform an upgrade. Multiple package names can be supplied like packages.1=foo and packages.2=bar. Can be specified multiple times.",
+			},
+			&cli.BoolFlag{
+				Name:  "security-only",
+				Usage: "If 'true' then only packages with USNs, i.e. security upgrades will be applied.",
+			},
+			&cli.StringFlag{
+				Name:  "deliver-after",
+				Usage: "A time in the future to perform the package upgrade.",
+			},
+			&cli.IntFlag{
+				Name:  "deliver-delay-window",
+				Usage: "Randomise delivery within the given time frame specified in minutes",
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			params := &client.LegacyUpgradePackagesParams{}
+			params.Query = cmd.String("query")
+			if cmd.IsSet("packages") {
+				v := cmd.StringSlice("packages")
+				params.Packages = &v
+			}
+			if cmd.IsSet("security-only") {
+				v := cmd.Bool("security-only")
+				params.SecurityOnly = &v
+			}
+			if cmd.IsSet("deliver-after") {
+				v := cmd.String("deliver-after")
+				params.DeliverAfter = &v
+			}
+			if cmd.IsSet("deliver-delay-window") {
+				v := cmd.Int("deliver-delay-window")
+				params.DeliverDelayWindow = &v
+			}
+			res, err := api.LegacyUpgradePackages(ctx, params)
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+}
@@ -0,0 +1,138 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jansdhillon/landscape-go-api-client/client"
+	"github.com/urfave/cli/v3"
+)
+
+const bodyFlag = "body"
+
+var bodyCliFlag = &cli.StringFlag{
+	Name:     bodyFlag,
+	Usage:    "The JSON request body. Use @path to read it from a file, or @- to read it from stdin.",
+	Required: true,
+}
+
+// apiCommands returns the generated commands, with the hand-written commands
+// that cover the same operation swapped in. Hand-written commands take care of
+// things like base64 encoding that the raw operations leave to the caller.
+func apiCommands() []*cli.Command {
+	overrides := map[string]*cli.Command{
+		"create-script":            scriptCmd.Command("create"),
+		"edit-script":              scriptCmd.Command("edit"),
+		"get-script":               scriptCmd.Command("get"),
+		"get-scripts":              scriptCmd.Command("list"),
+		"remove-script":            scriptCmd.Command("delete"),
+		"copy-script":              scriptCmd.Command("copy"),
+		"get-script-code":          scriptCmd.Command("code"),
+		"execute-script":           scriptCmd.Command("run"),
+		"create-script-attachment": scriptCmd.Command("attachment").Command("create"),
+		"get-script-attachment":    scriptCmd.Command("attachment").Command("get"),
+		"remove-script-attachment": scriptCmd.Command("attachment").Command("remove"),
+		"create-script-profile":    scriptProfileCmd.Command("create"),
+		"update-script-profile":    scriptProfileCmd.Command("update"),
+		"import-gpg-key":           gpgKeyCmd.Command("import"),
+		"create-distribution":      distributionCmd.Command("create"),
+		"create-series":            seriesCmd.Command("create"),
+		"create-pocket":            pocketCmd.Command("create"),
+		"sync-mirror-pocket":       mirrorCmd.Command("sync"),
+	}
+
+	commands := make([]*cli.Command, 0, len(generatedCommands))
+	for _, generated := range generatedCommands {
+		override, ok := overrides[generated.Name]
+		if !ok || override == nil {
+			commands = append(commands, generated)
+			continue
+		}
+
+		commands = append(commands, &cli.Command{
+			Name:      generated.Name,
+			Usage:     override.Usage,
+			Category:  generated.Category,
+			ArgsUsage: override.ArgsUsage,
+			Flags:     override.Flags,
+			Action:    override.Action,
+		})
+	}
+
+	return commands
+}
+
+func apiClient(ctx context.Context) (*client.ClientWithResponses, error) {
+	api, ok := ctx.Value(apiClientKey).(*client.ClientWithResponses)
+	if !ok || api == nil {
+		return nil, fmt.Errorf("api client not initialized")
+	}
+	return api, nil
+}
+
+// intArg parses the positional argument at index as an int.
+func intArg(cmd *cli.Command, index int, name string) (int, error) {
+	s, err := stringArg(cmd, index, name)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be an integer: %w", name, err)
+	}
+	return n, nil
+}
+
+// stringArg returns the positional argument at index.
+func stringArg(cmd *cli.Command, index int, name string) (string, error) {
+	s := cmd.Args().Get(index)
+	if s == "" {
+		return "", fmt.Errorf("%s must be provided as argument %d", name, index+1)
+	}
+	return s, nil
+}
+
+// requestBody returns the body given with --body, reading it from a file or
+// stdin if it starts with @.
+func requestBody(cmd *cli.Command) (io.Reader, error) {
+	body := cmd.String(bodyFlag)
+
+	path, ok := strings.CutPrefix(body, "@")
+	if !ok {
+		return strings.NewReader(body), nil
+	}
+
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(cmd.Root().Reader)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+
+	return bytes.NewReader(data), nil
+}
+
+// intMap converts the values of a key=value flag to ints.
+func intMap(m map[string]string) (map[string]int, error) {
+	out := make(map[string]int, len(m))
+	for k, v := range m {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("value for %s must be an integer: %w", k, err)
+		}
+		out[k] = n
+	}
+	return out, nil
+}
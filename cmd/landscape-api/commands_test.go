@@ -0,0 +1,146 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jansdhillon/landscape-go-api-client/client"
+	"github.com/jansdhillon/landscape-go-api-client/client/clienttest"
+)
+
+// runCommand runs the CLI with args, logged into server, and returns what it
+// wrote to stdout.
+//
+// The commands are package variables, and their flags keep the values they
+// were last parsed with, so a test shouldn't run the same command twice with
+// different flags.
+func runCommand(t *testing.T, server *clienttest.Server, args ...string) (string, error) {
+	t.Helper()
+
+	var out bytes.Buffer
+	root := rootCmd()
+	root.Writer = &out
+	root.ErrWriter = io.Discard
+
+	args = append([]string{
+		"landscape-api",
+		"--config", filepath.Join(t.TempDir(), "config.yaml"),
+		"--base-url", server.URL,
+		"--access-key", clienttest.DefaultAccessKey,
+		"--secret-key", clienttest.DefaultSecretKey,
+		"--secret-store", secretStoreNone,
+		"--insecure-skip-verify",
+		"--no-token-cache",
+	}, args...)
+
+	err := root.Run(context.Background(), args)
+	return out.String(), err
+}
+
+func TestScriptProfileOverridesCheckLimits(t *testing.T) {
+	server := clienttest.NewServer(clienttest.WithScriptProfileLimits(client.ScriptProfileLimits{MaxNumComputers: 100, MaxNumProfiles: 10, MinInterval: 60}))
+	defer server.Close()
+
+	script := server.AddScript(clienttest.Script{Title: "nightly", Code: "#!/bin/sh\necho hi", V2: true, Username: "root", TimeLimit: 300})
+
+	api, err := server.NewClient()
+	if err != nil {
+		t.Fatalf("failed to init client: %v", err)
+	}
+	profile, err := api.CreateScriptProfileWithResponse(context.Background(), client.ScriptProfileCreateBody{
+		Title:     "nightly",
+		ScriptId:  script.ID,
+		TimeLimit: 300,
+		Username:  "root",
+		Trigger:   client.RecurringTrigger("0 3 * * *", time.Now()),
+	})
+	if err != nil {
+		t.Fatalf("CreateScriptProfileWithResponse failed: %v", err)
+	}
+	if profile.JSON201 == nil {
+		t.Fatalf("expected 201, got %d: %s", profile.StatusCode(), profile.Body)
+	}
+
+	// The same flags for every command, as create and update share them.
+	every5Minutes := []string{"--cron", "*/5 * * * *", "--all-computers"}
+	for _, args := range [][]string{
+		append([]string{"script-profile", "create", "--script", fmt.Sprint(script.ID)}, every5Minutes...),
+		append([]string{"create-script-profile", "--script", fmt.Sprint(script.ID)}, every5Minutes...),
+		append([]string{"script-profile", "update", fmt.Sprint(profile.JSON201.Id)}, every5Minutes...),
+		append([]string{"update-script-profile", fmt.Sprint(profile.JSON201.Id)}, every5Minutes...),
+	} {
+		server.ResetRequests()
+
+		if _, err := runCommand(t, server, args...); !errors.Is(err, client.ErrScriptProfileLimit) {
+			t.Fatalf("expected %v to fail with ErrScriptProfileLimit, got %v", args, err)
+		}
+		for _, req := range server.Requests() {
+			if req.Operation == "CreateScriptProfile" || req.Operation == "UpdateScriptProfile" {
+				t.Fatalf("expected %v not to send %s", args, req.Operation)
+			}
+		}
+	}
+}
+
+func TestScriptOverrides(t *testing.T) {
+	server := clienttest.NewServer()
+	defer server.Close()
+
+	script := server.AddScript(clienttest.Script{
+		Title:       "backup",
+		Code:        "#!/bin/sh\necho backup",
+		Attachments: []clienttest.Attachment{{Filename: "notes.txt", Content: []byte("notes")}},
+	})
+	id := fmt.Sprint(script.ID)
+
+	for _, tt := range []struct {
+		args []string
+		want string
+	}{
+		{args: []string{"get-scripts"}, want: `"title": "backup"`},
+		{args: []string{"get-script-code", id}, want: "echo backup"},
+		{args: []string{"copy-script", "--title", "backup copy", id}, want: `"title": "backup copy"`},
+		{args: []string{"remove-script-attachment", "--script-id", id, "--filename", "notes.txt"}},
+	} {
+		out, err := runCommand(t, server, tt.args...)
+		if err != nil {
+			t.Fatalf("%v failed: %v", tt.args, err)
+		}
+		if !strings.Contains(out, tt.want) {
+			t.Fatalf("expected the output of %v to contain %q, got %s", tt.args, tt.want, out)
+		}
+	}
+
+	if stored, _ := server.Script(script.ID); len(stored.Attachments) != 0 {
+		t.Fatalf("expected the attachment to be removed, got %+v", stored.Attachments)
+	}
+
+	if _, err := runCommand(t, server, "remove-script", id); err != nil {
+		t.Fatalf("remove-script failed: %v", err)
+	}
+	if _, ok := server.Script(script.ID); ok {
+		t.Fatal("expected the script to be removed")
+	}
+}
+
+func TestScriptRunRejectsInvalidID(t *testing.T) {
+	server := clienttest.NewServer()
+	defer server.Close()
+
+	_, err := runCommand(t, server, "execute-script", "--query", "tag:web", "nightly")
+	if err == nil || !strings.Contains(err.Error(), "script ID must be an integer") {
+		t.Fatalf("expected an invalid script ID error, got %v", err)
+	}
+	for _, req := range server.Requests() {
+		if req.Operation == "LegacyExecuteScript" {
+			t.Fatal("expected no LegacyExecuteScript request")
+		}
+	}
+}
@@ -0,0 +1,545 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jansdhillon/landscape-go-api-client/client"
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	configFlag           = "config"
+	contextFlag          = "context"
+	authMethodFlag       = "auth-method"
+	oidcIssuerFlag       = "oidc-issuer"
+	oidcClientIDFlag     = "oidc-client-id"
+	oidcExchangePathFlag = "oidc-exchange-path"
+)
+
+const (
+	authMethodAccessKey = "access-key"
+	authMethodPassword  = "password"
+	authMethodOIDC      = "oidc"
+)
+
+// noAPIClientKey marks commands, in their Metadata, that run without logging
+// into Landscape.
+const noAPIClientKey = "no-api-client"
+
+// Config is the CLI configuration file. It holds named contexts, each
+// describing how to reach and log into one Landscape instance.
+type Config struct {
+	CurrentContext string              `yaml:"current-context,omitempty"`
+	Contexts       map[string]*Context `yaml:"contexts,omitempty"`
+}
+
+// Context is a named set of defaults for the global flags. Secrets are not
+// stored here; provide them with flags or environment variables.
+type Context struct {
+	BaseURL    string `yaml:"base-url,omitempty"`
+	AuthMethod string `yaml:"auth-method,omitempty"`
+	AccessKey  string `yaml:"access-key,omitempty"`
+	Email      string `yaml:"email,omitempty"`
+	Account    string `yaml:"account,omitempty"`
+	CACert     string `yaml:"ca-cert,omitempty"`
+
+	ClientCert    string `yaml:"client-cert,omitempty"`
+	ClientKey     string `yaml:"client-key,omitempty"`
+	TLSServerName string `yaml:"tls-server-name,omitempty"`
+
+	OIDCIssuer       string `yaml:"oidc-issuer,omitempty"`
+	OIDCClientID     string `yaml:"oidc-client-id,omitempty"`
+	OIDCExchangePath string `yaml:"oidc-exchange-path,omitempty"`
+}
+
+// defaultConfigPath returns ~/.config/landscape-api/config.yaml, or the
+// equivalent for the platform.
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "landscape-api", "config.yaml")
+}
+
+// loadConfig reads the config file at path. A missing file is an empty
+// config.
+func loadConfig(path string) (*Config, error) {
+	cfg := &Config{Contexts: map[string]*Context{}}
+	if path == "" {
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	if cfg.Contexts == nil {
+		cfg.Contexts = map[string]*Context{}
+	}
+
+	return cfg, nil
+}
+
+// save writes the config to path, readable only by the current user.
+func (cfg *Config) save(path string) error {
+	if path == "" {
+		return fmt.Errorf("no config file path; set --%s", configFlag)
+	}
+
+	var data bytes.Buffer
+	enc := yaml.NewEncoder(&data)
+	enc.SetIndent(2)
+	if err := enc.Encode(cfg); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".config-*.yaml")
+	if err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data.Bytes()); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// context returns the context with the given name, or the current context if
+// name is empty. It returns nil if no context is selected.
+func (cfg *Config) context(name string) (*Context, error) {
+	if name == "" {
+		name = cfg.CurrentContext
+	}
+	if name == "" {
+		return nil, nil
+	}
+
+	lctx, ok := cfg.Contexts[name]
+	if !ok {
+		return nil, fmt.Errorf("no context named %q in the config file", name)
+	}
+	return lctx, nil
+}
+
+// stringSetting returns the value of a global flag, falling back to the value
+// from the selected context if the flag wasn't set on the command line or in
+// the environment.
+func stringSetting(c *cli.Command, flag, fromContext string) string {
+	if c.IsSet(flag) || fromContext == "" {
+		return c.String(flag)
+	}
+	return fromContext
+}
+
+// settings are the connection and login values for a command, taken from
+// flags and environment variables, falling back to the selected context.
+type settings struct {
+	baseURL    string
+	authMethod string
+	email      string
+	password   string
+	account    string
+	accessKey  string
+	secretKey  string
+	caCert     string
+
+	clientCert    string
+	clientKey     string
+	tlsServerName string
+
+	oidcIssuer       string
+	oidcClientID     string
+	oidcExchangePath string
+
+	token     string
+	tokenFile string
+}
+
+// loadSettings resolves the settings from the root command's flags and the
+// context selected with --context, or the current context.
+func loadSettings(c *cli.Command) (*settings, error) {
+	cfg, err := loadConfig(c.String(configFlag))
+	if err != nil {
+		return nil, err
+	}
+	lctx, err := cfg.context(c.String(contextFlag))
+	if err != nil {
+		return nil, err
+	}
+	if lctx == nil {
+		lctx = &Context{}
+	}
+
+	return &settings{
+		baseURL:    stringSetting(c, baseURLFlag, lctx.BaseURL),
+		authMethod: lctx.AuthMethod,
+		email:      stringSetting(c, emailFlag, lctx.Email),
+		password:   c.String(passwordFlag),
+		account:    stringSetting(c, accountFlag, lctx.Account),
+		accessKey:  stringSetting(c, accessKeyFlag, lctx.AccessKey),
+		secretKey:  c.String(secretKeyFlag),
+		caCert:     stringSetting(c, caCertFlag, lctx.CACert),
+
+		clientCert:    stringSetting(c, clientCertFlag, lctx.ClientCert),
+		clientKey:     stringSetting(c, clientKeyFlag, lctx.ClientKey),
+		tlsServerName: stringSetting(c, tlsServerNameFlag, lctx.TLSServerName),
+
+		oidcIssuer:       stringSetting(c, oidcIssuerFlag, lctx.OIDCIssuer),
+		oidcClientID:     stringSetting(c, oidcClientIDFlag, lctx.OIDCClientID),
+		oidcExchangePath: stringSetting(c, oidcExchangePathFlag, lctx.OIDCExchangePath),
+
+		token:     c.String(tokenFlag),
+		tokenFile: c.String(tokenFileFlag),
+	}, nil
+}
+
+// loginProvider returns the LoginProvider for the credentials in s. A token or
+// token file is used as it is, without logging in. Otherwise, the context's
+// auth method only decides between email/password and access keys
+// when credentials for both were given. OIDC is used when it is the auth
+// method, or when an issuer is set and no other credentials are; device login
+// prompts are written to w.
+func (s *settings) loginProvider(w io.Writer) (client.LoginProvider, error) {
+	if s.token != "" {
+		return client.NewStaticTokenProvider(s.token), nil
+	}
+	if s.tokenFile != "" {
+		return client.NewTokenFileProvider(s.tokenFile), nil
+	}
+
+	if s.useOIDC() {
+		if s.oidcClientID == "" {
+			return nil, fmt.Errorf("OIDC login requires the --%s flag or the LANDSCAPE_OIDC_CLIENT_ID env var", oidcClientIDFlag)
+		}
+		if s.oidcExchangePath == "" {
+			return nil, fmt.Errorf("OIDC login requires the --%s flag or the LANDSCAPE_OIDC_EXCHANGE_PATH env var", oidcExchangePathFlag)
+		}
+
+		p := client.NewOIDCProvider(s.oidcIssuer, s.oidcClientID, s.oidcExchangePath)
+		p.Prompt = func(code client.DeviceCode) error {
+			uri := code.VerificationURIComplete
+			if uri == "" {
+				uri = code.VerificationURI
+			}
+			_, err := fmt.Fprintf(w, "To log in, open %s and enter the code %s\n", uri, code.UserCode)
+			return err
+		}
+		return p, nil
+	}
+
+	usePassword := s.email != "" && s.password != ""
+	if usePassword && s.accessKey != "" && s.secretKey != "" && s.authMethod == authMethodAccessKey {
+		usePassword = false
+	}
+
+	if usePassword {
+		if s.account != "" {
+			return client.NewEmailPasswordProvider(s.email, s.password, &s.account), nil
+		}
+		return client.NewEmailPasswordProvider(s.email, s.password, nil), nil
+	}
+
+	if s.accessKey == "" || s.secretKey == "" {
+		if s.authMethod == authMethodPassword {
+			return nil, fmt.Errorf("the context logs in with a password: provide the -p flag or set the LANDSCAPE_PASSWORD env var")
+		}
+		return nil, fmt.Errorf("must provide the -e & -p flags or the -ak & -sk flags, or set either the LANDSCAPE_EMAIL & LANDSCAPE_PASSWORD env vars or the LANDSCAPE_ACCESS_KEY & LANDSCAPE_SECRET_KEY env vars, or save them with the login command")
+	}
+
+	return client.NewAccessKeyProvider(s.accessKey, s.secretKey), nil
+}
+
+// resolveSecrets fills in the secret key and password that weren't given as
+// flags or env vars, from --secret-key-file or the secret store selected with
+// --secret-store. With the default store, a missing or unavailable Secret
+// Service is ignored.
+func (s *settings) resolveSecrets(c *cli.Command) error {
+	if s.token != "" || s.tokenFile != "" {
+		return nil
+	}
+
+	if path := c.String(secretKeyFileFlag); s.secretKey == "" && path != "" {
+		secret, err := readSecretFile(path)
+		if err != nil {
+			return err
+		}
+		s.secretKey = secret
+	}
+
+	storeName := c.String(secretStoreFlag)
+	store, err := openSecretStore(storeName)
+	if err != nil || store == nil {
+		return err
+	}
+	strict := storeName != "" && storeName != secretStoreAuto
+
+	lookup := func(field *string, kind, user string) error {
+		if *field != "" || user == "" {
+			return nil
+		}
+		secret, err := store.Get(secretKeyName(s.baseURL, kind, user))
+		if errors.Is(err, errSecretNotFound) || (err != nil && !strict) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read credentials from %s: %w", store, err)
+		}
+		*field = secret
+		return nil
+	}
+
+	if err := lookup(&s.secretKey, authMethodAccessKey, s.accessKey); err != nil {
+		return err
+	}
+	return lookup(&s.password, authMethodPassword, s.email)
+}
+
+func (s *settings) useOIDC() bool {
+	if s.oidcIssuer == "" {
+		return false
+	}
+	if s.authMethod == authMethodOIDC {
+		return true
+	}
+	return s.authMethod == "" && s.password == "" && s.secretKey == ""
+}
+
+// requiresAPIClient reports whether the command that is about to run needs a
+// logged in API client, by following the subcommands named in the root
+// command's arguments.
+func requiresAPIClient(root *cli.Command) bool {
+	cmd := root
+	for _, arg := range root.Args().Slice() {
+		if strings.HasPrefix(arg, "-") {
+			continue
+		}
+		sub := cmd.Command(arg)
+		if sub == nil {
+			break
+		}
+		if noAPI, _ := sub.Metadata[noAPIClientKey].(bool); noAPI {
+			return false
+		}
+		cmd = sub
+	}
+	return true
+}
+
+var configCmd = &cli.Command{
+	Name:     "config",
+	Usage:    "Manage the contexts in the CLI configuration file.",
+	Metadata: map[string]any{noAPIClientKey: true},
+	Commands: []*cli.Command{
+		{
+			Name:   "list",
+			Usage:  "List the contexts. The current context is marked with *.",
+			Action: listContextsAction,
+		},
+		{
+			Name:      "use-context",
+			Usage:     "Set the context used when --context isn't given.",
+			ArgsUsage: "[context]",
+			Action:    useContextAction,
+		},
+		{
+			Name:      "set",
+			Usage:     "Create or update a context. Only the given flags are changed.",
+			ArgsUsage: "[context]",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  baseURLFlag,
+					Usage: "The base URL of Landscape.",
+				},
+				&cli.StringFlag{
+					Name:  authMethodFlag,
+					Usage: "How to log in: access-key, password or oidc.",
+				},
+				&cli.StringFlag{
+					Name:  accessKeyFlag,
+					Usage: "The access key to log in with. The secret key is not stored.",
+				},
+				&cli.StringFlag{
+					Name:  emailFlag,
+					Usage: "The email to log in with. The password is not stored.",
+				},
+				&cli.StringFlag{
+					Name:  accountFlag,
+					Usage: "The account to log into.",
+				},
+				&cli.StringFlag{
+					Name:  caCertFlag,
+					Usage: "Path to a PEM-encoded CA certificate file for verifying the server's TLS certificate.",
+				},
+				&cli.StringFlag{
+					Name:  clientCertFlag,
+					Usage: "Path to a PEM-encoded client certificate to present.",
+				},
+				&cli.StringFlag{
+					Name:  clientKeyFlag,
+					Usage: "Path to the PEM-encoded private key of the client certificate.",
+				},
+				&cli.StringFlag{
+					Name:  tlsServerNameFlag,
+					Usage: "The server name to verify the server's TLS certificate against.",
+				},
+				&cli.StringFlag{
+					Name:  oidcIssuerFlag,
+					Usage: "The OIDC issuer URL to log in through.",
+				},
+				&cli.StringFlag{
+					Name:  oidcClientIDFlag,
+					Usage: "The client ID registered with the OIDC issuer.",
+				},
+				&cli.StringFlag{
+					Name:  oidcExchangePathFlag,
+					Usage: "The Landscape endpoint, relative to the base URL, that exchanges OIDC ID tokens for a JWT.",
+				},
+				&cli.BoolFlag{
+					Name:  "use",
+					Usage: "Also make this the current context.",
+				},
+			},
+			Action: setContextAction,
+		},
+	},
+}
+
+func listContextsAction(ctx context.Context, cmd *cli.Command) error {
+	cfg, err := loadConfig(cmd.Root().String(configFlag))
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(cfg.Contexts))
+	for name := range cfg.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tw := tabwriter.NewWriter(cmd.Root().Writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CURRENT\tNAME\tBASE URL\tAUTH METHOD\tACCOUNT")
+	for _, name := range names {
+		lctx := cfg.Contexts[name]
+		current := ""
+		if name == cfg.CurrentContext {
+			current = "*"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", current, name, lctx.BaseURL, lctx.AuthMethod, lctx.Account)
+	}
+
+	return tw.Flush()
+}
+
+func useContextAction(ctx context.Context, cmd *cli.Command) error {
+	name := cmd.Args().First()
+	if name == "" {
+		return fmt.Errorf("context name must be provided as the first argument")
+	}
+
+	path := cmd.Root().String(configFlag)
+	cfg, err := loadConfig(path)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := cfg.Contexts[name]; !ok {
+		return fmt.Errorf("no context named %q in the config file", name)
+	}
+	cfg.CurrentContext = name
+
+	if err := cfg.save(path); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.Root().Writer, "Switched to context %q.\n", name)
+	return nil
+}
+
+func setContextAction(ctx context.Context, cmd *cli.Command) error {
+	name := cmd.Args().First()
+	if name == "" {
+		return fmt.Errorf("context name must be provided as the first argument")
+	}
+
+	switch method := cmd.String(authMethodFlag); method {
+	case "", authMethodAccessKey, authMethodPassword, authMethodOIDC:
+	default:
+		return fmt.Errorf("unknown auth method %q, must be access-key, password or oidc", method)
+	}
+
+	path := cmd.Root().String(configFlag)
+	cfg, err := loadConfig(path)
+	if err != nil {
+		return err
+	}
+
+	lctx, ok := cfg.Contexts[name]
+	if !ok {
+		lctx = &Context{}
+		cfg.Contexts[name] = lctx
+	}
+
+	for flag, field := range map[string]*string{
+		baseURLFlag:          &lctx.BaseURL,
+		authMethodFlag:       &lctx.AuthMethod,
+		accessKeyFlag:        &lctx.AccessKey,
+		emailFlag:            &lctx.Email,
+		accountFlag:          &lctx.Account,
+		caCertFlag:           &lctx.CACert,
+		clientCertFlag:       &lctx.ClientCert,
+		clientKeyFlag:        &lctx.ClientKey,
+		tlsServerNameFlag:    &lctx.TLSServerName,
+		oidcIssuerFlag:       &lctx.OIDCIssuer,
+		oidcClientIDFlag:     &lctx.OIDCClientID,
+		oidcExchangePathFlag: &lctx.OIDCExchangePath,
+	} {
+		if cmd.IsSet(flag) {
+			*field = cmd.String(flag)
+		}
+	}
+
+	if cmd.Bool("use") || cfg.CurrentContext == "" {
+		cfg.CurrentContext = name
+	}
+
+	if err := cfg.save(path); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.Root().Writer, "Context %q saved.\n", name)
+	return nil
+}
@@ -0,0 +1,5 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+//go:generate go run ./internal/commandgen -in ../../client/client.gen.go -out commands.gen.go
@@ -0,0 +1,523 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Command commandgen reads the generated client and writes a cli.Command for
+// every method of ClientInterface. Query parameters become flags, documented
+// with the comments on the params struct fields, and path parameters become
+// positional arguments. Operations with a JSON body take it with --body.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+var (
+	actionPattern = regexp.MustCompile(`\?action=(\w+)`)
+	methodPattern = regexp.MustCompile(`^"(GET|POST|PUT|PATCH|DELETE|HEAD)"$`)
+	pathParamName = regexp.MustCompile(`^"(\w+)"$`)
+)
+
+// operation is one method of ClientInterface.
+type operation struct {
+	Method     string
+	Name       string
+	Usage      string
+	Category   string
+	PathArgs   []pathArg
+	ParamsType string
+	Flags      []flagField
+	HasBody    bool
+}
+
+type pathArg struct {
+	Name string
+	Var  string
+	Kind string // "int" or "string"
+}
+
+type flagField struct {
+	Field    string
+	Name     string
+	Usage    string
+	Kind     string // string, int, bool, stringSlice, intSlice, stringMap, intMap
+	Pointer  bool
+	Required bool
+	Convert  string // named type to convert scalars to, if any
+}
+
+// source holds the declarations of the generated client that are needed to
+// describe each operation.
+type source struct {
+	structs map[string]*ast.StructType
+	types   map[string]ast.Expr
+	funcs   map[string]*ast.FuncDecl
+}
+
+func main() {
+	in := flag.String("in", "../../client/client.gen.go", "generated client to read")
+	out := flag.String("out", "commands.gen.go", "file to write")
+	flag.Parse()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, *in, nil, parser.ParseComments)
+	if err != nil {
+		log.Fatalf("failed to parse %s: %v", *in, err)
+	}
+
+	src := source{
+		structs: map[string]*ast.StructType{},
+		types:   map[string]ast.Expr{},
+		funcs:   map[string]*ast.FuncDecl{},
+	}
+	var iface *ast.InterfaceType
+
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				ts, ok := spec.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+				src.types[ts.Name.Name] = ts.Type
+				switch t := ts.Type.(type) {
+				case *ast.StructType:
+					src.structs[ts.Name.Name] = t
+				case *ast.InterfaceType:
+					if ts.Name.Name == "ClientInterface" {
+						iface = t
+					}
+				}
+			}
+		case *ast.FuncDecl:
+			if d.Recv == nil {
+				src.funcs[d.Name.Name] = d
+			}
+		}
+	}
+	if iface == nil {
+		log.Fatalf("no ClientInterface found in %s", *in)
+	}
+
+	methods := map[string]*ast.FuncType{}
+	for _, m := range iface.Methods.List {
+		if ft, ok := m.Type.(*ast.FuncType); ok && len(m.Names) == 1 {
+			methods[m.Names[0].Name] = ft
+		}
+	}
+
+	var ops []operation
+	names := map[string]string{}
+	for method, ft := range methods {
+		// Operations with a JSON body also have a WithBody variant taking
+		// an io.Reader; the command calls that one with --body.
+		if base, ok := strings.CutSuffix(method, "WithBody"); ok {
+			if _, ok := methods[base]; ok {
+				continue
+			}
+		}
+
+		op := src.operation(method, ft)
+		if other, ok := names[op.Name]; ok {
+			log.Fatalf("%s and %s both map to command %q", method, other, op.Name)
+		}
+		names[op.Name] = method
+		ops = append(ops, op)
+	}
+	sort.Slice(ops, func(i, j int) bool { return ops[i].Name < ops[j].Name })
+
+	var buf bytes.Buffer
+	if err := commandsTemplate.Execute(&buf, ops); err != nil {
+		log.Fatalf("failed to render commands: %v", err)
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("failed to format output: %v\n%s", err, buf.Bytes())
+	}
+	if err := os.WriteFile(*out, formatted, 0o644); err != nil {
+		log.Fatalf("failed to write %s: %v", *out, err)
+	}
+}
+
+func (s source) operation(method string, ft *ast.FuncType) operation {
+	op := operation{Method: method}
+
+	legacy := strings.HasPrefix(method, "Legacy")
+	httpMethod, path, pathParams := s.request(method)
+
+	if legacy {
+		action := strings.TrimPrefix(method, "Legacy")
+		if m := actionPattern.FindStringSubmatch(path); m != nil {
+			action = m[1]
+		}
+		op.Name = kebab(action)
+		op.Usage = fmt.Sprintf("Call the %s legacy action.", action)
+		op.Category = "legacy actions"
+	} else {
+		op.Name = kebab(method)
+		op.Usage = fmt.Sprintf("Call %s %s.", httpMethod, path)
+		op.Category = "REST API"
+	}
+
+	pathIndex := 0
+	for _, field := range ft.Params.List {
+		typ := exprString(field.Type)
+		for _, name := range field.Names {
+			switch {
+			case name.Name == "ctx" || name.Name == "reqEditors" || name.Name == "contentType":
+			case name.Name == "params":
+				op.ParamsType = strings.TrimPrefix(typ, "*")
+				op.Flags = s.flags(op.ParamsType)
+			case name.Name == "body":
+				op.HasBody = true
+			default:
+				arg := pathArg{Var: name.Name, Kind: s.underlying(typ)}
+				if pathIndex < len(pathParams) {
+					arg.Name = kebab(pathParams[pathIndex])
+				} else {
+					arg.Name = kebab(name.Name)
+				}
+				pathIndex++
+				op.PathArgs = append(op.PathArgs, arg)
+			}
+		}
+	}
+
+	return op
+}
+
+// request returns the HTTP method, path and path parameter names of the
+// request built for method.
+func (s source) request(method string) (string, string, []string) {
+	fn, ok := s.funcs["New"+method+"RequestWithBody"]
+	if !ok {
+		fn, ok = s.funcs["New"+method+"Request"]
+	}
+	if !ok {
+		log.Fatalf("no request builder found for %s", method)
+	}
+
+	var httpMethod, path string
+	var params []string
+
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		switch exprString(call.Fun) {
+		case "fmt.Sprintf":
+			if lit, ok := call.Args[0].(*ast.BasicLit); ok && path == "" {
+				path, _ = strconv.Unquote(lit.Value)
+			}
+		case "http.NewRequest":
+			if lit, ok := call.Args[0].(*ast.BasicLit); ok {
+				if m := methodPattern.FindStringSubmatch(lit.Value); m != nil {
+					httpMethod = m[1]
+				}
+			}
+		case "runtime.StyleParamWithLocation":
+			if len(call.Args) > 3 && exprString(call.Args[3]) == "runtime.ParamLocationPath" {
+				if lit, ok := call.Args[2].(*ast.BasicLit); ok {
+					if m := pathParamName.FindStringSubmatch(lit.Value); m != nil {
+						params = append(params, m[1])
+					}
+				}
+			}
+		}
+		return true
+	})
+
+	for _, p := range params {
+		path = strings.Replace(path, "%s", "{"+p+"}", 1)
+	}
+
+	return httpMethod, path, params
+}
+
+func (s source) flags(paramsType string) []flagField {
+	st, ok := s.structs[paramsType]
+	if !ok {
+		log.Fatalf("no struct found for %s", paramsType)
+	}
+
+	var flags []flagField
+	for _, field := range st.Fields.List {
+		if field.Tag == nil || len(field.Names) != 1 {
+			continue
+		}
+		tag, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			log.Fatalf("bad tag on %s: %v", paramsType, err)
+		}
+		formName, _, _ := strings.Cut(reflect.StructTag(tag).Get("form"), ",")
+		if formName == "" {
+			continue
+		}
+
+		f := flagField{
+			Field: field.Names[0].Name,
+			Name:  kebab(formName),
+			Usage: fieldUsage(field.Names[0].Name, field.Doc),
+		}
+
+		typ := field.Type
+		if star, ok := typ.(*ast.StarExpr); ok {
+			f.Pointer = true
+			typ = star.X
+		}
+		f.Required = !f.Pointer
+
+		switch t := typ.(type) {
+		case *ast.ArrayType:
+			switch s.underlying(exprString(t.Elt)) {
+			case "int":
+				f.Kind = "intSlice"
+			default:
+				f.Kind = "stringSlice"
+			}
+		case *ast.MapType:
+			switch s.underlying(exprString(t.Value)) {
+			case "int":
+				f.Kind = "intMap"
+			default:
+				f.Kind = "stringMap"
+			}
+		case *ast.Ident:
+			f.Kind = s.underlying(t.Name)
+			if _, named := s.types[t.Name]; named {
+				f.Convert = "client." + t.Name
+			}
+		default:
+			log.Fatalf("unsupported type for %s.%s", paramsType, f.Field)
+		}
+
+		// Boolean flags can't be required; they are false when omitted.
+		if f.Kind == "bool" {
+			f.Required = false
+		}
+
+		flags = append(flags, f)
+	}
+
+	return flags
+}
+
+// underlying resolves named types declared in the generated client to the
+// builtin type they are based on.
+func (s source) underlying(name string) string {
+	for range 10 {
+		expr, ok := s.types[name]
+		if !ok {
+			break
+		}
+		ident, ok := expr.(*ast.Ident)
+		if !ok {
+			break
+		}
+		name = ident.Name
+	}
+	switch name {
+	case "int", "string", "bool":
+		return name
+	default:
+		log.Fatalf("unsupported type %s", name)
+		return ""
+	}
+}
+
+var (
+	spaces = regexp.MustCompile(`\s+`)
+
+	// dottedListHint matches the spec's note on encoding lists, which the
+	// legacy param encoder does for CLI users, who repeat the flag instead.
+	dottedListHint = regexp.MustCompile(`\(Use \w+\.1, \w+\.2, etc\. for multiple values\)`)
+)
+
+// fieldUsage turns a generated field comment like "Query A query string..."
+// into flag usage text.
+func fieldUsage(field string, doc *ast.CommentGroup) string {
+	if doc == nil {
+		return ""
+	}
+	text := strings.TrimSpace(doc.Text())
+	text = strings.TrimPrefix(text, field+" ")
+	text = spaces.ReplaceAllString(text, " ")
+	return dottedListHint.ReplaceAllString(text, "Can be specified multiple times.")
+}
+
+// kebab converts an identifier in camel, pascal or snake case to kebab case,
+// keeping acronyms together (ex. GetAPTSources becomes get-apt-sources).
+func kebab(s string) string {
+	runes := []rune(strings.ReplaceAll(s, "_", "-"))
+
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 && runes[i-1] != '-' {
+			prevLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (unicode.IsUpper(runes[i-1]) && nextLower) {
+				b.WriteRune('-')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+
+	return b.String()
+}
+
+func exprString(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.SelectorExpr:
+		return exprString(e.X) + "." + e.Sel.Name
+	case *ast.StarExpr:
+		return "*" + exprString(e.X)
+	case *ast.Ellipsis:
+		return "..." + exprString(e.Elt)
+	case *ast.ArrayType:
+		return "[]" + exprString(e.Elt)
+	case *ast.MapType:
+		return "map[" + exprString(e.Key) + "]" + exprString(e.Value)
+	default:
+		return fmt.Sprintf("%T", expr)
+	}
+}
+
+var commandsTemplate = template.Must(template.New("commands").Funcs(template.FuncMap{
+	"quote": strconv.Quote,
+	"argsUsage": func(args []pathArg) string {
+		var parts []string
+		for _, a := range args {
+			parts = append(parts, "<"+a.Name+">")
+		}
+		return strings.Join(parts, " ")
+	},
+	"flagType": func(kind string) string {
+		return map[string]string{
+			"string":      "cli.StringFlag",
+			"int":         "cli.IntFlag",
+			"bool":        "cli.BoolFlag",
+			"stringSlice": "cli.StringSliceFlag",
+			"intSlice":    "cli.IntSliceFlag",
+			"stringMap":   "cli.StringMapFlag",
+			"intMap":      "cli.StringMapFlag",
+		}[kind]
+	},
+	"getter": func(kind string) string {
+		return map[string]string{
+			"string":      "String",
+			"int":         "Int",
+			"bool":        "Bool",
+			"stringSlice": "StringSlice",
+			"intSlice":    "IntSlice",
+			"stringMap":   "StringMap",
+		}[kind]
+	},
+}).Parse(`// Code generated by commandgen. DO NOT EDIT.
+
+package main
+
+import (
+	"context"
+
+	"github.com/jansdhillon/landscape-go-api-client/client"
+	"github.com/urfave/cli/v3"
+)
+
+// generatedCommands has a command for every operation of the API client.
+var generatedCommands = []*cli.Command{
+{{- range . }}
+	{
+		Name:     {{ quote .Name }},
+		Usage:    {{ quote .Usage }},
+		Category: {{ quote .Category }},
+		{{- if .PathArgs }}
+		ArgsUsage: {{ quote (argsUsage .PathArgs) }},
+		{{- end }}
+		{{- if or .Flags .HasBody }}
+		Flags: []cli.Flag{
+			{{- range .Flags }}
+			&{{ flagType .Kind }}{
+				Name: {{ quote .Name }},
+				{{- if .Usage }}
+				Usage: {{ quote .Usage }},
+				{{- end }}
+				{{- if .Required }}
+				Required: true,
+				{{- end }}
+			},
+			{{- end }}
+			{{- if .HasBody }}
+			bodyCliFlag,
+			{{- end }}
+		},
+		{{- end }}
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			api, err := apiClient(ctx)
+			if err != nil {
+				return err
+			}
+			{{- range $i, $a := .PathArgs }}
+			{{- if eq $a.Kind "int" }}
+			{{ $a.Var }}, err := intArg(cmd, {{ $i }}, {{ quote $a.Name }})
+			{{- else }}
+			{{ $a.Var }}, err := stringArg(cmd, {{ $i }}, {{ quote $a.Name }})
+			{{- end }}
+			if err != nil {
+				return err
+			}
+			{{- end }}
+			{{- if .ParamsType }}
+			params := &client.{{ .ParamsType }}{}
+			{{- range .Flags }}
+			{{- if eq .Kind "intMap" }}
+			if cmd.IsSet({{ quote .Name }}) {
+				v, err := intMap(cmd.StringMap({{ quote .Name }}))
+				if err != nil {
+					return err
+				}
+				params.{{ .Field }} = {{ if .Pointer }}&{{ end }}v
+			}
+			{{- else if .Pointer }}
+			if cmd.IsSet({{ quote .Name }}) {
+				v := {{ if .Convert }}{{ .Convert }}({{ end }}cmd.{{ getter .Kind }}({{ quote .Name }}){{ if .Convert }}){{ end }}
+				params.{{ .Field }} = &v
+			}
+			{{- else }}
+			params.{{ .Field }} = {{ if .Convert }}{{ .Convert }}({{ end }}cmd.{{ getter .Kind }}({{ quote .Name }}){{ if .Convert }}){{ end }}
+			{{- end }}
+			{{- end }}
+			{{- end }}
+			{{- if .HasBody }}
+			body, err := requestBody(cmd)
+			if err != nil {
+				return err
+			}
+			{{- end }}
+			res, err := api.{{ .Method }}{{ if .HasBody }}WithBody{{ end }}(ctx{{ range .PathArgs }}, {{ .Var }}{{ end }}{{ if .ParamsType }}, params{{ end }}{{ if .HasBody }}, "application/json", body{{ end }})
+			if err != nil {
+				return err
+			}
+			return WriteResponseToRoot(ctx, cmd, res)
+		},
+	},
+{{- end }}
+}
+`))
@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v3"
+	"golang.org/x/term"
+)
+
+var loginCmd = &cli.Command{
+	Name:     "login",
+	Usage:    "Check the secret key or password for the selected credentials and save it in the secret store, so later commands don't need it as a flag or env var.",
+	Metadata: map[string]any{noAPIClientKey: true},
+	Action:   loginAction,
+}
+
+func loginAction(ctx context.Context, cmd *cli.Command) error {
+	root := cmd.Root()
+
+	s, err := loadSettings(root)
+	if err != nil {
+		return err
+	}
+	if s.baseURL == "" {
+		return fmt.Errorf("base URL must be provided")
+	}
+
+	store, err := openSecretStore(root.String(secretStoreFlag))
+	if err != nil {
+		return err
+	}
+	if store == nil {
+		return fmt.Errorf("login needs a secret store to save credentials in; set --%s", secretStoreFlag)
+	}
+
+	var kind, user string
+	var secret *string
+	switch {
+	case s.email != "" && s.authMethod != authMethodAccessKey:
+		kind, user, secret = authMethodPassword, s.email, &s.password
+	case s.accessKey != "":
+		kind, user, secret = authMethodAccessKey, s.accessKey, &s.secretKey
+		if path := root.String(secretKeyFileFlag); *secret == "" && path != "" {
+			if *secret, err = readSecretFile(path); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("must provide the -e or -ak flag, or set the LANDSCAPE_EMAIL or LANDSCAPE_ACCESS_KEY env var, to log in")
+	}
+
+	if *secret == "" {
+		label := "Secret key"
+		if kind == authMethodPassword {
+			label = "Password"
+		}
+		if *secret, err = promptSecret(root, fmt.Sprintf("%s for %s: ", label, user)); err != nil {
+			return err
+		}
+	}
+	if *secret == "" {
+		return fmt.Errorf("no %s given", strings.ToLower(kind))
+	}
+
+	session, err := settingsSession(ctx, root, s)
+	if err != nil {
+		return err
+	}
+
+	if err := store.Set(secretKeyName(s.baseURL, kind, user), *secret); err != nil {
+		return fmt.Errorf("failed to save credentials in %s: %w", store, err)
+	}
+
+	fmt.Fprintf(root.Writer, "Logged in as %s. Credentials saved in %s.\n", session.Email, store)
+	return nil
+}
+
+// promptSecret reads a secret from the terminal without echoing it, or a line
+// from stdin if it isn't a terminal.
+func promptSecret(root *cli.Command, prompt string) (string, error) {
+	if f, ok := root.Reader.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		fmt.Fprint(root.ErrWriter, prompt)
+		secret, err := term.ReadPassword(int(f.Fd()))
+		fmt.Fprintln(root.ErrWriter)
+		if err != nil {
+			return "", fmt.Errorf("failed to read secret: %w", err)
+		}
+		return string(secret), nil
+	}
+
+	line, err := bufio.NewReader(root.Reader).ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("failed to read secret from stdin: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
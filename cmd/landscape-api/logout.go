@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/jansdhillon/landscape-go-api-client/client"
+	"github.com/urfave/cli/v3"
+)
+
+var logoutCmd = &cli.Command{
+	Name:     "logout",
+	Usage:    "Remove the cached login token for the selected base URL and credentials, so the next command logs in again.",
+	Metadata: map[string]any{noAPIClientKey: true},
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "all",
+			Usage: "Remove every cached token.",
+		},
+		&cli.BoolFlag{
+			Name:  "forget",
+			Usage: "Also remove the secret key or password saved by login from the secret store.",
+		},
+	},
+	Action: logoutAction,
+}
+
+// loginIdentity identifies the credentials of a LoginProvider in the token
+// cache, without including any secrets. It is empty for providers whose
+// tokens aren't cached, like tokens passed with --token.
+func loginIdentity(lp client.LoginProvider) string {
+	switch p := lp.(type) {
+	case *client.AccessKeyProvider:
+		return "access-key:" + p.AccessKey
+	case *client.EmailPasswordProvider:
+		identity := "email:" + p.Email
+		if p.Account != nil {
+			identity += "/" + *p.Account
+		}
+		return identity
+	case *client.OIDCProvider:
+		return "oidc:" + p.Issuer + " " + p.ClientID
+	default:
+		return ""
+	}
+}
+
+func logoutAction(ctx context.Context, cmd *cli.Command) error {
+	if cmd.Bool("all") {
+		dir, err := client.DefaultTokenCacheDir()
+		if err != nil {
+			return err
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			return fmt.Errorf("failed to remove token cache: %w", err)
+		}
+		fmt.Fprintln(cmd.Root().Writer, "Removed all cached tokens.")
+		return nil
+	}
+
+	s, err := loadSettings(cmd.Root())
+	if err != nil {
+		return err
+	}
+	if s.baseURL == "" {
+		return fmt.Errorf("base URL must be provided")
+	}
+
+	var providers []client.LoginProvider
+	if s.accessKey != "" {
+		providers = append(providers, client.NewAccessKeyProvider(s.accessKey, ""))
+	}
+	if s.email != "" {
+		var account *string
+		if s.account != "" {
+			account = &s.account
+		}
+		providers = append(providers, client.NewEmailPasswordProvider(s.email, "", account))
+	}
+	var oidc *client.OIDCProvider
+	if s.oidcIssuer != "" {
+		oidc = client.NewOIDCProvider(s.oidcIssuer, s.oidcClientID, s.oidcExchangePath)
+		providers = append(providers, oidc)
+	}
+	if len(providers) == 0 {
+		return fmt.Errorf("must provide the -e, -ak or --%s flag, or set the LANDSCAPE_EMAIL, LANDSCAPE_ACCESS_KEY or LANDSCAPE_OIDC_ISSUER env var, to select the token to remove", oidcIssuerFlag)
+	}
+
+	for _, lp := range providers {
+		if err := client.NewCachedLoginProvider(lp, loginIdentity(lp)).Clear(s.baseURL); err != nil {
+			return fmt.Errorf("failed to remove cached token: %w", err)
+		}
+	}
+	if oidc != nil {
+		if err := oidc.ClearRefreshToken(); err != nil {
+			return fmt.Errorf("failed to remove OIDC refresh token: %w", err)
+		}
+	}
+
+	if cmd.Bool("forget") {
+		if err := forgetSecrets(cmd.Root(), s); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(cmd.Root().Writer, "Logged out of %s.\n", s.baseURL)
+	return nil
+}
+
+// forgetSecrets removes the secrets saved by login for the credentials in s.
+func forgetSecrets(root *cli.Command, s *settings) error {
+	store, err := openSecretStore(root.String(secretStoreFlag))
+	if err != nil || store == nil {
+		return err
+	}
+
+	for kind, user := range map[string]string{
+		authMethodAccessKey: s.accessKey,
+		authMethodPassword:  s.email,
+	} {
+		if user == "" {
+			continue
+		}
+		if err := store.Delete(secretKeyName(s.baseURL, kind, user)); err != nil {
+			return fmt.Errorf("failed to remove credentials from %s: %w", store, err)
+		}
+	}
+
+	return nil
+}
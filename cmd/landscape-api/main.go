@@ -3,15 +3,10 @@
 package main
 
 import (
-	"bytes"
 	"context"
-	"crypto/tls"
-	"crypto/x509"
-	"encoding/json"
 	"fmt"
-	"io"
 	"log"
-	"net/http"
+	"log/slog"
 	"os"
 
 	"github.com/jansdhillon/landscape-go-api-client/client"
@@ -23,34 +18,54 @@ type ctxKey string
 const apiClientKey ctxKey = "landscape-api-client"
 
 const (
-	baseURLFlag   = "base-url"
-	accessKeyFlag = "access-key"
-	secretKeyFlag = "secret-key"
-	emailFlag     = "email"
-	passwordFlag  = "password"
-	accountFlag   = "account"
-	caCertFlag    = "ca-cert"
+	baseURLFlag            = "base-url"
+	accessKeyFlag          = "access-key"
+	secretKeyFlag          = "secret-key"
+	emailFlag              = "email"
+	passwordFlag           = "password"
+	accountFlag            = "account"
+	caCertFlag             = "ca-cert"
+	clientCertFlag         = "client-cert"
+	clientKeyFlag          = "client-key"
+	tlsServerNameFlag      = "tls-server-name"
+	insecureSkipVerifyFlag = "insecure-skip-verify"
+	retriesFlag            = "max-retries"
+	debugFlag              = "debug"
+	traceHTTPFlag          = "trace-http"
+
+	noTokenCacheFlag = "no-token-cache"
+	tokenFlag        = "token"
+	tokenFileFlag    = "token-file"
 )
 
-func main() {
-	cmd := &cli.Command{
+// maxTracedBodyBytes is how much of each body --trace-http logs.
+const maxTracedBodyBytes = 64 << 10
+
+// rootCmd returns the landscape-api command with its global flags.
+func rootCmd() *cli.Command {
+	return &cli.Command{
 		Name:  "landscape-api",
 		Usage: "Interact with the Landscape API.",
-		Commands: []*cli.Command{
+		Commands: append([]*cli.Command{
 			scriptCmd,
+			scriptProfileCmd,
 			gpgKeyCmd,
 			distributionCmd,
 			seriesCmd,
 			pocketCmd,
 			mirrorCmd,
-		},
+			configCmd,
+			loginCmd,
+			logoutCmd,
+			whoamiCmd,
+			accountsCmd,
+		}, apiCommands()...),
 		Flags: []cli.Flag{
 			&cli.StringFlag{
-				Name:     baseURLFlag,
-				Aliases:  []string{"u", "url", "base_url"},
-				Usage:    "The base URL of Landscape (can also be set via LANDSCAPE_BASE_URL env var).",
-				Required: true,
-				Sources:  cli.EnvVars("LANDSCAPE_BASE_URL"),
+				Name:    baseURLFlag,
+				Aliases: []string{"u", "url", "base_url"},
+				Usage:   "The base URL of Landscape (can also be set via LANDSCAPE_BASE_URL env var, or in the selected context).",
+				Sources: cli.EnvVars("LANDSCAPE_BASE_URL"),
 			},
 			&cli.StringFlag{
 				Name:    accessKeyFlag,
@@ -64,6 +79,11 @@ func main() {
 				Usage:   "An secret key for the Landscape API (can also be set via LANDSCAPE_SECRET_KEY env var). If provided, you must also provide the -access-key flag or set the LANDSCAPE_ACCESS_KEY env var.",
 				Sources: cli.EnvVars("LANDSCAPE_SECRET_KEY"),
 			},
+			&cli.StringFlag{
+				Name:    secretKeyFileFlag,
+				Usage:   "Path to a file holding the secret key, so it doesn't show up in shell history or process listings (can also be set via LANDSCAPE_SECRET_KEY_FILE env var).",
+				Sources: cli.EnvVars("LANDSCAPE_SECRET_KEY_FILE"),
+			},
 			&cli.StringFlag{
 				Name:    emailFlag,
 				Aliases: []string{"e"},
@@ -82,65 +102,141 @@ func main() {
 				Usage:   "An account to login into the Landscape API with (can also be set via LANDSCAPE_ACCOUNT env var). If provided, you must also provide the -email and -password flags or set the LANDSCAPE_EMAIL and LANDSCAPE_PASSWORD env vars.",
 				Sources: cli.EnvVars("LANDSCAPE_ACCOUNT"),
 			},
+			&cli.StringFlag{
+				Name:    clientCertFlag,
+				Usage:   "Path to a PEM-encoded client certificate to present, ex. to an mTLS proxy in front of Landscape (can also be set via LANDSCAPE_CLIENT_CERT env var). Requires --client-key.",
+				Sources: cli.EnvVars("LANDSCAPE_CLIENT_CERT"),
+			},
+			&cli.StringFlag{
+				Name:    clientKeyFlag,
+				Usage:   "Path to the PEM-encoded private key of the client certificate (can also be set via LANDSCAPE_CLIENT_KEY env var).",
+				Sources: cli.EnvVars("LANDSCAPE_CLIENT_KEY"),
+			},
+			&cli.StringFlag{
+				Name:    tlsServerNameFlag,
+				Usage:   "The server name to verify the server's TLS certificate against, if it differs from the host in the base URL (can also be set via LANDSCAPE_TLS_SERVER_NAME env var).",
+				Sources: cli.EnvVars("LANDSCAPE_TLS_SERVER_NAME"),
+			},
+			&cli.BoolFlag{
+				Name:    insecureSkipVerifyFlag,
+				Usage:   "Don't verify the server's TLS certificate. This is insecure and should only be used for testing (can also be set via LANDSCAPE_INSECURE_SKIP_VERIFY env var).",
+				Sources: cli.EnvVars("LANDSCAPE_INSECURE_SKIP_VERIFY"),
+			},
+			&cli.StringFlag{
+				Name:    secretStoreFlag,
+				Usage:   "Where to look up secret keys and passwords that aren't given as flags or env vars, and where login saves them: auto, secret-service, pass, file or none (can also be set via LANDSCAPE_SECRET_STORE env var). The file store keeps them unencrypted in LANDSCAPE_SECRET_STORE_DIR.",
+				Value:   secretStoreAuto,
+				Sources: cli.EnvVars("LANDSCAPE_SECRET_STORE"),
+			},
 			&cli.StringFlag{
 				Name:    caCertFlag,
 				Aliases: []string{"ca"},
 				Usage:   "Path to a PEM-encoded CA certificate file for verifying the server's TLS certificate. Can also be set via LANDSCAPE_CA_CERT env var.",
 				Sources: cli.EnvVars("LANDSCAPE_CA_CERT"),
 			},
+			&cli.StringFlag{
+				Name:    tokenFlag,
+				Usage:   "A Landscape JWT to use instead of logging in, ex. one minted by a CI pipeline (can also be set via LANDSCAPE_TOKEN env var).",
+				Sources: cli.EnvVars("LANDSCAPE_TOKEN"),
+			},
+			&cli.StringFlag{
+				Name:    tokenFileFlag,
+				Usage:   "Path to a file holding a Landscape JWT to use instead of logging in (can also be set via LANDSCAPE_TOKEN_FILE env var). The file is read again when it changes.",
+				Sources: cli.EnvVars("LANDSCAPE_TOKEN_FILE"),
+			},
+			&cli.StringFlag{
+				Name:    oidcIssuerFlag,
+				Usage:   "An OIDC issuer to log in through with the device flow, ex. for SSO (can also be set via LANDSCAPE_OIDC_ISSUER env var). Requires --oidc-client-id.",
+				Sources: cli.EnvVars("LANDSCAPE_OIDC_ISSUER"),
+			},
+			&cli.StringFlag{
+				Name:    oidcClientIDFlag,
+				Usage:   "The client ID registered with the OIDC issuer (can also be set via LANDSCAPE_OIDC_CLIENT_ID env var).",
+				Sources: cli.EnvVars("LANDSCAPE_OIDC_CLIENT_ID"),
+			},
+			&cli.StringFlag{
+				Name:    oidcExchangePathFlag,
+				Usage:   "The Landscape endpoint, relative to the base URL, that exchanges OIDC ID tokens for a JWT, ex. api/login/oidc (can also be set via LANDSCAPE_OIDC_EXCHANGE_PATH env var). It depends on the deployment, so it's required for OIDC login.",
+				Sources: cli.EnvVars("LANDSCAPE_OIDC_EXCHANGE_PATH"),
+			},
+			&cli.StringFlag{
+				Name:    configFlag,
+				Usage:   "Path to the config file holding the contexts (can also be set via LANDSCAPE_CONFIG env var).",
+				Value:   defaultConfigPath(),
+				Sources: cli.EnvVars("LANDSCAPE_CONFIG"),
+			},
+			&cli.StringFlag{
+				Name:    contextFlag,
+				Usage:   "The context from the config file to use (can also be set via LANDSCAPE_CONTEXT env var). Defaults to the current context. Flags and env vars override its values.",
+				Sources: cli.EnvVars("LANDSCAPE_CONTEXT"),
+			},
+			&cli.BoolFlag{
+				Name:    noTokenCacheFlag,
+				Usage:   "Log in on every invocation instead of reusing a token cached under the user cache dir (can also be set via LANDSCAPE_NO_TOKEN_CACHE env var).",
+				Sources: cli.EnvVars("LANDSCAPE_NO_TOKEN_CACHE"),
+			},
+			&cli.IntFlag{
+				Name:    retriesFlag,
+				Usage:   "How many times to retry read requests that fail with HTTP 429, 502 or 503 (can also be set via LANDSCAPE_MAX_RETRIES env var). Set to 0 to disable retries.",
+				Value:   3,
+				Sources: cli.EnvVars("LANDSCAPE_MAX_RETRIES"),
+			},
+			&cli.BoolFlag{
+				Name:    debugFlag,
+				Usage:   "Log each HTTP request's method, URL, status and latency to stderr, with secrets redacted (can also be set via LANDSCAPE_DEBUG env var).",
+				Sources: cli.EnvVars("LANDSCAPE_DEBUG"),
+			},
+			&cli.BoolFlag{
+				Name:    traceHTTPFlag,
+				Usage:   "Like --debug, but also log request and response headers and bodies, with secrets redacted (can also be set via LANDSCAPE_TRACE_HTTP env var).",
+				Sources: cli.EnvVars("LANDSCAPE_TRACE_HTTP"),
+			},
+			&cli.StringFlag{
+				Name:    outputFlag,
+				Aliases: []string{"o"},
+				Usage:   "Output format: json, yaml, table, jsonpath=<expr> or raw (can also be set via LANDSCAPE_OUTPUT env var).",
+				Value:   outputJSON,
+				Sources: cli.EnvVars("LANDSCAPE_OUTPUT"),
+			},
+			&cli.StringSliceFlag{
+				Name:  columnsFlag,
+				Usage: "Columns to show with --output table, ex. id,title,creator.name. Defaults to a set chosen for the resource.",
+			},
 		},
 		Before: func(ctx context.Context, c *cli.Command) (context.Context, error) {
-			baseURL := c.String(baseURLFlag)
-			if baseURL == "" {
-				return ctx, fmt.Errorf("base URL must be provided")
+			if _, _, err := parseOutputFormat(c.String(outputFlag)); err != nil {
+				return ctx, err
 			}
 
-			email := c.String(emailFlag)
-			password := c.String(passwordFlag)
-			account := c.String(accountFlag)
-
-			var lp client.LoginProvider
-
-			if email != "" && password != "" {
-				if account != "" {
-					lp = client.NewEmailPasswordProvider(email, password, &account)
-				} else {
-					lp = client.NewEmailPasswordProvider(email, password, nil)
-				}
-
-			} else {
-				accessKey := c.String(accessKeyFlag)
-				secretKey := c.String(secretKeyFlag)
+			if !requiresAPIClient(c) {
+				return ctx, nil
+			}
 
-				if accessKey == "" || secretKey == "" {
-					return ctx, fmt.Errorf("must provide the -e & -p flags or the -ak & -sk flags, or set either the LANDSCAPE_EMAIL & LANDSCAPE_PASSWORD env vars or the LANDSCAPE_ACCESS_KEY & LANDSCAPE_SECRET_KEY env vars")
-				}
+			s, err := loadSettings(c)
+			if err != nil {
+				return ctx, err
+			}
+			if s.baseURL == "" {
+				return ctx, fmt.Errorf("base URL must be provided")
+			}
+			if err := s.resolveSecrets(c); err != nil {
+				return ctx, err
+			}
 
-				lp = client.NewAccessKeyProvider(accessKey, secretKey)
+			lp, err := s.loginProvider(c.ErrWriter)
+			if err != nil {
+				return ctx, err
+			}
+			if identity := loginIdentity(lp); identity != "" && !c.Bool(noTokenCacheFlag) {
+				lp = client.NewCachedLoginProvider(lp, identity)
 			}
 
-			var extraOpts []client.ClientOption
-			if certPath := c.String(caCertFlag); certPath != "" {
-				pemData, err := os.ReadFile(certPath)
-				if err != nil {
-					return ctx, fmt.Errorf("failed to read CA cert file: %w", err)
-				}
-				pool, err := x509.SystemCertPool()
-				if err != nil {
-					pool = x509.NewCertPool()
-				}
-				if !pool.AppendCertsFromPEM(pemData) {
-					return ctx, fmt.Errorf("failed to parse CA cert: invalid PEM data")
-				}
-				tlsClient := &http.Client{
-					Transport: &http.Transport{
-						TLSClientConfig: &tls.Config{RootCAs: pool},
-					},
-				}
-				extraOpts = append(extraOpts, client.WithHTTPClient(tlsClient))
+			extraOpts, err := clientOptions(c, s)
+			if err != nil {
+				return ctx, err
 			}
 
-			api, err := client.NewLandscapeAPIClient(baseURL, lp, extraOpts...)
+			api, err := client.NewLandscapeAPIClient(s.baseURL, lp, extraOpts...)
 			if err != nil {
 				return ctx, err
 			}
@@ -148,6 +244,10 @@ func main() {
 			return context.WithValue(ctx, apiClientKey, api), nil
 		},
 	}
+}
+
+func main() {
+	cmd := rootCmd()
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -157,20 +257,33 @@ func main() {
 	}
 }
 
-func WriteResponseToRoot(_ context.Context, cmd *cli.Command, res *http.Response) error {
-	defer res.Body.Close()
+// clientOptions returns the options for the API client configured by the
+// root command's flags.
+func clientOptions(c *cli.Command, s *settings) ([]client.ClientOption, error) {
+	var opts []client.ClientOption
 
-	body, err := io.ReadAll(res.Body)
+	tlsConfig, err := tlsConfig(c, s)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if tlsConfig != nil {
+		opts = append(opts, client.WithTLSConfig(tlsConfig))
+	}
+
+	if trace := c.Bool(traceHTTPFlag); trace || c.Bool(debugFlag) {
+		logger := slog.New(slog.NewTextHandler(c.ErrWriter, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		var logOpts []client.LoggerOption
+		if trace {
+			logOpts = append(logOpts, client.WithLogBodies(maxTracedBodyBytes))
+		}
+		opts = append(opts, client.WithLogger(logger, logOpts...))
 	}
 
-	var out bytes.Buffer
-	if err := json.Indent(&out, body, "", "  "); err != nil {
-		return err
+	if retries := c.Int(retriesFlag); retries > 0 {
+		policy := client.DefaultRetryPolicy()
+		policy.MaxAttempts = retries + 1
+		opts = append(opts, client.WithRetry(policy))
 	}
 
-	out.WriteTo(cmd.Root().Writer)
-	fmt.Fprintln(cmd.Root().Writer)
-	return nil
+	return opts, nil
 }
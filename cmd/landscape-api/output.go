@@ -0,0 +1,358 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jansdhillon/landscape-go-api-client/client"
+	"github.com/speakeasy-api/jsonpath/pkg/jsonpath"
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	outputFlag  = "output"
+	columnsFlag = "columns"
+)
+
+const (
+	outputJSON     = "json"
+	outputYAML     = "yaml"
+	outputTable    = "table"
+	outputJSONPath = "jsonpath"
+	outputRaw      = "raw"
+)
+
+// defaultColumns are the fields shown by table output, in order, when
+// --columns isn't given. Only the ones present in the response are used.
+var defaultColumns = []string{
+	"id",
+	"computer_id",
+	"activity_id",
+	"name",
+	"title",
+	"hostname",
+	"summary",
+	"type",
+	"status",
+	"activity_status",
+	"result_code",
+	"access_group",
+	"version_number",
+	"current",
+}
+
+// parseOutputFormat splits the value of --output into the format and, for
+// jsonpath, the expression.
+func parseOutputFormat(value string) (string, string, error) {
+	format, expr, _ := strings.Cut(value, "=")
+	switch format {
+	case outputJSON, outputYAML, outputTable, outputRaw:
+		return format, "", nil
+	case outputJSONPath:
+		if expr == "" {
+			return "", "", fmt.Errorf("--output jsonpath requires an expression, ex. jsonpath='$[*].id'")
+		}
+		if _, err := jsonpath.NewPath(expr); err != nil {
+			return "", "", fmt.Errorf("invalid JSONPath expression %q: %w", expr, err)
+		}
+		return format, expr, nil
+	default:
+		return "", "", fmt.Errorf("unknown output format %q, must be one of json, yaml, table, jsonpath=<expr> or raw", value)
+	}
+}
+
+// WriteResponseToRoot writes the body of res to the root command's writer in
+// the format selected with --output. Non-2xx responses are returned as a
+// *client.APIError instead.
+func WriteResponseToRoot(_ context.Context, cmd *cli.Command, res *http.Response) error {
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return client.NewAPIError(res, body)
+	}
+
+	return writeOutput(cmd, body)
+}
+
+// writeOutput writes a response body in the format selected with --output.
+// Bodies that aren't JSON, like the plain file name returned when creating a
+// script attachment, are written as they are unless a JSONPath is given.
+func writeOutput(cmd *cli.Command, body []byte) error {
+	root := cmd.Root()
+	w := root.Writer
+
+	format, expr, err := parseOutputFormat(root.String(outputFlag))
+	if err != nil {
+		return err
+	}
+
+	if format == outputRaw {
+		_, err := w.Write(body)
+		return err
+	}
+
+	// Responses without a body, like 204 No Content from archiving a script,
+	// print nothing.
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	if !json.Valid(body) {
+		if format == outputJSONPath {
+			return fmt.Errorf("can't apply a JSONPath to a response that isn't JSON: %q", body)
+		}
+		_, err := fmt.Fprintln(w, strings.TrimRight(string(body), "\n"))
+		return err
+	}
+
+	switch format {
+	case outputYAML:
+		return writeYAML(w, body)
+	case outputTable:
+		return writeTable(w, body, root.StringSlice(columnsFlag))
+	case outputJSONPath:
+		return writeJSONPath(w, body, expr)
+	default:
+		var out bytes.Buffer
+		if err := json.Indent(&out, bytes.TrimSpace(body), "", "  "); err != nil {
+			return err
+		}
+		out.WriteTo(w)
+		fmt.Fprintln(w)
+		return nil
+	}
+}
+
+// jsonNode parses a JSON document into a YAML node, which keeps the order of
+// object keys.
+func jsonNode(body []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(body, &doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+func writeYAML(w io.Writer, body []byte) error {
+	doc, err := jsonNode(body)
+	if err != nil {
+		return err
+	}
+	blockStyle(doc)
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// blockStyle clears the flow style and quoting that parsing JSON leaves on a
+// node, so it is written as idiomatic YAML.
+func blockStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		blockStyle(c)
+	}
+}
+
+func writeJSONPath(w io.Writer, body []byte, expr string) error {
+	path, err := jsonpath.NewPath(expr)
+	if err != nil {
+		return err
+	}
+
+	doc, err := jsonNode(body)
+	if err != nil {
+		return err
+	}
+
+	for _, match := range path.Query(doc.Content[0]) {
+		if match.Kind == yaml.ScalarNode {
+			fmt.Fprintln(w, match.Value)
+			continue
+		}
+
+		var v any
+		if err := match.Decode(&v); err != nil {
+			return err
+		}
+		out, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(out))
+	}
+
+	return nil
+}
+
+func writeTable(w io.Writer, body []byte, columns []string) error {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return err
+	}
+
+	rows, list := tableRows(v)
+	if list && len(rows) == 0 {
+		_, err := fmt.Fprintln(w, "No results.")
+		return err
+	}
+	if len(columns) == 0 {
+		columns = tableColumns(rows)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	headers := make([]string, len(columns))
+	for i, c := range columns {
+		headers[i] = strings.ToUpper(c)
+	}
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+
+	for _, row := range rows {
+		cells := make([]string, len(columns))
+		for i, c := range columns {
+			cells[i] = tableCell(lookup(row, c))
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+// tableRows returns the objects to show as rows: the elements of a list, the
+// results of a paginated response, the only list of objects in an object, or
+// the object itself. It reports whether the rows came from a list, which can
+// be empty.
+func tableRows(v any) ([]map[string]any, bool) {
+	switch v := v.(type) {
+	case []any:
+		rows := make([]map[string]any, 0, len(v))
+		for _, item := range v {
+			if m, ok := item.(map[string]any); ok {
+				rows = append(rows, m)
+			} else {
+				rows = append(rows, map[string]any{"value": item})
+			}
+		}
+		return rows, true
+	case map[string]any:
+		if results, ok := v["results"].([]any); ok {
+			return tableRows(results)
+		}
+
+		var lists []string
+		for k, field := range v {
+			if items, ok := field.([]any); ok && len(items) > 0 {
+				if _, ok := items[0].(map[string]any); ok {
+					lists = append(lists, k)
+				}
+			}
+		}
+		if len(lists) == 1 {
+			return tableRows(v[lists[0]])
+		}
+		return []map[string]any{v}, false
+	default:
+		return []map[string]any{{"value": v}}, false
+	}
+}
+
+// tableColumns picks the default columns present in rows, falling back to
+// every scalar field if none of them are.
+func tableColumns(rows []map[string]any) []string {
+	present := map[string]bool{}
+	for _, row := range rows {
+		for k := range row {
+			present[k] = true
+		}
+	}
+
+	var columns []string
+	for _, c := range defaultColumns {
+		if present[c] {
+			columns = append(columns, c)
+		}
+	}
+	if len(columns) > 0 {
+		return columns
+	}
+
+	for k := range present {
+		if !slices.ContainsFunc(rows, func(row map[string]any) bool { return !isScalar(row[k]) }) {
+			columns = append(columns, k)
+		}
+	}
+	sort.Strings(columns)
+
+	return columns
+}
+
+// lookup returns the field at a dotted path, ex. "creator.name".
+func lookup(row map[string]any, path string) any {
+	var v any = row
+	for _, key := range strings.Split(path, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = m[key]
+	}
+	return v
+}
+
+func isScalar(v any) bool {
+	switch v.(type) {
+	case map[string]any, []any:
+		return false
+	default:
+		return true
+	}
+}
+
+func tableCell(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.ReplaceAll(v, "\n", " ")
+	case []any:
+		if !slices.ContainsFunc(v, func(item any) bool { return !isScalar(item) }) {
+			parts := make([]string, len(v))
+			for i, item := range v {
+				parts[i] = tableCell(item)
+			}
+			return strings.Join(parts, ",")
+		}
+	case map[string]any:
+	default:
+		return fmt.Sprint(v)
+	}
+
+	out, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(out)
+}
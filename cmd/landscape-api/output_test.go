@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jansdhillon/landscape-go-api-client/client"
+	"github.com/jansdhillon/landscape-go-api-client/client/clienttest"
+	"github.com/urfave/cli/v3"
+)
+
+// formatOutput runs writeOutput on body with the given --output and
+// --columns, and returns what it wrote.
+func formatOutput(t *testing.T, body, format string, columns ...string) (string, error) {
+	t.Helper()
+
+	var out bytes.Buffer
+	cmd := &cli.Command{
+		Name:   "landscape-api",
+		Writer: &out,
+		Flags: []cli.Flag{
+			&cli.StringFlag{Name: outputFlag, Value: outputJSON},
+			&cli.StringSliceFlag{Name: columnsFlag},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			return writeOutput(cmd, []byte(body))
+		},
+	}
+
+	args := []string{"landscape-api", "--output", format}
+	for _, c := range columns {
+		args = append(args, "--columns", c)
+	}
+	err := cmd.Run(context.Background(), args)
+	return out.String(), err
+}
+
+func TestWriteOutput(t *testing.T) {
+	const scripts = `[{"id": 1, "title": "backup", "creator": {"name": "Jan"}, "tags": ["a", "b"]}, {"id": 20, "title": "uptime", "creator": {"name": "Ana"}, "tags": []}]`
+
+	tests := []struct {
+		name    string
+		body    string
+		format  string
+		columns []string
+		want    string
+	}{
+		{
+			name:   "json",
+			body:   `{"id":1,"title":"backup"}`,
+			format: outputJSON,
+			want:   "{\n  \"id\": 1,\n  \"title\": \"backup\"\n}\n",
+		},
+		{
+			name:   "yaml",
+			body:   `{"title":"backup","id":1,"tags":["a","b"],"creator":{"name":"Jan"}}`,
+			format: outputYAML,
+			want:   "title: backup\nid: 1\ntags:\n  - a\n  - b\ncreator:\n  name: Jan\n",
+		},
+		{
+			name:   "raw",
+			body:   `{"id":1}`,
+			format: outputRaw,
+			want:   `{"id":1}`,
+		},
+		{
+			name:   "jsonpath scalars",
+			body:   scripts,
+			format: "jsonpath=$[*].title",
+			want:   "backup\nuptime\n",
+		},
+		{
+			name:   "jsonpath objects",
+			body:   scripts,
+			format: "jsonpath=$[0].creator",
+			want:   "{\"name\":\"Jan\"}\n",
+		},
+		{
+			name:   "table",
+			body:   scripts,
+			format: outputTable,
+			want:   "ID  TITLE\n1   backup\n20  uptime\n",
+		},
+		{
+			name:    "table columns",
+			body:    scripts,
+			format:  outputTable,
+			columns: []string{"title,creator.name,tags"},
+			want:    "TITLE   CREATOR.NAME  TAGS\nbackup  Jan           a,b\nuptime  Ana           \n",
+		},
+		{
+			name:   "table of paginated results",
+			body:   `{"count": 1, "next": null, "results": [{"id": 7, "title": "nightly"}]}`,
+			format: outputTable,
+			want:   "ID  TITLE\n7   nightly\n",
+		},
+		{
+			name:   "table of an object",
+			body:   `{"id": 7, "title": "nightly", "attachments": []}`,
+			format: outputTable,
+			want:   "ID  TITLE\n7   nightly\n",
+		},
+		{
+			name:   "table of an object without default columns",
+			body:   `{"zone": "b", "size": 2, "nested": {"a": 1}}`,
+			format: outputTable,
+			want:   "SIZE  ZONE\n2     b\n",
+		},
+		{
+			name:   "table of an empty list",
+			body:   `[]`,
+			format: outputTable,
+			want:   "No results.\n",
+		},
+		{
+			name:    "table of an empty list with columns",
+			body:    `[]`,
+			format:  outputTable,
+			columns: []string{"id"},
+			want:    "No results.\n",
+		},
+		{
+			name:   "table of empty paginated results",
+			body:   `{"count": 0, "results": []}`,
+			format: outputTable,
+			want:   "No results.\n",
+		},
+		{
+			name:   "body that isn't JSON",
+			body:   "notes.txt\n",
+			format: outputTable,
+			want:   "notes.txt\n",
+		},
+		{
+			name:   "empty body",
+			body:   "",
+			format: outputYAML,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatOutput(t, tt.body, tt.format, tt.columns...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("expected:\n%q\ngot:\n%q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWriteOutputErrors(t *testing.T) {
+	for _, tt := range []struct {
+		body, format, err string
+	}{
+		{body: `{}`, format: "xml", err: "unknown output format"},
+		{body: `{}`, format: "jsonpath", err: "requires an expression"},
+		{body: `{}`, format: "jsonpath=$[", err: "invalid JSONPath expression"},
+		{body: "notes.txt", format: "jsonpath=$.id", err: "isn't JSON"},
+	} {
+		if _, err := formatOutput(t, tt.body, tt.format); err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Fatalf("expected %s output of %q to fail with %q, got %v", tt.format, tt.body, tt.err, err)
+		}
+	}
+}
+
+func TestEmptyScriptProfileListTable(t *testing.T) {
+	server := clienttest.NewServer(clienttest.WithScriptProfileLimits(client.ScriptProfileLimits{MaxNumComputers: 100, MaxNumProfiles: 10, MinInterval: 60}))
+	defer server.Close()
+
+	out, err := runCommand(t, server, "--output", outputTable, "script-profile", "list")
+	if err != nil {
+		t.Fatalf("script-profile list failed: %v", err)
+	}
+	if out != "No results.\n" {
+		t.Fatalf("expected No results., got %q", out)
+	}
+}
@@ -237,6 +237,7 @@ var mirrorCmd = &cli.Command{
 					Usage:    "The name of the distribution.",
 					Required: true,
 				},
+				waitCliFlag,
 			},
 			Action: syncMirrorAction,
 		},
@@ -472,5 +473,5 @@ func syncMirrorAction(ctx context.Context, cmd *cli.Command) error {
 	if err != nil {
 		return err
 	}
-	return WriteResponseToRoot(ctx, cmd, res)
+	return WriteActivityResponseToRoot(ctx, cmd, api, res)
 }
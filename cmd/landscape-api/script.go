@@ -6,7 +6,11 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/jansdhillon/landscape-go-api-client/client"
 	"github.com/urfave/cli/v3"
@@ -19,6 +23,14 @@ const (
 	titleFlag              = "title"
 	scriptIDFlag           = "script-id"
 	scriptAttachmentIDFlag = "script-attachment-id"
+	queryFlag              = "query"
+	usernameFlag           = "username"
+	timeLimitFlag          = "time-limit"
+	limitFlag              = "limit"
+	offsetFlag             = "offset"
+	filenameFlag           = "filename"
+	fromFileFlag           = "from-file"
+	attachFlag             = "attach"
 )
 
 var scriptCmd = &cli.Command{
@@ -26,8 +38,9 @@ var scriptCmd = &cli.Command{
 	Usage: "Manage and create Landscape scripts.",
 	Commands: []*cli.Command{
 		{
-			Name:  "create",
-			Usage: "Create a new script.",
+			Name:      "create",
+			Usage:     "Create a new script.",
+			ArgsUsage: "[attachment...]",
 			Flags: []cli.Flag{
 				&cli.StringFlag{
 					Name:     titleFlag,
@@ -35,9 +48,17 @@ var scriptCmd = &cli.Command{
 					Required: true,
 				},
 				&cli.StringFlag{
-					Name:     codeFlag,
-					Aliases:  []string{"c"},
-					Required: true,
+					Name:    codeFlag,
+					Aliases: []string{"c"},
+					Usage:   "The code of the script.",
+				},
+				&cli.StringFlag{
+					Name:  fromFileFlag,
+					Usage: "Read the code of the script from a file instead of --code, or from stdin if it's -. It must start with a shebang line, ex. #!/bin/bash.",
+				},
+				&cli.StringSliceFlag{
+					Name:  attachFlag,
+					Usage: fmt.Sprintf("A file to attach to the script. Can be specified multiple times and can be a glob, ex. './configs/*.conf'. A script can have up to %d attachments, of up to %d KiB in total.", client.MaxScriptAttachments, client.MaxScriptAttachmentsSize>>10),
 				},
 				&cli.StringFlag{
 					Name:     scriptTypeFlag,
@@ -61,7 +82,7 @@ var scriptCmd = &cli.Command{
 				&cli.StringFlag{
 					Name:     codeFlag,
 					Aliases:  []string{"c"},
-					Required: true,
+					Required: false,
 				},
 			},
 			Action: editScriptAction,
@@ -72,6 +93,97 @@ var scriptCmd = &cli.Command{
 			ArgsUsage: "[script-id]",
 			Action:    getScriptAction,
 		},
+		{
+			Name:  "list",
+			Usage: "List scripts.",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    scriptTypeFlag,
+					Aliases: []string{"s"},
+					Usage:   "The type of scripts to list: V1, V2, active, archived, redacted or all.",
+				},
+				&cli.IntFlag{
+					Name:  limitFlag,
+					Usage: "The maximum number of scripts to list. Landscape defaults to 1000.",
+				},
+				&cli.IntFlag{
+					Name:  offsetFlag,
+					Usage: "The number of scripts to skip.",
+				},
+			},
+			Action: listScriptsAction,
+		},
+		{
+			Name:      "delete",
+			Usage:     "Delete a script.",
+			ArgsUsage: "[script-id]",
+			Action:    deleteScriptAction,
+		},
+		{
+			Name:      "copy",
+			Usage:     "Copy a script to a new script.",
+			ArgsUsage: "[script-id]",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     titleFlag,
+					Aliases:  []string{"t"},
+					Usage:    "The title of the new script.",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:  accessGroupFlag,
+					Usage: "The access group of the new script. Defaults to the access group of the script being copied.",
+				},
+			},
+			Action: copyScriptAction,
+		},
+		{
+			Name:      "code",
+			Usage:     "Print the source code of a script.",
+			ArgsUsage: "[script-id]",
+			Action:    scriptCodeAction,
+		},
+		{
+			Name:      "archive",
+			Usage:     "Archive a V2 script, so it can no longer be edited or executed.",
+			ArgsUsage: "[script-id]",
+			Action:    archiveScriptAction,
+		},
+		{
+			Name:      "redact",
+			Usage:     "Redact a V2 script, removing its code and attachments. This can't be undone.",
+			ArgsUsage: "[script-id]",
+			Action:    redactScriptAction,
+		},
+		applyScriptsCmd,
+		{
+			Name:      "run",
+			Aliases:   []string{"execute"},
+			Usage:     "Execute a script on the computers matching a query.",
+			ArgsUsage: "[script-id]",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     queryFlag,
+					Aliases:  []string{"q"},
+					Usage:    "A query selecting the computers to run the script on, ex. tag:web.",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:  usernameFlag,
+					Usage: "The user to run the script as. Required if the script has no default user.",
+				},
+				&cli.IntFlag{
+					Name:  timeLimitFlag,
+					Usage: "How many seconds to let the script run before it is killed.",
+				},
+				&cli.StringFlag{
+					Name:  accessGroupFlag,
+					Usage: "Only run the script on computers in this access group.",
+				},
+				waitCliFlag,
+			},
+			Action: runScriptAction,
+		},
 		{
 			Name:  "attachment",
 			Usage: "Create or manage script attachments.",
@@ -81,12 +193,15 @@ var scriptCmd = &cli.Command{
 					Usage: "Create a script attachment.",
 					Flags: []cli.Flag{
 						&cli.StringFlag{
-							Name:     fileFlag,
-							Aliases:  []string{"f"},
-							Usage:    "The file you wish to use as an attachment. The format for this parameter is: <filename>$$<base64 encoded file contents>.",
-							Required: true,
+							Name:    fileFlag,
+							Aliases: []string{"f"},
+							Usage:   "The file you wish to use as an attachment. The format for this parameter is: <filename>$$<base64 encoded file contents>.",
+						},
+						&cli.StringFlag{
+							Name:  fromFileFlag,
+							Usage: "A local file to attach instead of --file. It's encoded for you and attached under its base name.",
 						},
-						&cli.Int64Flag{
+						&cli.IntFlag{
 							Name:     scriptIDFlag,
 							Aliases:  []string{"s"},
 							Usage:    "The ID of the script you want to make attachment for.",
@@ -100,13 +215,13 @@ var scriptCmd = &cli.Command{
 					Usage:  "Get a script attachment by the script ID and the attachment ID.",
 					Action: getScriptAttachmentAction,
 					Flags: []cli.Flag{
-						&cli.Int64Flag{
+						&cli.IntFlag{
 							Name:     scriptIDFlag,
 							Aliases:  []string{"s"},
 							Usage:    "The ID of the script you want to make attachment for.",
 							Required: true,
 						},
-						&cli.Int64Flag{
+						&cli.IntFlag{
 							Name:     scriptAttachmentIDFlag,
 							Aliases:  []string{"i"},
 							Usage:    "The ID of the script attachment to get.",
@@ -114,6 +229,25 @@ var scriptCmd = &cli.Command{
 						},
 					},
 				},
+				{
+					Name:   "remove",
+					Usage:  "Remove a script attachment by the script ID and its file name.",
+					Action: removeScriptAttachmentAction,
+					Flags: []cli.Flag{
+						&cli.IntFlag{
+							Name:     scriptIDFlag,
+							Aliases:  []string{"s"},
+							Usage:    "The ID of the script to remove the attachment from.",
+							Required: true,
+						},
+						&cli.StringFlag{
+							Name:     filenameFlag,
+							Aliases:  []string{"n"},
+							Usage:    "The file name of the attachment to remove.",
+							Required: true,
+						},
+					},
+				},
 			},
 		},
 	},
@@ -126,22 +260,160 @@ func createScriptAction(ctx context.Context, cmd *cli.Command) error {
 	}
 
 	title := cmd.String(titleFlag)
-	code := cmd.String(codeFlag)
 	scriptType := cmd.String(scriptTypeFlag)
 
-	enc := base64.StdEncoding.EncodeToString([]byte(code))
+	code, err := scriptCode(cmd)
+	if err != nil {
+		return err
+	}
+
+	// A glob after --attach that the shell expanded leaves every file after
+	// the first as an argument.
+	patterns := cmd.StringSlice(attachFlag)
+	if cmd.Args().Present() {
+		if len(patterns) == 0 {
+			return fmt.Errorf("unexpected arguments %q", cmd.Args().Slice())
+		}
+		patterns = append(patterns, cmd.Args().Slice()...)
+	}
+
+	attachments, err := readAttachments(patterns)
+	if err != nil {
+		return err
+	}
+	if err := client.CheckScriptAttachments(attachments); err != nil {
+		return err
+	}
+
+	enc := base64.StdEncoding.EncodeToString(code)
 
-	res, err := api.LegacyCreateScript(ctx, &client.LegacyCreateScriptParams{
+	params := &client.LegacyCreateScriptParams{
 		Title:      title,
 		Code:       enc,
 		ScriptType: &scriptType,
-	})
+	}
+
+	if len(attachments) == 0 {
+		res, err := api.LegacyCreateScript(ctx, params)
+		if err != nil {
+			return err
+		}
+		return WriteResponseToRoot(ctx, cmd, res)
+	}
+
+	created, err := api.LegacyCreateScriptWithResponse(ctx, params)
+	if err != nil {
+		return err
+	}
+	if err := client.CheckResponse(created); err != nil {
+		return err
+	}
+	script, err := client.ParseLegacyResponse[client.V1Script](created.Body)
+	if err != nil {
+		return fmt.Errorf("failed to decode created script: %w", err)
+	}
+
+	for _, attachment := range attachments {
+		res, err := api.LegacyCreateScriptAttachmentWithResponse(ctx, &client.LegacyCreateScriptAttachmentParams{
+			ScriptId: script.Id,
+			File:     attachment.Param(),
+		})
+		if err == nil {
+			err = client.CheckResponse(res)
+		}
+		if err != nil {
+			return fmt.Errorf("created script %d, but failed to attach %s: %w", script.Id, attachment.Filename, err)
+		}
+	}
+
+	// Get the script again so the output lists its attachments.
+	res, err := api.GetScript(ctx, script.Id)
 	if err != nil {
 		return err
 	}
 	return WriteResponseToRoot(ctx, cmd, res)
 }
 
+// scriptCode returns the code given with --code or --from-file. Code read
+// with --from-file must start with a shebang line, while --code is sent as
+// is.
+func scriptCode(cmd *cli.Command) ([]byte, error) {
+	var code []byte
+	switch path := cmd.String(fromFileFlag); {
+	case cmd.IsSet(codeFlag) && path != "":
+		return nil, fmt.Errorf("--%s and --%s can't be used together", codeFlag, fromFileFlag)
+	case path == "-":
+		data, err := io.ReadAll(cmd.Root().Reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read script from stdin: %w", err)
+		}
+		code = data
+	case path != "":
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read script: %w", err)
+		}
+		code = data
+	case cmd.IsSet(codeFlag):
+		return []byte(cmd.String(codeFlag)), nil
+	default:
+		return nil, fmt.Errorf("the script's code must be given with --%s or --%s", codeFlag, fromFileFlag)
+	}
+
+	if _, err := client.ScriptInterpreter(code); err != nil {
+		return nil, err
+	}
+	return code, nil
+}
+
+// readAttachments reads the files to attach to a script. Patterns that the
+// shell didn't expand, ex. because they were quoted, are expanded here.
+func readAttachments(patterns []string) ([]client.ScriptAttachmentFile, error) {
+	var files []client.ScriptAttachmentFile
+	for _, pattern := range patterns {
+		paths := []string{pattern}
+		if strings.ContainsAny(pattern, "*?[") {
+			matches, err := filepath.Glob(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid attachment pattern %q: %w", pattern, err)
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no files match attachment pattern %q", pattern)
+			}
+			paths = matches
+		}
+
+		for _, path := range paths {
+			file, err := readAttachment(path)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+func readAttachment(path string) (client.ScriptAttachmentFile, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return client.ScriptAttachmentFile{}, fmt.Errorf("failed to read attachment: %w", err)
+	}
+	if info.IsDir() {
+		return client.ScriptAttachmentFile{}, fmt.Errorf("attachment %s is a directory", path)
+	}
+	// Don't read a file that's too large to attach anyway.
+	if info.Size() > client.MaxScriptAttachmentsSize {
+		return client.ScriptAttachmentFile{}, fmt.Errorf("%w: attachment %s is %d bytes, more than the maximum of %d bytes", client.ErrScriptAttachmentLimit, path, info.Size(), client.MaxScriptAttachmentsSize)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return client.ScriptAttachmentFile{}, fmt.Errorf("failed to read attachment: %w", err)
+	}
+	return client.ScriptAttachmentFile{Filename: filepath.Base(path), Content: content}, nil
+}
+
 func editScriptAction(ctx context.Context, cmd *cli.Command) error {
 	api, ok := ctx.Value(apiClientKey).(*client.ClientWithResponses)
 	if !ok || api == nil {
@@ -159,16 +431,20 @@ func editScriptAction(ctx context.Context, cmd *cli.Command) error {
 		return fmt.Errorf("couldn't convert script ID to string: %s", err)
 	}
 
-	title := cmd.String(titleFlag)
-	code := cmd.String(codeFlag)
-
-	enc := base64.StdEncoding.EncodeToString([]byte(code))
+	params := &client.LegacyEditScriptParams{ScriptId: scriptID}
+	if cmd.IsSet(titleFlag) {
+		title := cmd.String(titleFlag)
+		params.Title = &title
+	}
+	if cmd.IsSet(codeFlag) {
+		enc := base64.StdEncoding.EncodeToString([]byte(cmd.String(codeFlag)))
+		params.Code = &enc
+	}
+	if params.Title == nil && params.Code == nil {
+		return fmt.Errorf("nothing to edit, provide --%s or --%s", titleFlag, codeFlag)
+	}
 
-	res, err := api.LegacyEditScript(ctx, &client.LegacyEditScriptParams{
-		ScriptId: scriptID,
-		Title:    &title,
-		Code:     &enc,
-	})
+	res, err := api.LegacyEditScript(ctx, params)
 	if err != nil {
 		return err
 	}
@@ -200,16 +476,49 @@ func getScriptAction(ctx context.Context, cmd *cli.Command) error {
 	return WriteResponseToRoot(ctx, cmd, res)
 }
 
+func runScriptAction(ctx context.Context, cmd *cli.Command) error {
+	api, err := apiClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	scriptID, err := intArg(cmd, 0, "script ID")
+	if err != nil {
+		return err
+	}
+
+	params := &client.LegacyExecuteScriptParams{
+		Query:    cmd.String(queryFlag),
+		ScriptId: scriptID,
+	}
+	if username := cmd.String(usernameFlag); username != "" {
+		params.Username = &username
+	}
+	if timeLimit := cmd.Int(timeLimitFlag); timeLimit > 0 {
+		params.TimeLimit = &timeLimit
+	}
+	if accessGroup := cmd.String(accessGroupFlag); accessGroup != "" {
+		params.InAccessGroup = &accessGroup
+	}
+
+	res, err := api.LegacyExecuteScript(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	return WriteActivityResponseToRoot(ctx, cmd, api, res)
+}
+
 func getScriptAttachmentAction(ctx context.Context, cmd *cli.Command) error {
 	api, ok := ctx.Value(apiClientKey).(*client.ClientWithResponses)
 	if !ok || api == nil {
 		return fmt.Errorf("api client not initialized")
 	}
 
-	scriptID := cmd.Int64(scriptIDFlag)
-	attachmentID := cmd.Int64(scriptAttachmentIDFlag)
+	scriptID := cmd.Int(scriptIDFlag)
+	attachmentID := cmd.Int(scriptAttachmentIDFlag)
 
-	res, err := api.GetScriptAttachment(ctx, int(scriptID), int(attachmentID))
+	res, err := api.GetScriptAttachment(ctx, scriptID, attachmentID)
 	if err != nil {
 		return fmt.Errorf("failed to get script attachment: %w", err)
 	}
@@ -223,11 +532,27 @@ func createScriptAttachmentAction(ctx context.Context, cmd *cli.Command) error {
 		return fmt.Errorf("api client not initialized")
 	}
 
-	scriptID := cmd.Int64(scriptIDFlag)
+	scriptID := cmd.Int(scriptIDFlag)
 	file := cmd.String(fileFlag)
 
+	switch path := cmd.String(fromFileFlag); {
+	case file != "" && path != "":
+		return fmt.Errorf("--%s and --%s can't be used together", fileFlag, fromFileFlag)
+	case path != "":
+		attachment, err := readAttachment(path)
+		if err != nil {
+			return err
+		}
+		if err := client.CheckScriptAttachments([]client.ScriptAttachmentFile{attachment}); err != nil {
+			return err
+		}
+		file = attachment.Param()
+	case file == "":
+		return fmt.Errorf("the attachment must be given with --%s or --%s", fileFlag, fromFileFlag)
+	}
+
 	res, err := api.LegacyCreateScriptAttachment(ctx, &client.LegacyCreateScriptAttachmentParams{
-		ScriptId: int(scriptID),
+		ScriptId: scriptID,
 		File:     file,
 	})
 	if err != nil {
@@ -236,3 +561,161 @@ func createScriptAttachmentAction(ctx context.Context, cmd *cli.Command) error {
 
 	return WriteResponseToRoot(ctx, cmd, res)
 }
+
+func listScriptsAction(ctx context.Context, cmd *cli.Command) error {
+	api, err := apiClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	params := &client.LegacyGetScriptsParams{}
+	if scriptType := cmd.String(scriptTypeFlag); scriptType != "" {
+		params.ScriptType = &scriptType
+	}
+	if cmd.IsSet(limitFlag) {
+		limit := cmd.Int(limitFlag)
+		params.Limit = &limit
+	}
+	if cmd.IsSet(offsetFlag) {
+		offset := cmd.Int(offsetFlag)
+		params.Offset = &offset
+	}
+
+	res, err := api.LegacyGetScripts(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	return WriteResponseToRoot(ctx, cmd, res)
+}
+
+func deleteScriptAction(ctx context.Context, cmd *cli.Command) error {
+	api, err := apiClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	scriptID, err := intArg(cmd, 0, "script ID")
+	if err != nil {
+		return err
+	}
+
+	res, err := api.LegacyRemoveScript(ctx, &client.LegacyRemoveScriptParams{ScriptId: scriptID})
+	if err != nil {
+		return err
+	}
+
+	return WriteResponseToRoot(ctx, cmd, res)
+}
+
+func copyScriptAction(ctx context.Context, cmd *cli.Command) error {
+	api, err := apiClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	scriptID, err := intArg(cmd, 0, "script ID")
+	if err != nil {
+		return err
+	}
+
+	params := &client.LegacyCopyScriptParams{
+		ScriptId:         scriptID,
+		DestinationTitle: cmd.String(titleFlag),
+	}
+	if accessGroup := cmd.String(accessGroupFlag); accessGroup != "" {
+		params.AccessGroup = &accessGroup
+	}
+
+	res, err := api.LegacyCopyScript(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	return WriteResponseToRoot(ctx, cmd, res)
+}
+
+// scriptCodeAction prints the code of a script as it would be run, rather
+// than the JSON string Landscape returns it as.
+func scriptCodeAction(ctx context.Context, cmd *cli.Command) error {
+	api, err := apiClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	scriptID, err := intArg(cmd, 0, "script ID")
+	if err != nil {
+		return err
+	}
+
+	res, err := api.LegacyGetScriptCodeWithResponse(ctx, &client.LegacyGetScriptCodeParams{ScriptId: scriptID})
+	if err != nil {
+		return err
+	}
+	if err := client.CheckResponse(res); err != nil {
+		return err
+	}
+
+	code, err := client.ParseLegacyResponse[string](res.Body)
+	if err != nil {
+		return fmt.Errorf("failed to decode script code: %w", err)
+	}
+
+	_, err = io.WriteString(cmd.Root().Writer, code)
+	return err
+}
+
+func archiveScriptAction(ctx context.Context, cmd *cli.Command) error {
+	api, err := apiClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	scriptID, err := intArg(cmd, 0, "script ID")
+	if err != nil {
+		return err
+	}
+
+	res, err := api.ArchiveScript(ctx, scriptID)
+	if err != nil {
+		return err
+	}
+
+	return WriteResponseToRoot(ctx, cmd, res)
+}
+
+func redactScriptAction(ctx context.Context, cmd *cli.Command) error {
+	api, err := apiClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	scriptID, err := intArg(cmd, 0, "script ID")
+	if err != nil {
+		return err
+	}
+
+	res, err := api.RedactScript(ctx, scriptID)
+	if err != nil {
+		return err
+	}
+
+	return WriteResponseToRoot(ctx, cmd, res)
+}
+
+func removeScriptAttachmentAction(ctx context.Context, cmd *cli.Command) error {
+	api, err := apiClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := api.LegacyRemoveScriptAttachment(ctx, &client.LegacyRemoveScriptAttachmentParams{
+		ScriptId: cmd.Int(scriptIDFlag),
+		Filename: cmd.String(filenameFlag),
+	})
+	if err != nil {
+		return err
+	}
+
+	return WriteResponseToRoot(ctx, cmd, res)
+}
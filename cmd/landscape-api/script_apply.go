@@ -0,0 +1,707 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jansdhillon/landscape-go-api-client/client"
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+)
+
+const dryRunFlag = "dry-run"
+
+// diffContext is how many unchanged lines are shown around changed lines of
+// code.
+const diffContext = 2
+
+// The statuses a script manifest can ask for.
+const (
+	manifestActive   = "active"
+	manifestArchived = "archived"
+	manifestRedacted = "redacted"
+)
+
+var applyScriptsCmd = &cli.Command{
+	Name:  "apply",
+	Usage: "Create, edit, archive or redact V2 scripts to match a directory of script manifests.",
+	Description: `Each manifest is a YAML file describing a script, matched to a V2 script in
+Landscape by its title:
+
+   title: deploy
+   code_file: deploy.sh    # relative to the manifest
+   interpreter: /bin/bash  # added as a shebang line if the code has none
+   time_limit: 300
+   username: root
+   access_group: global
+   attachments:            # relative to the manifest, globs are expanded
+     - configs/*.conf
+   status: active          # or archived, or redacted
+
+Scripts that don't exist are created. Edits bump the script's version. The
+time limit, username and attachments are only managed when given, so leave
+out attachments to keep the ones added by hand, or set them to [] to remove
+them. Scripts in Landscape without a manifest are left alone.`,
+	Flags: []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:     filenameFlag,
+			Aliases:  []string{"f"},
+			Usage:    "A script manifest, or a directory of them (*.yaml and *.yml). Can be specified multiple times.",
+			Required: true,
+		},
+		&cli.BoolFlag{
+			Name:  dryRunFlag,
+			Usage: "Print the changes that would be made, without making them.",
+		},
+	},
+	Action: applyScriptsAction,
+}
+
+// scriptManifest describes a script that apply keeps in sync.
+type scriptManifest struct {
+	Title       string   `yaml:"title"`
+	CodeFile    string   `yaml:"code_file"`
+	Interpreter string   `yaml:"interpreter"`
+	TimeLimit   *int     `yaml:"time_limit"`
+	Username    *string  `yaml:"username"`
+	AccessGroup string   `yaml:"access_group"`
+	Attachments []string `yaml:"attachments"`
+	Status      string   `yaml:"status"`
+
+	path        string
+	code        []byte
+	attachments []client.ScriptAttachmentFile
+}
+
+// scriptChange is a change apply makes to bring a script in line with its
+// manifest.
+type scriptChange struct {
+	action   string
+	manifest *scriptManifest
+
+	// current is the script being changed, nil if it's being created.
+	current *client.V2Script
+
+	editCode      bool
+	editTimeLimit bool
+	editUsername  bool
+	codeDiff      []string
+
+	attach []client.ScriptAttachmentFile
+	detach []client.ScriptAttachment
+}
+
+// edits reports whether the change needs an EditScript call.
+func (c *scriptChange) edits() bool {
+	return c.editCode || c.editTimeLimit || c.editUsername
+}
+
+func applyScriptsAction(ctx context.Context, cmd *cli.Command) error {
+	api, err := apiClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	return applyScripts(ctx, api, cmd.Root().Writer, cmd.StringSlice(filenameFlag), cmd.Bool(dryRunFlag))
+}
+
+// applyScripts makes the V2 scripts match the manifests at paths, writing the
+// plan and progress to w. With dryRun, it only writes the plan.
+func applyScripts(ctx context.Context, api *client.ClientWithResponses, w io.Writer, paths []string, dryRun bool) error {
+	manifests, err := loadScriptManifests(paths)
+	if err != nil {
+		return err
+	}
+
+	changes, err := planScriptChanges(ctx, api, manifests)
+	if err != nil {
+		return err
+	}
+
+	writeScriptPlan(w, changes)
+	if dryRun || len(changes) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(w)
+	for _, change := range changes {
+		if err := applyScriptChange(ctx, api, change); err != nil {
+			return fmt.Errorf("failed to %s script %q: %w", change.action, change.manifest.Title, err)
+		}
+		fmt.Fprintf(w, "%s: %s\n", change.manifest.Title, pastTense(change.action))
+	}
+	return nil
+}
+
+// loadScriptManifests reads the manifests at paths, and the files they refer
+// to.
+func loadScriptManifests(paths []string) ([]*scriptManifest, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read manifests: %w", err)
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read manifests: %w", err)
+		}
+		for _, entry := range entries {
+			if ext := filepath.Ext(entry.Name()); !entry.IsDir() && (ext == ".yaml" || ext == ".yml") {
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+	}
+
+	titles := map[string]string{}
+	var manifests []*scriptManifest
+	for _, file := range files {
+		loaded, err := loadScriptManifestFile(file)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range loaded {
+			if other, ok := titles[m.Title]; ok {
+				return nil, fmt.Errorf("%s: script %q is also described by %s", m.path, m.Title, other)
+			}
+			titles[m.Title] = m.path
+			manifests = append(manifests, m)
+		}
+	}
+
+	if len(manifests) == 0 {
+		return nil, fmt.Errorf("no script manifests found in %s", strings.Join(paths, ", "))
+	}
+
+	slices.SortFunc(manifests, func(a, b *scriptManifest) int { return strings.Compare(a.Title, b.Title) })
+	return manifests, nil
+}
+
+// loadScriptManifestFile reads the manifests in a file, which can hold
+// several YAML documents.
+func loadScriptManifestFile(path string) ([]*scriptManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	var manifests []*scriptManifest
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	for {
+		m := &scriptManifest{path: path}
+		if err := dec.Decode(m); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("%s: invalid manifest: %w", path, err)
+		}
+		if err := m.load(); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		manifests = append(manifests, m)
+	}
+	return manifests, nil
+}
+
+// load validates m and reads its code and attachments.
+func (m *scriptManifest) load() error {
+	if m.Title == "" {
+		return errors.New("title is required")
+	}
+
+	switch m.Status = strings.ToLower(m.Status); m.Status {
+	case "":
+		m.Status = manifestActive
+	case manifestActive, manifestArchived, manifestRedacted:
+	default:
+		return fmt.Errorf("status of %q must be active, archived or redacted, not %q", m.Title, m.Status)
+	}
+
+	if m.CodeFile == "" {
+		return fmt.Errorf("code_file of %q is required", m.Title)
+	}
+	code, err := os.ReadFile(m.relative(m.CodeFile))
+	if err != nil {
+		return fmt.Errorf("failed to read code of %q: %w", m.Title, err)
+	}
+
+	if m.Interpreter != "" {
+		if !bytes.HasPrefix(code, []byte("#!")) {
+			code = append([]byte("#!"+m.Interpreter+"\n"), code...)
+		} else if interpreter, err := client.ScriptInterpreter(code); err == nil && interpreter != m.Interpreter {
+			return fmt.Errorf("interpreter of %q is %s, but its code starts with a shebang line for %s", m.Title, m.Interpreter, interpreter)
+		}
+	}
+	if _, err := client.ScriptInterpreter(code); err != nil {
+		return fmt.Errorf("code of %q: %w", m.Title, err)
+	}
+	m.code = code
+
+	if m.Attachments != nil {
+		patterns := make([]string, len(m.Attachments))
+		for i, pattern := range m.Attachments {
+			patterns[i] = m.relative(pattern)
+		}
+		if m.attachments, err = readAttachments(patterns); err != nil {
+			return fmt.Errorf("attachments of %q: %w", m.Title, err)
+		}
+		if err := client.CheckScriptAttachments(m.attachments); err != nil {
+			return fmt.Errorf("attachments of %q: %w", m.Title, err)
+		}
+	}
+
+	return nil
+}
+
+// relative resolves a path in the manifest against its directory.
+func (m *scriptManifest) relative(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(filepath.Dir(m.path), path)
+}
+
+// planScriptChanges compares the manifests with the V2 scripts in Landscape
+// and returns the changes needed to make them match.
+func planScriptChanges(ctx context.Context, api *client.ClientWithResponses, manifests []*scriptManifest) ([]*scriptChange, error) {
+	all := "all"
+	results, err := client.CollectAll(client.Paginate(ctx, 0, client.GetScriptsPages(api, &client.LegacyGetScriptsParams{ScriptType: &all})))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scripts: %w", err)
+	}
+
+	byTitle := map[string][]client.V2Script{}
+	v1 := map[string]int{}
+	for _, result := range results {
+		script, err := result.Script()
+		if err != nil {
+			return nil, err
+		}
+		switch script := script.(type) {
+		case client.V1Script:
+			v1[script.Title] = script.Id
+		case client.V2Script:
+			byTitle[script.Title] = append(byTitle[script.Title], script)
+		}
+	}
+
+	var changes []*scriptChange
+	for _, m := range manifests {
+		current := currentScript(byTitle[m.Title])
+		if current == nil {
+			if id, ok := v1[m.Title]; ok {
+				return nil, fmt.Errorf("%s: script %q (%d) is a V1 script, which can't be versioned, archived or redacted", m.path, m.Title, id)
+			}
+		}
+
+		change, err := planScriptChange(ctx, api, m, current)
+		if err != nil {
+			return nil, err
+		}
+		if change != nil {
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+// currentScript picks the script a manifest applies to among the V2 scripts
+// with its title: the active one, or else the most recent one.
+func currentScript(scripts []client.V2Script) *client.V2Script {
+	var current *client.V2Script
+	for i := range scripts {
+		script := &scripts[i]
+		switch {
+		case current == nil,
+			script.Status == client.ACTIVE && current.Status != client.ACTIVE,
+			(script.Status == client.ACTIVE) == (current.Status == client.ACTIVE) && script.Id > current.Id:
+			current = script
+		}
+	}
+	return current
+}
+
+// planScriptChange returns the change needed to make current match m, or nil
+// if it already does.
+func planScriptChange(ctx context.Context, api *client.ClientWithResponses, m *scriptManifest, current *client.V2Script) (*scriptChange, error) {
+	var status client.V2ScriptStatus
+	if current != nil {
+		status = current.Status
+	}
+
+	switch m.Status {
+	case manifestArchived:
+		if status != client.ACTIVE {
+			return nil, nil
+		}
+		return &scriptChange{action: "archive", manifest: m, current: current}, nil
+	case manifestRedacted:
+		if status != client.ACTIVE && status != client.ARCHIVED {
+			return nil, nil
+		}
+		return &scriptChange{action: "redact", manifest: m, current: current}, nil
+	}
+
+	// Archived and redacted scripts can't be brought back, so a new script
+	// takes their place.
+	if status != client.ACTIVE {
+		return &scriptChange{
+			action:   "create",
+			manifest: m,
+			codeDiff: lineDiff("", string(m.code), diffContext),
+			attach:   m.attachments,
+		}, nil
+	}
+
+	// GetScripts leaves out the code, so get the whole script.
+	res, err := api.GetScriptWithResponse(ctx, current.Id)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.CheckResponse(res); err != nil {
+		return nil, fmt.Errorf("failed to get script %q (%d): %w", m.Title, current.Id, err)
+	}
+	script, err := res.JSON200.AsV2Script()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode script %q (%d): %w", m.Title, current.Id, err)
+	}
+
+	if m.AccessGroup != "" && script.AccessGroup != nil && *script.AccessGroup != m.AccessGroup {
+		return nil, fmt.Errorf("%s: script %q (%d) is in access group %s, and scripts can't be moved to another access group", m.path, m.Title, script.Id, *script.AccessGroup)
+	}
+
+	change := &scriptChange{action: "edit", manifest: m, current: &script}
+
+	if code := derefOrZero(script.Code); code != string(m.code) {
+		change.editCode = true
+		change.codeDiff = lineDiff(code, string(m.code), diffContext)
+	}
+	change.editTimeLimit = m.TimeLimit != nil && *m.TimeLimit != derefOrZero(script.TimeLimit)
+	change.editUsername = m.Username != nil && *m.Username != derefOrZero(script.Username)
+
+	if m.Attachments != nil {
+		change.attach, change.detach, err = diffAttachments(ctx, api, script, m.attachments)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !change.edits() && len(change.attach) == 0 && len(change.detach) == 0 {
+		return nil, nil
+	}
+	return change, nil
+}
+
+// diffAttachments returns the files to attach to a script and the
+// attachments to remove from it so that its attachments are files. Changed
+// attachments are removed and attached again.
+func diffAttachments(ctx context.Context, api *client.ClientWithResponses, script client.V2Script, files []client.ScriptAttachmentFile) (attach []client.ScriptAttachmentFile, detach []client.ScriptAttachment, err error) {
+	existing := map[string]client.ScriptAttachment{}
+	for _, attachment := range derefOrZero(script.Attachments) {
+		existing[attachment.Filename] = attachment
+	}
+
+	for _, file := range files {
+		attachment, ok := existing[file.Filename]
+		if !ok {
+			attach = append(attach, file)
+			continue
+		}
+		delete(existing, file.Filename)
+
+		res, err := api.GetScriptAttachmentWithResponse(ctx, script.Id, attachment.Id)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := client.CheckResponse(res); err != nil {
+			return nil, nil, fmt.Errorf("failed to get attachment %s of script %q (%d): %w", file.Filename, script.Title, script.Id, err)
+		}
+		if res.JSON200 == nil || *res.JSON200 != string(file.Content) {
+			attach = append(attach, file)
+			detach = append(detach, attachment)
+		}
+	}
+
+	for _, attachment := range existing {
+		detach = append(detach, attachment)
+	}
+	slices.SortFunc(detach, func(a, b client.ScriptAttachment) int { return strings.Compare(a.Filename, b.Filename) })
+	return attach, detach, nil
+}
+
+// writeScriptPlan writes the changes apply will make, like a diff.
+func writeScriptPlan(w io.Writer, changes []*scriptChange) {
+	if len(changes) == 0 {
+		fmt.Fprintln(w, "Scripts are up to date.")
+		return
+	}
+
+	counts := map[string]int{}
+	for _, change := range changes {
+		counts[change.action]++
+		m := change.manifest
+
+		switch change.action {
+		case "create":
+			fmt.Fprintf(w, "+ create %q\n", m.Title)
+		case "edit":
+			if change.edits() {
+				fmt.Fprintf(w, "~ edit %q (%d), version %d -> %d\n", m.Title, change.current.Id, derefOrZero(change.current.VersionNumber), derefOrZero(change.current.VersionNumber)+1)
+			} else {
+				fmt.Fprintf(w, "~ edit attachments of %q (%d)\n", m.Title, change.current.Id)
+			}
+		case "archive":
+			fmt.Fprintf(w, "- archive %q (%d)\n", m.Title, change.current.Id)
+		case "redact":
+			fmt.Fprintf(w, "! redact %q (%d), removing its code and attachments\n", m.Title, change.current.Id)
+		}
+
+		if change.action == "create" {
+			if m.TimeLimit != nil {
+				fmt.Fprintf(w, "    time_limit: %d\n", *m.TimeLimit)
+			}
+			if m.Username != nil {
+				fmt.Fprintf(w, "    username: %q\n", *m.Username)
+			}
+			if m.AccessGroup != "" {
+				fmt.Fprintf(w, "    access_group: %s\n", m.AccessGroup)
+			}
+		}
+		if change.editTimeLimit {
+			fmt.Fprintf(w, "    time_limit: %d -> %d\n", derefOrZero(change.current.TimeLimit), *m.TimeLimit)
+		}
+		if change.editUsername {
+			fmt.Fprintf(w, "    username: %q -> %q\n", derefOrZero(change.current.Username), *m.Username)
+		}
+		if len(change.codeDiff) > 0 {
+			fmt.Fprintf(w, "    code (%s):\n", m.CodeFile)
+			for _, line := range change.codeDiff {
+				fmt.Fprintf(w, "      %s\n", line)
+			}
+		}
+		// Changed attachments are both removed and attached again.
+		replaced := map[string]bool{}
+		for _, file := range change.attach {
+			replaced[file.Filename] = true
+		}
+		for _, attachment := range change.detach {
+			if replaced[attachment.Filename] {
+				continue
+			}
+			fmt.Fprintf(w, "    - attachment %s\n", attachment.Filename)
+		}
+		for _, file := range change.attach {
+			if slices.ContainsFunc(change.detach, func(a client.ScriptAttachment) bool { return a.Filename == file.Filename }) {
+				fmt.Fprintf(w, "    ~ attachment %s (%d bytes)\n", file.Filename, len(file.Content))
+			} else {
+				fmt.Fprintf(w, "    + attachment %s (%d bytes)\n", file.Filename, len(file.Content))
+			}
+		}
+	}
+
+	fmt.Fprintf(w, "\n%d to create, %d to edit, %d to archive, %d to redact.\n",
+		counts["create"], counts["edit"], counts["archive"], counts["redact"])
+}
+
+// applyScriptChange makes a planned change.
+func applyScriptChange(ctx context.Context, api *client.ClientWithResponses, change *scriptChange) error {
+	m := change.manifest
+
+	var res interface {
+		StatusCode() int
+	}
+	var err error
+
+	switch change.action {
+	case "create":
+		v2 := "V2"
+		params := &client.LegacyCreateScriptParams{
+			Title:      m.Title,
+			Code:       base64.StdEncoding.EncodeToString(m.code),
+			ScriptType: &v2,
+			TimeLimit:  m.TimeLimit,
+			Username:   m.Username,
+		}
+		if m.AccessGroup != "" {
+			params.AccessGroup = &m.AccessGroup
+		}
+
+		created, err := api.LegacyCreateScriptWithResponse(ctx, params)
+		if err != nil {
+			return err
+		}
+		if err := client.CheckResponse(created); err != nil {
+			return err
+		}
+		script, err := client.ParseLegacyResponse[client.V2Script](created.Body)
+		if err != nil {
+			return fmt.Errorf("failed to decode created script: %w", err)
+		}
+		change.current = &script
+	case "edit":
+		if change.edits() {
+			params := &client.LegacyEditScriptParams{ScriptId: change.current.Id}
+			if change.editCode {
+				code := base64.StdEncoding.EncodeToString(m.code)
+				params.Code = &code
+			}
+			if change.editTimeLimit {
+				params.TimeLimit = m.TimeLimit
+			}
+			if change.editUsername {
+				params.Username = m.Username
+			}
+			if res, err = api.LegacyEditScriptWithResponse(ctx, params); err != nil {
+				return err
+			}
+			if err := client.CheckResponse(res); err != nil {
+				return err
+			}
+		}
+	case "archive":
+		if res, err = api.ArchiveScriptWithResponse(ctx, change.current.Id); err != nil {
+			return err
+		}
+		return client.CheckResponse(res)
+	case "redact":
+		if res, err = api.RedactScriptWithResponse(ctx, change.current.Id); err != nil {
+			return err
+		}
+		return client.CheckResponse(res)
+	}
+
+	for _, attachment := range change.detach {
+		res, err := api.LegacyRemoveScriptAttachmentWithResponse(ctx, &client.LegacyRemoveScriptAttachmentParams{
+			ScriptId: change.current.Id,
+			Filename: attachment.Filename,
+		})
+		if err != nil {
+			return err
+		}
+		if err := client.CheckResponse(res); err != nil {
+			return fmt.Errorf("failed to remove attachment %s: %w", attachment.Filename, err)
+		}
+	}
+	for _, file := range change.attach {
+		res, err := api.LegacyCreateScriptAttachmentWithResponse(ctx, &client.LegacyCreateScriptAttachmentParams{
+			ScriptId: change.current.Id,
+			File:     file.Param(),
+		})
+		if err != nil {
+			return err
+		}
+		if err := client.CheckResponse(res); err != nil {
+			return fmt.Errorf("failed to attach %s: %w", file.Filename, err)
+		}
+	}
+
+	return nil
+}
+
+func pastTense(action string) string {
+	switch action {
+	case "edit":
+		return "edited"
+	case "redact":
+		return "redacted"
+	default:
+		return action + "d"
+	}
+}
+
+// lineDiff returns a diff of the lines of two texts, with context unchanged
+// lines around each change. Lines are prefixed with "+ ", "- " or "  ", and
+// skipped unchanged lines are shown as "...".
+func lineDiff(old, new string, context int) []string {
+	a, b := splitLines(old), splitLines(new)
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var lines []string
+	var changed []bool
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines, changed = append(lines, "  "+a[i]), append(changed, false)
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines, changed = append(lines, "- "+a[i]), append(changed, true)
+			i++
+		default:
+			lines, changed = append(lines, "+ "+b[j]), append(changed, true)
+			j++
+		}
+	}
+
+	// Keep changed lines and the unchanged lines near them.
+	keep := make([]bool, len(lines))
+	for k, c := range changed {
+		if !c {
+			continue
+		}
+		for n := max(0, k-context); n <= min(len(lines)-1, k+context); n++ {
+			keep[n] = true
+		}
+	}
+
+	var out []string
+	skipped := false
+	for k, line := range lines {
+		if !keep[k] {
+			skipped = true
+			continue
+		}
+		if skipped && len(out) > 0 {
+			out = append(out, "...")
+		}
+		skipped = false
+		out = append(out, line)
+	}
+	return out
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+func derefOrZero[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jansdhillon/landscape-go-api-client/client"
+	"github.com/jansdhillon/landscape-go-api-client/client/clienttest"
+)
+
+func TestLineDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		context  int
+		want     []string
+	}{
+		{
+			name: "unchanged",
+			old:  "a\nb\n",
+			new:  "a\nb\n",
+		},
+		{
+			name: "new text",
+			new:  "a\nb\n",
+			want: []string{"+ a", "+ b"},
+		},
+		{
+			name: "removed text",
+			old:  "a\nb\n",
+			want: []string{"- a", "- b"},
+		},
+		{
+			name:    "replaced line",
+			old:     "a\nb\nc\n",
+			new:     "a\nB\nc\n",
+			context: 1,
+			want:    []string{"  a", "- b", "+ B", "  c"},
+		},
+		{
+			name:    "replaced lines",
+			old:     "a\nb\nc\nd\n",
+			new:     "a\nB\nC\nd\n",
+			context: 1,
+			want:    []string{"  a", "- b", "- c", "+ B", "+ C", "  d"},
+		},
+		{
+			name:    "added and removed lines",
+			old:     "a\nb\nc\n",
+			new:     "a\nc\nd\n",
+			context: 0,
+			want:    []string{"- b", "...", "+ d"},
+		},
+		{
+			name:    "skips unchanged lines outside the context",
+			old:     "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			new:     "1\n2\n3\n4\nfive\n6\n7\n8\n9\n",
+			context: 2,
+			want:    []string{"  3", "  4", "- 5", "+ five", "  6", "  7"},
+		},
+		{
+			name:    "separates distant changes",
+			old:     "1\n2\n3\n4\n5\n6\n7\n",
+			new:     "one\n2\n3\n4\n5\n6\nseven\n",
+			context: 1,
+			want:    []string{"- 1", "+ one", "  2", "...", "  6", "- 7", "+ seven"},
+		},
+		{
+			name:    "missing trailing newline",
+			old:     "a\nb",
+			new:     "a\nb\n",
+			context: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := lineDiff(tt.old, tt.new, tt.context)
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("expected diff %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestApplyScripts(t *testing.T) {
+	server := clienttest.NewServer()
+	defer server.Close()
+
+	api, err := server.NewClient()
+	if err != nil {
+		t.Fatalf("failed to init client: %v", err)
+	}
+
+	dir := t.TempDir()
+	writeFile := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	writeManifest := func(status string) {
+		t.Helper()
+		writeFile("deploy.yaml", `title: deploy
+code_file: deploy.sh
+interpreter: /bin/sh
+time_limit: 300
+username: root
+attachments:
+  - app.conf
+status: `+status+"\n")
+	}
+
+	// apply applies the manifests and returns the output, failing the test
+	// if the output doesn't contain each of want.
+	apply := func(t *testing.T, want ...string) string {
+		t.Helper()
+		server.ResetRequests()
+
+		var out bytes.Buffer
+		if err := applyScripts(context.Background(), api, &out, []string{dir}, false); err != nil {
+			t.Fatalf("apply failed: %v\n%s", err, out.String())
+		}
+		for _, s := range want {
+			if !strings.Contains(out.String(), s) {
+				t.Fatalf("expected the output to contain %q, got:\n%s", s, out.String())
+			}
+		}
+		return out.String()
+	}
+
+	deployScripts := func() []clienttest.Script {
+		var scripts []clienttest.Script
+		for _, script := range server.Scripts() {
+			if script.Title == "deploy" {
+				scripts = append(scripts, script)
+			}
+		}
+		return scripts
+	}
+	deployScript := func(t *testing.T) clienttest.Script {
+		t.Helper()
+		scripts := deployScripts()
+		if len(scripts) != 1 {
+			t.Fatalf("expected 1 deploy script, got %d", len(scripts))
+		}
+		return scripts[0]
+	}
+
+	writeFile("deploy.sh", "echo v1\n")
+	writeFile("app.conf", "debug = false\n")
+	writeManifest("active")
+
+	t.Run("creates missing scripts", func(t *testing.T) {
+		apply(t, `+ create "deploy"`, "+ #!/bin/sh", "+ echo v1", "+ attachment app.conf (14 bytes)", "deploy: created")
+
+		script := deployScript(t)
+		if !script.V2 || script.Code != "#!/bin/sh\necho v1\n" || script.TimeLimit != 300 || script.Username != "root" {
+			t.Fatalf("unexpected script: %+v", script)
+		}
+		if len(script.Attachments) != 1 || script.Attachments[0].Filename != "app.conf" || string(script.Attachments[0].Content) != "debug = false\n" {
+			t.Fatalf("unexpected attachments: %+v", script.Attachments)
+		}
+	})
+
+	t.Run("leaves matching scripts alone", func(t *testing.T) {
+		out := apply(t, "Scripts are up to date.")
+		if strings.Contains(out, "deploy:") {
+			t.Fatalf("expected no changes, got:\n%s", out)
+		}
+		for _, req := range server.Requests() {
+			if req.Operation != "LegacyGetScripts" && req.Operation != "GetScript" && req.Operation != "GetScriptAttachment" {
+				t.Fatalf("expected only reads, got %s", req.Operation)
+			}
+		}
+	})
+
+	t.Run("edits changed code", func(t *testing.T) {
+		writeFile("deploy.sh", "echo v2\n")
+		before := deployScript(t)
+
+		out := apply(t, "version 1 -> 2", "deploy: edited")
+		if !strings.Contains(out, "- echo v1\n      + echo v2\n") {
+			t.Fatalf("expected the old line to be removed before the new one is added, got:\n%s", out)
+		}
+
+		script := deployScript(t)
+		if script.ID != before.ID || script.Code != "#!/bin/sh\necho v2\n" || script.VersionNumber != before.VersionNumber+1 {
+			t.Fatalf("unexpected script after edit: %+v", script)
+		}
+	})
+
+	t.Run("replaces changed attachments", func(t *testing.T) {
+		writeFile("app.conf", "debug = true\n")
+
+		out := apply(t, "~ edit attachments of", "~ attachment app.conf (13 bytes)", "deploy: edited")
+		if strings.Contains(out, "version") {
+			t.Fatalf("expected only the attachments to change, got:\n%s", out)
+		}
+
+		script := deployScript(t)
+		if len(script.Attachments) != 1 || string(script.Attachments[0].Content) != "debug = true\n" {
+			t.Fatalf("unexpected attachments: %+v", script.Attachments)
+		}
+	})
+
+	t.Run("archives scripts", func(t *testing.T) {
+		writeManifest("archived")
+
+		apply(t, `- archive "deploy"`, "deploy: archived")
+		if script := deployScript(t); script.Status != client.ARCHIVED {
+			t.Fatalf("expected the script to be archived, got %s", script.Status)
+		}
+
+		apply(t, "Scripts are up to date.")
+	})
+
+	t.Run("creates a new script in place of an archived one", func(t *testing.T) {
+		writeManifest("active")
+		archived := deployScript(t)
+
+		apply(t, `+ create "deploy"`, "deploy: created")
+
+		scripts := deployScripts()
+		if len(scripts) != 2 {
+			t.Fatalf("expected the archived script and a new one, got %+v", scripts)
+		}
+		for _, script := range scripts {
+			switch {
+			case script.ID == archived.ID && script.Status != client.ARCHIVED:
+				t.Fatalf("expected the old script to stay archived, got %s", script.Status)
+			case script.ID != archived.ID && (script.Status != client.ACTIVE || script.Code != "#!/bin/sh\necho v2\n"):
+				t.Fatalf("unexpected new script: %+v", script)
+			}
+		}
+
+		apply(t, "Scripts are up to date.")
+	})
+}
@@ -0,0 +1,582 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jansdhillon/landscape-go-api-client/client"
+	"github.com/urfave/cli/v3"
+)
+
+const (
+	scriptFlag       = "script"
+	cronFlag         = "cron"
+	startAfterFlag   = "start-after"
+	atFlag           = "at"
+	eventFlag        = "event"
+	tagFlag          = "tag"
+	allComputersFlag = "all-computers"
+	archivedFlag     = "archived"
+	namesFlag        = "names"
+)
+
+// computersPageSize is how many computers are requested at a time when
+// counting the computers a script profile targets.
+const computersPageSize = 1000
+
+var scriptProfileCmd = &cli.Command{
+	Name:  "script-profile",
+	Usage: "Manage script profiles, which run a V2 script on a schedule or when an event happens.",
+	Commands: []*cli.Command{
+		{
+			Name:  "create",
+			Usage: "Create a script profile. Its schedule and computers are checked against the account's script profile limits first.",
+			Flags: append([]cli.Flag{
+				&cli.IntFlag{
+					Name:     scriptFlag,
+					Usage:    "The ID of the V2 script to run.",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:    titleFlag,
+					Aliases: []string{"t"},
+					Usage:   "The title of the script profile. Defaults to the title of the script.",
+				},
+				&cli.StringFlag{
+					Name:  usernameFlag,
+					Usage: "The user to run the script as. Defaults to the script's user.",
+				},
+				&cli.IntFlag{
+					Name:  timeLimitFlag,
+					Usage: "How many seconds to let the script run before it is killed. Defaults to the script's time limit.",
+				},
+			}, scriptProfileFlags...),
+			Action: createScriptProfileAction,
+		},
+		{
+			Name:  "list",
+			Usage: "List script profiles.",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  archivedFlag,
+					Usage: "Which script profiles to list: active, archived or all.",
+				},
+				&cli.StringFlag{
+					Name:  namesFlag,
+					Usage: "Only list script profiles with these titles, separated by commas.",
+				},
+				&cli.IntFlag{
+					Name:  scriptFlag,
+					Usage: "Only list the script profiles running the script with this ID.",
+				},
+			},
+			Action: listScriptProfilesAction,
+		},
+		{
+			Name:      "get",
+			Usage:     "Get a script profile.",
+			ArgsUsage: "[script-profile-id]",
+			Action:    getScriptProfileAction,
+		},
+		{
+			Name:      "update",
+			Usage:     "Update a script profile. Only the given fields are changed.",
+			ArgsUsage: "[script-profile-id]",
+			Flags: append([]cli.Flag{
+				&cli.StringFlag{
+					Name:    titleFlag,
+					Aliases: []string{"t"},
+					Usage:   "The new title of the script profile.",
+				},
+				&cli.StringFlag{
+					Name:  usernameFlag,
+					Usage: "The user to run the script as.",
+				},
+				&cli.IntFlag{
+					Name:  timeLimitFlag,
+					Usage: "How many seconds to let the script run before it is killed.",
+				},
+			}, scriptProfileFlags...),
+			Action: updateScriptProfileAction,
+		},
+		{
+			Name:      "archive",
+			Usage:     "Archive a script profile, so it no longer runs.",
+			ArgsUsage: "[script-profile-id]",
+			Action:    archiveScriptProfileAction,
+		},
+		{
+			Name:      "activities",
+			Usage:     "List the activities a script profile has created.",
+			ArgsUsage: "[script-profile-id]",
+			Action:    listScriptProfileActivitiesAction,
+		},
+		{
+			Name:      "computers",
+			Usage:     "List the computers a script profile targets.",
+			ArgsUsage: "[script-profile-id]",
+			Action:    listScriptProfileComputersAction,
+		},
+		{
+			Name:   "limits",
+			Usage:  "Show the account's limits on script profiles.",
+			Action: scriptProfileLimitsAction,
+		},
+	},
+}
+
+// scriptProfileFlags set the trigger and computers of a script profile.
+var scriptProfileFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:  cronFlag,
+		Usage: `Run the script on a schedule, given as a five-field cron expression, ex. "0 3 * * *".`,
+	},
+	&cli.StringFlag{
+		Name:  startAfterFlag,
+		Usage: "When the schedule given with --cron starts, in RFC 3339 format, ex. 2026-01-01T00:00:00Z. Defaults to now.",
+	},
+	&cli.StringFlag{
+		Name:  atFlag,
+		Usage: "Run the script once, at a time in RFC 3339 format, ex. 2026-01-01T03:00:00Z.",
+	},
+	&cli.StringFlag{
+		Name:  eventFlag,
+		Usage: "Run the script when an event happens to a computer: post_enrollment.",
+	},
+	&cli.StringSliceFlag{
+		Name:  tagFlag,
+		Usage: "Run the script on computers with this tag. Can be specified multiple times.",
+	},
+	&cli.BoolFlag{
+		Name:  allComputersFlag,
+		Usage: "Run the script on every computer.",
+	},
+}
+
+func createScriptProfileAction(ctx context.Context, cmd *cli.Command) error {
+	api, err := apiClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	if cmd.IsSet(startAfterFlag) && !cmd.IsSet(cronFlag) {
+		return fmt.Errorf("--%s can only be used with --%s", startAfterFlag, cronFlag)
+	}
+
+	var trigger client.ScriptProfileTriggerCreateRequest
+	switch {
+	case countSet(cmd, cronFlag, atFlag, eventFlag) != 1:
+		return fmt.Errorf("exactly one of --%s, --%s or --%s must be provided", cronFlag, atFlag, eventFlag)
+	case cmd.IsSet(cronFlag):
+		startAfter := time.Now().UTC().Truncate(time.Second)
+		if cmd.IsSet(startAfterFlag) {
+			if startAfter, err = timeFlag(cmd, startAfterFlag); err != nil {
+				return err
+			}
+		}
+		trigger = client.RecurringTrigger(cmd.String(cronFlag), startAfter)
+	case cmd.IsSet(atFlag):
+		at, err := timeFlag(cmd, atFlag)
+		if err != nil {
+			return err
+		}
+		trigger = client.OneTimeTrigger(at)
+	default:
+		trigger = client.EventTrigger(client.ScriptProfileEventType(cmd.String(eventFlag)))
+	}
+
+	if err := checkComputerFlags(cmd, true); err != nil {
+		return err
+	}
+
+	script, err := v2Script(ctx, api, int(cmd.Int(scriptFlag)))
+	if err != nil {
+		return err
+	}
+
+	body := client.ScriptProfileCreateBody{
+		ScriptId:  script.Id,
+		Title:     cmd.String(titleFlag),
+		Username:  cmd.String(usernameFlag),
+		TimeLimit: int(cmd.Int(timeLimitFlag)),
+		Trigger:   trigger,
+	}
+	if body.Title == "" {
+		body.Title = script.Title
+	}
+	if body.Username == "" {
+		if script.Username == nil || *script.Username == "" {
+			return fmt.Errorf("--%s must be provided, as script %d has no default user", usernameFlag, script.Id)
+		}
+		body.Username = *script.Username
+	}
+	if body.TimeLimit == 0 {
+		if script.TimeLimit == nil || *script.TimeLimit == 0 {
+			return fmt.Errorf("--%s must be provided, as script %d has no default time limit", timeLimitFlag, script.Id)
+		}
+		body.TimeLimit = *script.TimeLimit
+	}
+	if cmd.Bool(allComputersFlag) {
+		allComputers := true
+		body.AllComputers = &allComputers
+	} else {
+		tags := cmd.StringSlice(tagFlag)
+		body.Tags = &tags
+	}
+
+	if err := checkScriptProfileLimits(ctx, cmd, api); err != nil {
+		return err
+	}
+
+	res, err := api.CreateScriptProfile(ctx, body)
+	if err != nil {
+		return err
+	}
+
+	return WriteResponseToRoot(ctx, cmd, res)
+}
+
+func updateScriptProfileAction(ctx context.Context, cmd *cli.Command) error {
+	api, err := apiClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	scriptProfileID, err := intArg(cmd, 0, "script profile ID")
+	if err != nil {
+		return err
+	}
+
+	var body client.ScriptProfilePatchBody
+	if cmd.IsSet(titleFlag) {
+		title := cmd.String(titleFlag)
+		body.Title = &title
+	}
+	if cmd.IsSet(usernameFlag) {
+		username := cmd.String(usernameFlag)
+		body.Username = &username
+	}
+	if cmd.IsSet(timeLimitFlag) {
+		timeLimit := int(cmd.Int(timeLimitFlag))
+		body.TimeLimit = &timeLimit
+	}
+
+	if err := checkComputerFlags(cmd, false); err != nil {
+		return err
+	}
+	if cmd.IsSet(tagFlag) {
+		tags, allComputers := cmd.StringSlice(tagFlag), false
+		body.Tags, body.AllComputers = &tags, &allComputers
+	}
+	if cmd.Bool(allComputersFlag) {
+		allComputers := true
+		body.AllComputers = &allComputers
+	}
+
+	if countSet(cmd, cronFlag, atFlag, eventFlag) > 1 {
+		return fmt.Errorf("only one of --%s, --%s or --%s can be provided", cronFlag, atFlag, eventFlag)
+	}
+	if cmd.IsSet(startAfterFlag) && (cmd.IsSet(atFlag) || cmd.IsSet(eventFlag)) {
+		return fmt.Errorf("--%s can only be used with --%s", startAfterFlag, cronFlag)
+	}
+
+	var trigger client.ScriptProfileTriggerPatchRequest
+	switch {
+	case cmd.IsSet(cronFlag), cmd.IsSet(startAfterFlag):
+		// Leave out what isn't given, to only change the cron expression or
+		// start of an existing schedule.
+		var schedule client.ScriptProfileScheduleDraftEditTrigger
+		if cmd.IsSet(cronFlag) {
+			cron := cmd.String(cronFlag)
+			schedule.Interval = &cron
+		}
+		if cmd.IsSet(startAfterFlag) {
+			startAfter, err := timeFlag(cmd, startAfterFlag)
+			if err != nil {
+				return err
+			}
+			schedule.StartAfter = &startAfter
+		}
+		err = trigger.FromScriptProfileScheduleDraftEditTrigger(schedule)
+		body.Trigger = &trigger
+	case cmd.IsSet(atFlag):
+		at, err := timeFlag(cmd, atFlag)
+		if err != nil {
+			return err
+		}
+		body.Trigger = client.OneTimeTrigger(at).Patch()
+	case cmd.IsSet(eventFlag):
+		body.Trigger = client.EventTrigger(client.ScriptProfileEventType(cmd.String(eventFlag))).Patch()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to build trigger: %w", err)
+	}
+
+	if body == (client.ScriptProfilePatchBody{}) {
+		return errors.New("nothing to update, provide at least one field to change")
+	}
+
+	if err := checkScriptProfileLimits(ctx, cmd, api); err != nil {
+		return err
+	}
+
+	res, err := api.UpdateScriptProfile(ctx, scriptProfileID, body)
+	if err != nil {
+		return err
+	}
+
+	return WriteResponseToRoot(ctx, cmd, res)
+}
+
+func listScriptProfilesAction(ctx context.Context, cmd *cli.Command) error {
+	api, err := apiClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	if cmd.IsSet(scriptFlag) {
+		if cmd.IsSet(archivedFlag) || cmd.IsSet(namesFlag) {
+			return fmt.Errorf("--%s can't be combined with --%s or --%s", scriptFlag, archivedFlag, namesFlag)
+		}
+
+		res, err := api.ListScriptProfilesByScript(ctx, int(cmd.Int(scriptFlag)))
+		if err != nil {
+			return err
+		}
+		return WriteResponseToRoot(ctx, cmd, res)
+	}
+
+	params := &client.ListScriptProfilesParams{}
+	if cmd.IsSet(archivedFlag) {
+		archived := client.ListScriptProfilesParamsArchived(cmd.String(archivedFlag))
+		params.Archived = &archived
+	}
+	if cmd.IsSet(namesFlag) {
+		names := cmd.String(namesFlag)
+		params.Names = &names
+	}
+
+	res, err := api.ListScriptProfiles(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	return WriteResponseToRoot(ctx, cmd, res)
+}
+
+func getScriptProfileAction(ctx context.Context, cmd *cli.Command) error {
+	api, err := apiClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	scriptProfileID, err := intArg(cmd, 0, "script profile ID")
+	if err != nil {
+		return err
+	}
+
+	res, err := api.GetScriptProfile(ctx, scriptProfileID)
+	if err != nil {
+		return err
+	}
+
+	return WriteResponseToRoot(ctx, cmd, res)
+}
+
+func archiveScriptProfileAction(ctx context.Context, cmd *cli.Command) error {
+	api, err := apiClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	scriptProfileID, err := intArg(cmd, 0, "script profile ID")
+	if err != nil {
+		return err
+	}
+
+	res, err := api.ArchiveScriptProfile(ctx, scriptProfileID)
+	if err != nil {
+		return err
+	}
+
+	return WriteResponseToRoot(ctx, cmd, res)
+}
+
+func listScriptProfileActivitiesAction(ctx context.Context, cmd *cli.Command) error {
+	api, err := apiClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	scriptProfileID, err := intArg(cmd, 0, "script profile ID")
+	if err != nil {
+		return err
+	}
+
+	res, err := api.ListScriptProfileActivities(ctx, scriptProfileID)
+	if err != nil {
+		return err
+	}
+
+	return WriteResponseToRoot(ctx, cmd, res)
+}
+
+func listScriptProfileComputersAction(ctx context.Context, cmd *cli.Command) error {
+	api, err := apiClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	scriptProfileID, err := intArg(cmd, 0, "script profile ID")
+	if err != nil {
+		return err
+	}
+
+	res, err := api.ListScriptProfileComputers(ctx, scriptProfileID)
+	if err != nil {
+		return err
+	}
+
+	return WriteResponseToRoot(ctx, cmd, res)
+}
+
+func scriptProfileLimitsAction(ctx context.Context, cmd *cli.Command) error {
+	api, err := apiClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	res, err := api.GetScriptProfileLimits(ctx)
+	if err != nil {
+		return err
+	}
+
+	return WriteResponseToRoot(ctx, cmd, res)
+}
+
+// checkScriptProfileLimits checks the schedule and computers given to create
+// or update a script profile against the account's limits, so they aren't
+// only rejected by the server.
+func checkScriptProfileLimits(ctx context.Context, cmd *cli.Command, api *client.ClientWithResponses) error {
+	if !cmd.IsSet(cronFlag) && !cmd.IsSet(tagFlag) && !cmd.Bool(allComputersFlag) {
+		return nil
+	}
+
+	res, err := api.GetScriptProfileLimitsWithResponse(ctx)
+	if err != nil {
+		return err
+	}
+	if err := client.CheckResponse(res); err != nil {
+		return fmt.Errorf("failed to get script profile limits: %w", err)
+	}
+	limits := *res.JSON200
+
+	if cmd.IsSet(cronFlag) {
+		if err := limits.CheckInterval(cmd.String(cronFlag)); err != nil {
+			return err
+		}
+	}
+
+	if cmd.IsSet(tagFlag) || cmd.Bool(allComputersFlag) {
+		n, err := countComputers(ctx, api, cmd.StringSlice(tagFlag), limits.MaxNumComputers)
+		if err != nil {
+			return err
+		}
+		if err := limits.CheckComputers(n); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// countComputers counts the computers with any of tags, or every computer if
+// no tags are given. It stops counting once there are more than max.
+func countComputers(ctx context.Context, api *client.ClientWithResponses, tags []string, max int) (int, error) {
+	queries := []*string{nil}
+	if len(tags) > 0 {
+		queries = queries[:0]
+		for _, tag := range tags {
+			query := "tag:" + tag
+			queries = append(queries, &query)
+		}
+	}
+
+	seen := map[int]bool{}
+	for _, query := range queries {
+		pages := client.Paginate(ctx, computersPageSize, client.GetComputersPages(api, &client.LegacyGetComputersParams{Query: query}))
+		for computer, err := range pages {
+			if err != nil {
+				return 0, fmt.Errorf("failed to count computers: %w", err)
+			}
+			seen[computer.Id] = true
+			if max > 0 && len(seen) > max {
+				return len(seen), nil
+			}
+		}
+	}
+	return len(seen), nil
+}
+
+// v2Script gets the script a script profile will run, which must be a V2
+// script.
+func v2Script(ctx context.Context, api *client.ClientWithResponses, scriptID int) (client.V2Script, error) {
+	res, err := api.GetScriptWithResponse(ctx, scriptID)
+	if err != nil {
+		return client.V2Script{}, err
+	}
+	if err := client.CheckResponse(res); err != nil {
+		return client.V2Script{}, fmt.Errorf("failed to get script %d: %w", scriptID, err)
+	}
+
+	script, err := res.JSON200.Script()
+	if err != nil {
+		return client.V2Script{}, err
+	}
+	v2, ok := script.(client.V2Script)
+	if !ok {
+		return client.V2Script{}, fmt.Errorf("script %d is a V1 script, and script profiles can only run V2 scripts", scriptID)
+	}
+	return v2, nil
+}
+
+// checkComputerFlags checks that at most one of --tag and --all-computers is
+// given, or exactly one if required. --all-computers=false is rejected, as it
+// would leave the profile without computers to run on.
+func checkComputerFlags(cmd *cli.Command, required bool) error {
+	if cmd.IsSet(allComputersFlag) && !cmd.Bool(allComputersFlag) {
+		return fmt.Errorf("--%s=false isn't supported; use --%s to pick the computers instead", allComputersFlag, tagFlag)
+	}
+
+	switch n := countSet(cmd, tagFlag, allComputersFlag); {
+	case required && n != 1:
+		return fmt.Errorf("exactly one of --%s or --%s must be provided", tagFlag, allComputersFlag)
+	case n > 1:
+		return fmt.Errorf("only one of --%s or --%s can be provided", tagFlag, allComputersFlag)
+	}
+	return nil
+}
+
+// countSet returns how many of the flags are set.
+func countSet(cmd *cli.Command, flags ...string) int {
+	n := 0
+	for _, flag := range flags {
+		if cmd.IsSet(flag) {
+			n++
+		}
+	}
+	return n
+}
+
+// timeFlag parses a flag holding a time in RFC 3339 format.
+func timeFlag(cmd *cli.Command, name string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, cmd.String(name))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("--%s must be a time in RFC 3339 format, ex. 2026-01-01T03:00:00Z: %w", name, err)
+	}
+	return t, nil
+}
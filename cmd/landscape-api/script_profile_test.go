@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/urfave/cli/v3"
+)
+
+func TestCheckComputerFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		required bool
+		err      string
+	}{
+		{name: "tags", args: []string{"--tag", "web", "--tag", "db"}, required: true},
+		{name: "all computers", args: []string{"--all-computers"}, required: true},
+		{name: "neither when required", required: true, err: "exactly one of"},
+		{name: "neither when optional"},
+		{name: "both", args: []string{"--tag", "web", "--all-computers"}, err: "only one of"},
+		{name: "all computers false on create", args: []string{"--all-computers=false"}, required: true, err: "--all-computers=false isn't supported"},
+		{name: "all computers false on update", args: []string{"--all-computers=false"}, err: "--all-computers=false isn't supported"},
+		{name: "all computers false with tags", args: []string{"--tag", "web", "--all-computers=false"}, required: true, err: "--all-computers=false isn't supported"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cli.Command{
+				Name: "create",
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{Name: tagFlag},
+					&cli.BoolFlag{Name: allComputersFlag},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					return checkComputerFlags(cmd, tt.required)
+				},
+			}
+
+			err := cmd.Run(context.Background(), append([]string{"create"}, tt.args...))
+			if tt.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("expected error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
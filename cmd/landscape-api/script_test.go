@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/urfave/cli/v3"
+)
+
+func TestScriptCode(t *testing.T) {
+	dir := t.TempDir()
+	withShebang := filepath.Join(dir, "with-shebang.sh")
+	withoutShebang := filepath.Join(dir, "without-shebang.sh")
+	if err := os.WriteFile(withShebang, []byte("#!/bin/sh\necho hi\n"), 0o600); err != nil {
+		t.Fatalf("failed to write script: %v", err)
+	}
+	if err := os.WriteFile(withoutShebang, []byte("echo hi\n"), 0o600); err != nil {
+		t.Fatalf("failed to write script: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		args  []string
+		stdin string
+		want  string
+		err   string
+	}{
+		{
+			name: "code with a shebang",
+			args: []string{"--code", "#!/bin/sh\necho hi"},
+			want: "#!/bin/sh\necho hi",
+		},
+		{
+			name: "code without a shebang",
+			args: []string{"--code", "echo hi"},
+			want: "echo hi",
+		},
+		{
+			name: "file with a shebang",
+			args: []string{"--from-file", withShebang},
+			want: "#!/bin/sh\necho hi\n",
+		},
+		{
+			name: "file without a shebang",
+			args: []string{"--from-file", withoutShebang},
+			err:  "shebang",
+		},
+		{
+			name:  "stdin without a shebang",
+			args:  []string{"--from-file", "-"},
+			stdin: "echo hi\n",
+			err:   "shebang",
+		},
+		{
+			name: "code and file",
+			args: []string{"--code", "echo hi", "--from-file", withShebang},
+			err:  "can't be used together",
+		},
+		{
+			name: "no code",
+			err:  "must be given with",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var code []byte
+			cmd := &cli.Command{
+				Name:   "create",
+				Reader: strings.NewReader(tt.stdin),
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: codeFlag},
+					&cli.StringFlag{Name: fromFileFlag},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					var err error
+					code, err = scriptCode(cmd)
+					return err
+				},
+			}
+
+			err := cmd.Run(context.Background(), append([]string{"create"}, tt.args...))
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("expected error containing %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(code) != tt.want {
+				t.Fatalf("expected code %q, got %q", tt.want, code)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/zalando/go-keyring"
+)
+
+const (
+	secretStoreFlag   = "secret-store"
+	secretKeyFileFlag = "secret-key-file"
+)
+
+const (
+	secretStoreAuto          = "auto"
+	secretStoreSecretService = "secret-service"
+	secretStorePass          = "pass"
+	secretStoreFile          = "file"
+	secretStoreNone          = "none"
+)
+
+// secretService is the service name credentials are stored under in the
+// Secret Service, and the directory they're stored in by pass.
+const secretService = "landscape-api"
+
+var errSecretNotFound = errors.New("secret not found")
+
+// openSecretStore opens the store selected with --secret-store. Tests replace
+// it so they never touch the real Secret Service or pass.
+var openSecretStore = newSecretStore
+
+// secretStore stores the secret keys and passwords the CLI logs in with.
+type secretStore interface {
+	// Get returns the secret stored under key, or errSecretNotFound.
+	Get(key string) (string, error)
+	Set(key, secret string) error
+	// Delete removes the secret stored under key. Removing a secret that
+	// isn't stored is not an error.
+	Delete(key string) error
+	String() string
+}
+
+// newSecretStore returns the store selected with --secret-store. "auto" picks
+// the Secret Service; it returns nil for "none".
+func newSecretStore(name string) (secretStore, error) {
+	switch name {
+	case "", secretStoreAuto, secretStoreSecretService:
+		return secretServiceStore{}, nil
+	case secretStorePass:
+		return passStore{}, nil
+	case secretStoreFile:
+		dir := os.Getenv("LANDSCAPE_SECRET_STORE_DIR")
+		if dir == "" {
+			configDir, err := os.UserConfigDir()
+			if err != nil {
+				return nil, err
+			}
+			dir = filepath.Join(configDir, "landscape-api", "secrets")
+		}
+		return fileStore{dir: dir}, nil
+	case secretStoreNone:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown secret store %q, must be one of auto, secret-service, pass, file or none", name)
+	}
+}
+
+// secretKeyName is the key the secret for a login is stored under, ex.
+// landscape.example.com/access-key/XXXXX.
+func secretKeyName(baseURL, kind, user string) string {
+	host := baseURL
+	if u, err := url.Parse(baseURL); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	return sanitizeSecretKeyPart(host) + "/" + kind + "/" + sanitizeSecretKeyPart(user)
+}
+
+func sanitizeSecretKeyPart(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', strings.ContainsRune("@.-_+", r):
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+}
+
+// secretServiceStore uses the freedesktop Secret Service (ex. GNOME Keyring
+// or KWallet) on Linux, and the platform keychain elsewhere.
+type secretServiceStore struct{}
+
+func (secretServiceStore) Get(key string) (string, error) {
+	secret, err := keyring.Get(secretService, key)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", errSecretNotFound
+	}
+	return secret, err
+}
+
+func (secretServiceStore) Set(key, secret string) error {
+	return keyring.Set(secretService, key, secret)
+}
+
+func (secretServiceStore) Delete(key string) error {
+	err := keyring.Delete(secretService, key)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (secretServiceStore) String() string {
+	return "the Secret Service"
+}
+
+// passStore uses pass (https://www.passwordstore.org), which encrypts each
+// secret with GPG. It honors pass's own settings, like PASSWORD_STORE_DIR.
+type passStore struct{}
+
+func (passStore) Get(key string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("pass", "show", secretService+"/"+key)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if strings.Contains(stderr.String(), "is not in the password store") {
+			return "", errSecretNotFound
+		}
+		return "", fmt.Errorf("pass show failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	// Like pass -c, only the first line is the secret.
+	secret, _, _ := strings.Cut(stdout.String(), "\n")
+	return secret, nil
+}
+
+func (passStore) Set(key, secret string) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command("pass", "insert", "--multiline", "--force", secretService+"/"+key)
+	cmd.Stdin = strings.NewReader(secret + "\n")
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("pass insert failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+func (passStore) Delete(key string) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command("pass", "rm", "--force", secretService+"/"+key)
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if strings.Contains(stderr.String(), "is not in the password store") {
+			return nil
+		}
+		return fmt.Errorf("pass rm failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+func (passStore) String() string {
+	return "pass"
+}
+
+// fileStore keeps secrets unencrypted in files only the current user can
+// read. It's meant for tests and headless machines without a Secret Service.
+type fileStore struct {
+	dir string
+}
+
+func (s fileStore) Get(key string) (string, error) {
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", errSecretNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\n"), nil
+}
+
+func (s fileStore) Set(key, secret string) error {
+	path := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(secret+"\n"), 0o600)
+}
+
+func (s fileStore) Delete(key string) error {
+	if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s fileStore) String() string {
+	return s.dir
+}
+
+func (s fileStore) path(key string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(key))
+}
+
+// readSecretFile reads a secret from a file, ignoring the trailing newline.
+func readSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret file: %w", err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/urfave/cli/v3"
+)
+
+// memorySecretStore is a secretStore kept in memory.
+type memorySecretStore struct {
+	secrets map[string]string
+	err     error
+}
+
+func (s *memorySecretStore) Get(key string) (string, error) {
+	if s.err != nil {
+		return "", s.err
+	}
+	secret, ok := s.secrets[key]
+	if !ok {
+		return "", errSecretNotFound
+	}
+	return secret, nil
+}
+
+func (s *memorySecretStore) Set(key, secret string) error {
+	s.secrets[key] = secret
+	return nil
+}
+
+func (s *memorySecretStore) Delete(key string) error {
+	delete(s.secrets, key)
+	return nil
+}
+
+func (s *memorySecretStore) String() string {
+	return "memory"
+}
+
+// useSecretStore makes the CLI use store for the rest of the test, for every
+// --secret-store but none.
+func useSecretStore(t *testing.T, store secretStore) {
+	t.Helper()
+	orig := openSecretStore
+	t.Cleanup(func() { openSecretStore = orig })
+
+	openSecretStore = func(name string) (secretStore, error) {
+		if name == secretStoreNone {
+			return nil, nil
+		}
+		return store, nil
+	}
+}
+
+// resolveTestSettings runs the root command with args and returns the
+// settings after resolving their secrets.
+func resolveTestSettings(t *testing.T, args ...string) (*settings, error) {
+	t.Helper()
+
+	var s *settings
+	var resolveErr error
+
+	root := rootCmd()
+	root.Commands = nil
+	root.Before = nil
+	root.Action = func(ctx context.Context, c *cli.Command) error {
+		var err error
+		if s, err = loadSettings(c); err != nil {
+			return err
+		}
+		resolveErr = s.resolveSecrets(c)
+		return nil
+	}
+
+	args = append([]string{"landscape-api", "--config", filepath.Join(t.TempDir(), "config.yaml"), "--base-url", "https://landscape.example.com"}, args...)
+	if err := root.Run(context.Background(), args); err != nil {
+		t.Fatalf("failed to run command: %v", err)
+	}
+	return s, resolveErr
+}
+
+func TestResolveSecrets(t *testing.T) {
+	secretKeyFile := filepath.Join(t.TempDir(), "secret-key")
+	if err := os.WriteFile(secretKeyFile, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatalf("failed to write secret key file: %v", err)
+	}
+
+	stored := map[string]string{
+		"landscape.example.com/access-key/AK":             "from-store",
+		"landscape.example.com/password/jane@example.com": "password-from-store",
+	}
+	storeErr := errors.New("store is locked")
+
+	tests := []struct {
+		name         string
+		args         []string
+		env          map[string]string
+		storeErr     error
+		wantKey      string
+		wantPassword string
+		err          string
+	}{
+		{
+			name:    "flag before env, file and store",
+			args:    []string{"-ak", "AK", "-sk", "from-flag", "--secret-key-file", secretKeyFile},
+			env:     map[string]string{"LANDSCAPE_SECRET_KEY": "from-env"},
+			wantKey: "from-flag",
+		},
+		{
+			name:    "env before file and store",
+			args:    []string{"-ak", "AK", "--secret-key-file", secretKeyFile},
+			env:     map[string]string{"LANDSCAPE_SECRET_KEY": "from-env"},
+			wantKey: "from-env",
+		},
+		{
+			name:    "file before store",
+			args:    []string{"-ak", "AK", "--secret-key-file", secretKeyFile},
+			wantKey: "from-file",
+		},
+		{
+			name:    "store",
+			args:    []string{"-ak", "AK"},
+			wantKey: "from-store",
+		},
+		{
+			name:         "password from store",
+			args:         []string{"-e", "jane@example.com"},
+			wantPassword: "password-from-store",
+		},
+		{
+			name: "access key not in store",
+			args: []string{"-ak", "OTHER"},
+		},
+		{
+			name: "no store",
+			args: []string{"-ak", "AK", "--secret-store", secretStoreNone},
+		},
+		{
+			name: "token skips the store",
+			args: []string{"-ak", "AK", "--token", "jwt"},
+		},
+		{
+			name:     "default store errors are ignored",
+			args:     []string{"-ak", "AK"},
+			storeErr: storeErr,
+		},
+		{
+			name:     "selected store errors are returned",
+			args:     []string{"-ak", "AK", "--secret-store", secretStoreFile},
+			storeErr: storeErr,
+			err:      "store is locked",
+		},
+		{
+			name: "missing secret key file",
+			args: []string{"-ak", "AK", "--secret-key-file", filepath.Join(t.TempDir(), "missing")},
+			err:  "failed to read secret file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"LANDSCAPE_SECRET_KEY", "LANDSCAPE_SECRET_KEY_FILE", "LANDSCAPE_PASSWORD", "LANDSCAPE_SECRET_STORE", "LANDSCAPE_TOKEN", "LANDSCAPE_TOKEN_FILE"} {
+				t.Setenv(name, tt.env[name])
+			}
+			useSecretStore(t, &memorySecretStore{secrets: stored, err: tt.storeErr})
+
+			s, err := resolveTestSettings(t, tt.args...)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("expected error containing %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if s.secretKey != tt.wantKey || s.password != tt.wantPassword {
+				t.Fatalf("expected secret key %q and password %q, got %q and %q", tt.wantKey, tt.wantPassword, s.secretKey, s.password)
+			}
+		})
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "secrets")
+	t.Setenv("LANDSCAPE_SECRET_STORE_DIR", dir)
+
+	store, err := newSecretStore(secretStoreFile)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	key := secretKeyName("https://landscape.example.com:8443/api", authMethodAccessKey, "AK")
+
+	t.Run("missing key", func(t *testing.T) {
+		if _, err := store.Get(key); !errors.Is(err, errSecretNotFound) {
+			t.Fatalf("expected errSecretNotFound, got %v", err)
+		}
+		if err := store.Delete(key); err != nil {
+			t.Fatalf("unexpected error deleting a missing key: %v", err)
+		}
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		if err := store.Set(key, "s3cret"); err != nil {
+			t.Fatalf("failed to set secret: %v", err)
+		}
+		got, err := store.Get(key)
+		if err != nil || got != "s3cret" {
+			t.Fatalf("expected s3cret, got %q: %v", got, err)
+		}
+
+		if err := store.Set(key, "rotated"); err != nil {
+			t.Fatalf("failed to replace secret: %v", err)
+		}
+		if got, err := store.Get(key); err != nil || got != "rotated" {
+			t.Fatalf("expected rotated, got %q: %v", got, err)
+		}
+
+		if err := store.Delete(key); err != nil {
+			t.Fatalf("failed to delete secret: %v", err)
+		}
+		if _, err := store.Get(key); !errors.Is(err, errSecretNotFound) {
+			t.Fatalf("expected errSecretNotFound after delete, got %v", err)
+		}
+	})
+
+	t.Run("permissions", func(t *testing.T) {
+		if err := store.Set(key, "s3cret"); err != nil {
+			t.Fatalf("failed to set secret: %v", err)
+		}
+
+		path := filepath.Join(dir, filepath.FromSlash(key))
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("secret wasn't written to %s: %v", path, err)
+		}
+		if perm := info.Mode().Perm(); perm != 0o600 {
+			t.Fatalf("expected the secret file to be 0600, got %o", perm)
+		}
+
+		info, err = os.Stat(filepath.Dir(path))
+		if err != nil {
+			t.Fatalf("failed to stat the secret's directory: %v", err)
+		}
+		if perm := info.Mode().Perm(); perm != 0o700 {
+			t.Fatalf("expected the secret's directory to be 0700, got %o", perm)
+		}
+	})
+}
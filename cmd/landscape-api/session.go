@@ -0,0 +1,168 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jansdhillon/landscape-go-api-client/client"
+	"github.com/urfave/cli/v3"
+)
+
+var whoamiCmd = &cli.Command{
+	Name:     "whoami",
+	Usage:    "Log in and show the user, the account logged into, and the other accounts available.",
+	Metadata: map[string]any{noAPIClientKey: true},
+	Action:   whoamiAction,
+}
+
+var accountsCmd = &cli.Command{
+	Name:     "accounts",
+	Usage:    "Manage the accounts the user can log into.",
+	Metadata: map[string]any{noAPIClientKey: true},
+	Commands: []*cli.Command{
+		{
+			Name:   "list",
+			Usage:  "List the accounts available to the user.",
+			Action: listAccountsAction,
+		},
+		{
+			Name:      "use",
+			Usage:     "Log into another account by default, by saving it in the selected context.",
+			ArgsUsage: "[account]",
+			Action:    useAccountAction,
+		},
+	},
+}
+
+// accountEntry is an account as listed by accounts list.
+type accountEntry struct {
+	client.LoginAccount
+	Current bool `json:"current"`
+}
+
+// loginSession logs in with the credentials from the root command's flags,
+// without using the token cache, and returns the session.
+func loginSession(ctx context.Context, cmd *cli.Command) (*client.Session, error) {
+	root := cmd.Root()
+
+	s, err := loadSettings(root)
+	if err != nil {
+		return nil, err
+	}
+	if s.baseURL == "" {
+		return nil, fmt.Errorf("base URL must be provided")
+	}
+	if err := s.resolveSecrets(root); err != nil {
+		return nil, err
+	}
+
+	return settingsSession(ctx, root, s)
+}
+
+// settingsSession logs in with the credentials in s and returns the session.
+func settingsSession(ctx context.Context, root *cli.Command, s *settings) (*client.Session, error) {
+	// Only the login endpoints say who the user is and which accounts they
+	// have; a token obtained elsewhere doesn't.
+	if s.token != "" || s.tokenFile != "" {
+		return nil, fmt.Errorf("this command needs to log in to get the user and their accounts, which isn't supported with --%s or --%s; use an access key, password or OIDC instead", tokenFlag, tokenFileFlag)
+	}
+
+	lp, err := s.loginProvider(root.ErrWriter)
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := clientOptions(root, s)
+	if err != nil {
+		return nil, err
+	}
+
+	api, err := client.NewClientWithResponses(s.baseURL, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Login(ctx, api, lp)
+}
+
+func whoamiAction(ctx context.Context, cmd *cli.Command) error {
+	session, err := loginSession(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	out, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(cmd, out)
+}
+
+func listAccountsAction(ctx context.Context, cmd *cli.Command) error {
+	session, err := loginSession(ctx, cmd)
+	if err != nil {
+		return err
+	}
+
+	accounts := make([]accountEntry, len(session.Accounts))
+	for i, account := range session.Accounts {
+		accounts[i] = accountEntry{
+			LoginAccount: account,
+			Current:      account.Name == session.CurrentAccount,
+		}
+	}
+
+	out, err := json.Marshal(accounts)
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(cmd, out)
+}
+
+func useAccountAction(ctx context.Context, cmd *cli.Command) error {
+	name := cmd.Args().First()
+	if name == "" {
+		return fmt.Errorf("account name must be provided as the first argument")
+	}
+
+	root := cmd.Root()
+	path := root.String(configFlag)
+	cfg, err := loadConfig(path)
+	if err != nil {
+		return err
+	}
+
+	contextName := root.String(contextFlag)
+	if contextName == "" {
+		contextName = cfg.CurrentContext
+	}
+	lctx, err := cfg.context(contextName)
+	if err != nil {
+		return err
+	}
+	if lctx == nil {
+		return fmt.Errorf("no context selected; create one with config set, or pass --%s instead", accountFlag)
+	}
+
+	session, err := loginSession(ctx, cmd)
+	if err != nil {
+		return err
+	}
+	if _, ok := session.Account(name); !ok {
+		return fmt.Errorf("no account named %q; available accounts: %s", name, strings.Join(session.AccountNames(), ", "))
+	}
+
+	lctx.Account = name
+	if err := cfg.save(path); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(root.Writer, "Context %q now logs into account %q.\n", contextName, name)
+	return nil
+}
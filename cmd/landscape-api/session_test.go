@@ -0,0 +1,24 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWhoamiWithToken(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+
+	for _, args := range [][]string{
+		{"--token", "jwt", "whoami"},
+		{"--token-file", tokenFile, "accounts", "list"},
+	} {
+		args = append([]string{"landscape-api", "--config", filepath.Join(t.TempDir(), "config.yaml"), "--base-url", "https://landscape.example.com"}, args...)
+
+		err := rootCmd().Run(context.Background(), args)
+		if err == nil || !strings.Contains(err.Error(), "isn't supported with --token or --token-file") {
+			t.Fatalf("expected %v to fail because of the token, got %v", args[5:], err)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v3"
+)
+
+// tlsConfig builds the TLS configuration from the CA cert, client cert and
+// TLS flags. It returns nil if none of them were given, so the default
+// transport is used as it is.
+func tlsConfig(c *cli.Command, s *settings) (*tls.Config, error) {
+	insecure := c.Bool(insecureSkipVerifyFlag)
+	if s.caCert == "" && s.clientCert == "" && s.clientKey == "" && s.tlsServerName == "" && !insecure {
+		return nil, nil
+	}
+
+	config := &tls.Config{
+		ServerName: s.tlsServerName,
+	}
+
+	if s.caCert != "" {
+		pemData, err := os.ReadFile(s.caCert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA cert file: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pemData) {
+			return nil, fmt.Errorf("failed to parse CA cert: invalid PEM data")
+		}
+		config.RootCAs = pool
+	}
+
+	if s.clientCert != "" || s.clientKey != "" {
+		if s.clientCert == "" || s.clientKey == "" {
+			return nil, fmt.Errorf("--%s and --%s must be provided together", clientCertFlag, clientKeyFlag)
+		}
+		cert, err := tls.LoadX509KeyPair(s.clientCert, s.clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	if insecure {
+		fmt.Fprintln(c.ErrWriter, "WARNING: --insecure-skip-verify is set. The server's TLS certificate is NOT being verified, so anyone on the network path can impersonate Landscape and read your credentials. Only use this for testing.")
+		config.InsecureSkipVerify = true
+	}
+
+	return config, nil
+}